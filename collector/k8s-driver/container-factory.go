@@ -0,0 +1,74 @@
+package k8sdriver
+
+import (
+	"github.com/walmartdigital/katalog/domain"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// buildContainersFromK8sPodSpec returns the init and regular containers of a
+// pod template, init containers first as they run first
+func buildContainersFromK8sPodSpec(spec corev1.PodSpec) []domain.Container {
+	containers := make([]domain.Container, 0, len(spec.InitContainers)+len(spec.Containers))
+	for _, c := range spec.InitContainers {
+		container := buildContainerFromK8sContainer(c)
+		container.Init = true
+		containers = append(containers, container)
+	}
+	for _, c := range spec.Containers {
+		containers = append(containers, buildContainerFromK8sContainer(c))
+	}
+	return containers
+}
+
+func buildContainerFromK8sContainer(source corev1.Container) domain.Container {
+	container := domain.Container{
+		Name:            source.Name,
+		Image:           source.Image,
+		ImagePullPolicy: string(source.ImagePullPolicy),
+		Requests:        buildResourcesFromK8sResourceList(source.Resources.Requests),
+		Limits:          buildResourcesFromK8sResourceList(source.Resources.Limits),
+		LivenessProbe:   buildProbeFromK8sProbe(source.LivenessProbe),
+		ReadinessProbe:  buildProbeFromK8sProbe(source.ReadinessProbe),
+		StartupProbe:    buildProbeFromK8sProbe(source.StartupProbe),
+	}
+	for _, p := range source.Ports {
+		container.Ports = append(container.Ports, domain.ContainerPort{
+			Name:          p.Name,
+			ContainerPort: p.ContainerPort,
+			Protocol:      string(p.Protocol),
+		})
+	}
+	return container
+}
+
+func buildResourcesFromK8sResourceList(list corev1.ResourceList) *domain.Resources {
+	if len(list) == 0 {
+		return nil
+	}
+	resources := &domain.Resources{}
+	if cpu, ok := list[corev1.ResourceCPU]; ok {
+		resources.CPU = cpu.String()
+	}
+	if memory, ok := list[corev1.ResourceMemory]; ok {
+		resources.Memory = memory.String()
+	}
+	if resources.CPU == "" && resources.Memory == "" {
+		return nil
+	}
+	return resources
+}
+
+func buildProbeFromK8sProbe(probe *corev1.Probe) *domain.Probe {
+	if probe == nil {
+		return nil
+	}
+	switch {
+	case probe.Exec != nil:
+		return &domain.Probe{Type: domain.ProbeExec}
+	case probe.HTTPGet != nil:
+		return &domain.Probe{Type: domain.ProbeHTTPGet}
+	case probe.TCPSocket != nil:
+		return &domain.Probe{Type: domain.ProbeTCPSocket}
+	}
+	return &domain.Probe{}
+}
@@ -0,0 +1,76 @@
+package k8sdriver
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/walmartdigital/katalog/domain"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("Container builder", func() {
+
+	It("should build the init and regular containers of a pod template", func() {
+		containers := buildContainersFromK8sPodSpec(buildPodSpec())
+
+		Expect(containers).To(Equal([]domain.Container{
+			{
+				Name:  "migrations",
+				Image: "registry.example.com/migrations:1.0",
+				Init:  true,
+			},
+			{
+				Name:            "api",
+				Image:           "registry.example.com/api:2.3",
+				ImagePullPolicy: "IfNotPresent",
+				Requests:        &domain.Resources{CPU: "250m", Memory: "128Mi"},
+				Limits:          &domain.Resources{Memory: "256Mi"},
+				Ports:           []domain.ContainerPort{{Name: "http", ContainerPort: 8080, Protocol: "TCP"}},
+				LivenessProbe:   &domain.Probe{Type: domain.ProbeHTTPGet},
+				ReadinessProbe:  &domain.Probe{Type: domain.ProbeTCPSocket},
+				StartupProbe:    &domain.Probe{Type: domain.ProbeExec},
+			},
+		}))
+	})
+
+	It("should keep only the regular containers in the compatibility map", func() {
+		containers := buildContainersFromK8sPodSpec(buildPodSpec())
+
+		Expect(domain.ContainersByName(containers)).To(Equal(map[string]string{"api": "registry.example.com/api:2.3"}))
+	})
+
+})
+
+func buildPodSpec() corev1.PodSpec {
+	return corev1.PodSpec{
+		InitContainers: []corev1.Container{{
+			Name:  "migrations",
+			Image: "registry.example.com/migrations:1.0",
+		}},
+		Containers: []corev1.Container{{
+			Name:            "api",
+			Image:           "registry.example.com/api:2.3",
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("250m"),
+					corev1.ResourceMemory: resource.MustParse("128Mi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("256Mi"),
+				},
+			},
+			Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080, Protocol: corev1.ProtocolTCP}},
+			LivenessProbe: &corev1.Probe{Handler: corev1.Handler{
+				HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt(8080)},
+			}},
+			ReadinessProbe: &corev1.Probe{Handler: corev1.Handler{
+				TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(8080)},
+			}},
+			StartupProbe: &corev1.Probe{Handler: corev1.Handler{
+				Exec: &corev1.ExecAction{Command: []string{"cat", "/tmp/ready"}},
+			}},
+		}},
+	}
+}
@@ -0,0 +1,61 @@
+package k8sdriver
+
+import (
+	"time"
+
+	"github.com/walmartdigital/katalog/domain"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BuildCronJobFromK8sCronJob ...
+func buildCronJobFromK8sCronJob(sourceCronJob *batchv1beta1.CronJob, jobs []batchv1.Job) domain.CronJob {
+	containers := buildContainersFromK8sPodSpec(sourceCronJob.Spec.JobTemplate.Spec.Template.Spec)
+
+	suspend := false
+	if sourceCronJob.Spec.Suspend != nil {
+		suspend = *sourceCronJob.Spec.Suspend
+	}
+
+	destinationCronJob := &domain.CronJob{
+		ID:                 string(sourceCronJob.GetUID()),
+		Name:               sourceCronJob.GetName(),
+		Generation:         sourceCronJob.GetGeneration(),
+		Namespace:          sourceCronJob.GetNamespace(),
+		Labels:             sourceCronJob.GetLabels(),
+		Annotations:        sourceCronJob.GetAnnotations(),
+		OwnerReferences:    buildOwnerReferencesFromK8sOwnerReferences(sourceCronJob.GetOwnerReferences()),
+		PodLabels:          sourceCronJob.Spec.JobTemplate.Spec.Template.GetLabels(),
+		Containers:         domain.ContainersByName(containers),
+		ContainerSpecs:     containers,
+		Schedule:           sourceCronJob.Spec.Schedule,
+		Suspend:            suspend,
+		ConcurrencyPolicy:  string(sourceCronJob.Spec.ConcurrencyPolicy),
+		LastScheduleTime:   formatK8sTime(sourceCronJob.Status.LastScheduleTime),
+		LastSuccessfulTime: formatK8sTime(lastSuccessfulCompletion(sourceCronJob, jobs)),
+		Timestamp:          time.Now().UTC().Format(collectedFormat),
+		ObservedGeneration: 0,
+	}
+
+	return *destinationCronJob
+}
+
+// lastSuccessfulCompletion returns the most recent completion time among the
+// succeeded jobs owned by the given cronjob, or nil if none has succeeded yet.
+func lastSuccessfulCompletion(cronJob *batchv1beta1.CronJob, jobs []batchv1.Job) *metav1.Time {
+	var last *metav1.Time
+	for i := range jobs {
+		job := &jobs[i]
+		if !isOwnedBy(job.GetOwnerReferences(), cronJob.GetUID()) {
+			continue
+		}
+		if job.Status.Succeeded <= 0 || job.Status.CompletionTime == nil {
+			continue
+		}
+		if last == nil || last.Before(job.Status.CompletionTime) {
+			last = job.Status.CompletionTime
+		}
+	}
+	return last
+}
@@ -0,0 +1,99 @@
+package k8sdriver
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/walmartdigital/katalog/domain"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("CronJob builder struct", func() {
+
+	BeforeEach(func() {})
+
+	It("should build a CronJob object when pass k8sCronJob resource", func() {
+		cronJob := buildCronJobFromK8sCronJob(buildCronJob(), []batchv1.Job{})
+
+		Expect(cronJob.GetID()).To(Equal("UIDExample"))
+		Expect(cronJob.GetGeneration()).To(Equal(int64(5)))
+		Expect(cronJob.GetName()).To(Equal("NameExample"))
+		Expect(cronJob.GetNamespace()).To(Equal("NameSpaceExample"))
+		Expect(cronJob.GetLabels()).To(Equal(map[string]string{"keyLabelExample": "valueLabelExample"}))
+		Expect(cronJob.GetAnnotations()).To(Equal(map[string]string{"keyAnnotationsExample": "valueAnnotationsExample"}))
+		Expect(cronJob.GetContainers()).To(Equal(map[string]string{"containerNameExample": "containerImageExample"}))
+		Expect(cronJob.GetContainerSpecs()).To(Equal([]domain.Container{{Name: "containerNameExample", Image: "containerImageExample"}}))
+		Expect(cronJob.GetSchedule()).To(Equal("*/5 * * * *"))
+		Expect(cronJob.IsSuspended()).To(BeTrue())
+		Expect(cronJob.GetConcurrencyPolicy()).To(Equal("Forbid"))
+		Expect(cronJob.GetLastScheduleTime()).To(Equal("2020-10-01 10:00:00"))
+		Expect(cronJob.GetLastSuccessfulTime()).To(Equal(""))
+		Expect(cronJob.GetTimestamp()).Should(MatchRegexp(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}`))
+	})
+
+	It("should take the last successful completion from the jobs it owns", func() {
+		jobs := []batchv1.Job{
+			buildCompletedJob("UIDExample", 1, time.Date(2020, 10, 1, 9, 0, 0, 0, time.UTC)),
+			buildCompletedJob("UIDExample", 1, time.Date(2020, 10, 1, 9, 30, 0, 0, time.UTC)),
+			buildCompletedJob("UIDExample", 0, time.Date(2020, 10, 1, 9, 45, 0, 0, time.UTC)),
+			buildCompletedJob("OtherUID", 1, time.Date(2020, 10, 1, 9, 50, 0, 0, time.UTC)),
+		}
+
+		cronJob := buildCronJobFromK8sCronJob(buildCronJob(), jobs)
+
+		Expect(cronJob.GetLastSuccessfulTime()).To(Equal("2020-10-01 09:30:00"))
+	})
+
+})
+
+func buildCronJob() *batchv1beta1.CronJob {
+	suspend := true
+	lastSchedule := metav1.NewTime(time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC))
+	return &batchv1beta1.CronJob{
+		TypeMeta: metav1.TypeMeta{},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "NameExample",
+			Namespace:   "NameSpaceExample",
+			UID:         "UIDExample",
+			Generation:  5,
+			Labels:      map[string]string{"keyLabelExample": "valueLabelExample"},
+			Annotations: map[string]string{"keyAnnotationsExample": "valueAnnotationsExample"},
+		},
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule:          "*/5 * * * *",
+			Suspend:           &suspend,
+			ConcurrencyPolicy: batchv1beta1.ForbidConcurrent,
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Name:  "containerNameExample",
+								Image: "containerImageExample",
+							}},
+						},
+					},
+				},
+			},
+		},
+		Status: batchv1beta1.CronJobStatus{LastScheduleTime: &lastSchedule},
+	}
+}
+
+func buildCompletedJob(ownerUID string, succeeded int32, completion time.Time) batchv1.Job {
+	completionTime := metav1.NewTime(completion)
+	return batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			OwnerReferences: []metav1.OwnerReference{{Kind: "CronJob", UID: types.UID(ownerUID)}},
+		},
+		Status: batchv1.JobStatus{
+			Succeeded:      succeeded,
+			CompletionTime: &completionTime,
+		},
+	}
+}
@@ -0,0 +1,120 @@
+package k8sdriver
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+// CustomResourceConfig names a GroupVersionResource the collector watches
+// through the dynamic client. Fields maps the name of each extracted field to
+// a JSONPath expression evaluated against the object, e.g.
+// replicas: .spec.replicas
+type CustomResourceConfig struct {
+	Group    string            `json:"group"`
+	Version  string            `json:"version"`
+	Resource string            `json:"resource"`
+	Fields   map[string]string `json:"fields,omitempty"`
+}
+
+// GroupVersionResource ...
+func (c CustomResourceConfig) GroupVersionResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: c.Group, Version: c.Version, Resource: c.Resource}
+}
+
+// LoadCustomResourceConfigs reads the YAML or JSON list of custom resources
+// to watch and validates their JSONPath expressions.
+func LoadCustomResourceConfigs(path string) ([]CustomResourceConfig, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var configs []CustomResourceConfig
+	if err := yaml.Unmarshal(content, &configs); err != nil {
+		return nil, fmt.Errorf("invalid custom resources config %s: %v", path, err)
+	}
+
+	for _, config := range configs {
+		if _, err := buildCustomResourceWatch(config); err != nil {
+			return nil, err
+		}
+	}
+
+	return configs, nil
+}
+
+// customResourceWatch holds the parsed JSONPath expressions of a watched
+// custom resource. JSONPath keeps state while evaluating, hence the mutex.
+type customResourceWatch struct {
+	config CustomResourceConfig
+	fields map[string]*jsonpath.JSONPath
+	mutex  sync.Mutex
+}
+
+func buildCustomResourceWatch(config CustomResourceConfig) (*customResourceWatch, error) {
+	if config.Version == "" || config.Resource == "" {
+		return nil, fmt.Errorf("custom resource %q of group %q needs a version and a resource", config.Resource, config.Group)
+	}
+
+	watch := &customResourceWatch{
+		config: config,
+		fields: make(map[string]*jsonpath.JSONPath),
+	}
+
+	for name, expression := range config.Fields {
+		if !strings.HasPrefix(expression, "{") {
+			expression = "{" + expression + "}"
+		}
+		parser := jsonpath.New(name).AllowMissingKeys(true)
+		if err := parser.Parse(expression); err != nil {
+			return nil, fmt.Errorf("invalid JSONPath for field %s of %s: %v", name, config.GroupVersionResource(), err)
+		}
+		watch.fields[name] = parser
+	}
+
+	return watch, nil
+}
+
+// extractFields evaluates the configured expressions against the object.
+// Missing fields are left out, fields matching several values become lists.
+func (w *customResourceWatch) extractFields(object map[string]interface{}) map[string]interface{} {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	fields := make(map[string]interface{})
+	for name, parser := range w.fields {
+		results, err := parser.FindResults(object)
+		if err != nil {
+			log.Debugf("field %s of %s not extracted: %v", name, w.config.GroupVersionResource(), err)
+			continue
+		}
+
+		var values []interface{}
+		for _, result := range results {
+			for _, value := range result {
+				if value.CanInterface() {
+					values = append(values, value.Interface())
+				}
+			}
+		}
+
+		switch len(values) {
+		case 0:
+		case 1:
+			fields[name] = values[0]
+		default:
+			fields[name] = values
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
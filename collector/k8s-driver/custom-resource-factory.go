@@ -0,0 +1,34 @@
+package k8sdriver
+
+import (
+	"time"
+
+	"github.com/walmartdigital/katalog/domain"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// buildCustomResourceFromUnstructured ...
+func buildCustomResourceFromUnstructured(source *unstructured.Unstructured, watch *customResourceWatch) domain.CustomResource {
+	gvr := watch.config.GroupVersionResource()
+	observedGeneration, _, _ := unstructured.NestedInt64(source.Object, "status", "observedGeneration")
+
+	destinationCustomResource := &domain.CustomResource{
+		ID:                 string(source.GetUID()),
+		Name:               source.GetName(),
+		Group:              gvr.Group,
+		Version:            gvr.Version,
+		Kind:               source.GetKind(),
+		Resource:           gvr.Resource,
+		APIVersion:         source.GetAPIVersion(),
+		Generation:         source.GetGeneration(),
+		Namespace:          source.GetNamespace(),
+		Labels:             source.GetLabels(),
+		Annotations:        source.GetAnnotations(),
+		OwnerReferences:    buildOwnerReferencesFromK8sOwnerReferences(source.GetOwnerReferences()),
+		Fields:             watch.extractFields(source.Object),
+		Timestamp:          time.Now().UTC().Format(collectedFormat),
+		ObservedGeneration: observedGeneration,
+	}
+
+	return *destinationCustomResource
+}
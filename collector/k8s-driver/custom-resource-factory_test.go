@@ -0,0 +1,118 @@
+package k8sdriver
+
+import (
+	"io/ioutil"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("CustomResource builder struct", func() {
+
+	var watch *customResourceWatch
+
+	BeforeEach(func() {
+		var err error
+		watch, err = buildCustomResourceWatch(CustomResourceConfig{
+			Group:    "argoproj.io",
+			Version:  "v1alpha1",
+			Resource: "rollouts",
+			Fields: map[string]string{
+				"replicas": ".spec.replicas",
+				"images":   "{.spec.template.spec.containers[*].image}",
+				"phase":    ".status.phase",
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should build a CustomResource object when pass an unstructured resource", func() {
+		customResource := buildCustomResourceFromUnstructured(buildRollout(), watch)
+
+		Expect(customResource.GetID()).To(Equal("UIDExample"))
+		Expect(customResource.GetName()).To(Equal("NameExample"))
+		Expect(customResource.GetNamespace()).To(Equal("NameSpaceExample"))
+		Expect(customResource.GetGeneration()).To(Equal(int64(3)))
+		Expect(customResource.GetObservedGeneration()).To(Equal(int64(2)))
+		Expect(customResource.GetGroup()).To(Equal("argoproj.io"))
+		Expect(customResource.GetKind()).To(Equal("Rollout"))
+		Expect(customResource.GetResource()).To(Equal("rollouts"))
+		Expect(customResource.APIVersion).To(Equal("argoproj.io/v1alpha1"))
+		Expect(customResource.GetLabels()).To(Equal(map[string]string{"keyLabelExample": "valueLabelExample"}))
+		Expect(customResource.GetTimestamp()).Should(MatchRegexp(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}`))
+	})
+
+	It("should extract the configured fields and leave the missing ones out", func() {
+		customResource := buildCustomResourceFromUnstructured(buildRollout(), watch)
+
+		Expect(customResource.GetFields()).To(Equal(map[string]interface{}{
+			"replicas": int64(4),
+			"images":   []interface{}{"api:1.0", "sidecar:2.1"},
+		}))
+	})
+
+	It("should reject invalid JSONPath expressions and incomplete resources", func() {
+		_, err := buildCustomResourceWatch(CustomResourceConfig{Version: "v1", Resource: "apps", Fields: map[string]string{"bad": "{.spec["}})
+		Expect(err).To(HaveOccurred())
+
+		_, err = buildCustomResourceWatch(CustomResourceConfig{Group: "example.com", Resource: "apps"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should load the custom resources to watch from a YAML file", func() {
+		file, err := ioutil.TempFile("", "custom-resources-*.yaml")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.Remove(file.Name())
+		_, err = file.WriteString(`
+- group: argoproj.io
+  version: v1alpha1
+  resource: rollouts
+  fields:
+    replicas: .spec.replicas
+- group: serving.knative.dev
+  version: v1
+  resource: services
+`)
+		Expect(err).NotTo(HaveOccurred())
+		file.Close()
+
+		configs, err := LoadCustomResourceConfigs(file.Name())
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configs).To(HaveLen(2))
+		Expect(configs[0].GroupVersionResource().String()).To(Equal("argoproj.io/v1alpha1, Resource=rollouts"))
+		Expect(configs[0].Fields).To(Equal(map[string]string{"replicas": ".spec.replicas"}))
+		Expect(configs[1].Resource).To(Equal("services"))
+	})
+
+})
+
+func buildRollout() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Rollout",
+		"metadata": map[string]interface{}{
+			"name":       "NameExample",
+			"namespace":  "NameSpaceExample",
+			"uid":        "UIDExample",
+			"generation": int64(3),
+			"labels":     map[string]interface{}{"keyLabelExample": "valueLabelExample"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(4),
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "api", "image": "api:1.0"},
+						map[string]interface{}{"name": "sidecar", "image": "sidecar:2.1"},
+					},
+				},
+			},
+		},
+		"status": map[string]interface{}{
+			"observedGeneration": int64(2),
+		},
+	}}
+}
@@ -0,0 +1,30 @@
+package k8sdriver
+
+import (
+	"time"
+
+	"github.com/walmartdigital/katalog/domain"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// BuildDaemonSetFromK8sDaemonSet ...
+func buildDaemonSetFromK8sDaemonSet(sourceDaemonSet *appsv1.DaemonSet) domain.DaemonSet {
+	containers := buildContainersFromK8sPodSpec(sourceDaemonSet.Spec.Template.Spec)
+
+	destinationDaemonSet := &domain.DaemonSet{
+		ID:                 string(sourceDaemonSet.GetUID()),
+		Name:               sourceDaemonSet.GetName(),
+		Generation:         sourceDaemonSet.GetGeneration(),
+		Namespace:          sourceDaemonSet.GetNamespace(),
+		Labels:             sourceDaemonSet.GetLabels(),
+		Annotations:        sourceDaemonSet.GetAnnotations(),
+		OwnerReferences:    buildOwnerReferencesFromK8sOwnerReferences(sourceDaemonSet.GetOwnerReferences()),
+		PodLabels:          sourceDaemonSet.Spec.Template.GetLabels(),
+		Containers:         domain.ContainersByName(containers),
+		ContainerSpecs:     containers,
+		Timestamp:          time.Now().UTC().Format(collectedFormat),
+		ObservedGeneration: sourceDaemonSet.Status.ObservedGeneration,
+	}
+
+	return *destinationDaemonSet
+}
@@ -0,0 +1,55 @@
+package k8sdriver
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("DaemonSet builder struct", func() {
+
+	BeforeEach(func() {})
+
+	It("should build a DaemonSet object when pass k8sDaemonSet resource", func() {
+		daemonSet := buildDaemonSetFromK8sDaemonSet(buildDaemonSet())
+
+		Expect(daemonSet.GetID()).To(Equal("UIDExample"))
+		Expect(daemonSet.GetObservedGeneration()).To(Equal(int64(1)))
+		Expect(daemonSet.GetGeneration()).To(Equal(int64(5)))
+		Expect(daemonSet.GetName()).To(Equal("NameExample"))
+		Expect(daemonSet.GetNamespace()).To(Equal("NameSpaceExample"))
+		Expect(daemonSet.GetLabels()).To(Equal(map[string]string{"keyLabelExample": "valueLabelExample"}))
+		Expect(daemonSet.GetAnnotations()).To(Equal(map[string]string{"keyAnnotationsExample": "valueAnnotationsExample"}))
+		Expect(daemonSet.GetContainers()).To(Equal(map[string]string{"containerNameExample": "containerImageExample"}))
+		Expect(daemonSet.GetTimestamp()).Should(MatchRegexp(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}`))
+	})
+
+})
+
+func buildDaemonSet() *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		TypeMeta: metav1.TypeMeta{},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "NameExample",
+			Namespace:   "NameSpaceExample",
+			UID:         "UIDExample",
+			Generation:  5,
+			Labels:      map[string]string{"keyLabelExample": "valueLabelExample"},
+			Annotations: map[string]string{"keyAnnotationsExample": "valueAnnotationsExample"},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "containerNameExample",
+						Image: "containerImageExample",
+					}},
+				},
+			},
+		},
+		Status: appsv1.DaemonSetStatus{ObservedGeneration: 1},
+	}
+}
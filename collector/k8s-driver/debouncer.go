@@ -0,0 +1,71 @@
+package k8sdriver
+
+import (
+	"sync"
+	"time"
+)
+
+// debouncer coalesces the triggers received for a key during a time window
+// into a single call, so bursts of events (e.g. a rolling restart) fire once.
+type debouncer struct {
+	delay   time.Duration
+	fire    func(key string)
+	pending map[string]*time.Timer
+	firing  sync.WaitGroup
+	mutex   sync.Mutex
+}
+
+func buildDebouncer(delay time.Duration, fire func(key string)) *debouncer {
+	return &debouncer{
+		delay:   delay,
+		fire:    fire,
+		pending: make(map[string]*time.Timer),
+	}
+}
+
+// Trigger schedules a call for the key unless one is already pending.
+func (d *debouncer) Trigger(key string) {
+	if d.delay <= 0 {
+		d.fire(key)
+		return
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if _, ok := d.pending[key]; ok {
+		return
+	}
+
+	d.pending[key] = time.AfterFunc(d.delay, func() {
+		d.mutex.Lock()
+		if _, ok := d.pending[key]; !ok {
+			d.mutex.Unlock()
+			return
+		}
+		delete(d.pending, key)
+		d.firing.Add(1)
+		d.mutex.Unlock()
+
+		defer d.firing.Done()
+		d.fire(key)
+	})
+}
+
+// Flush fires every pending key right away and waits for the calls in
+// progress, so nothing is left behind on shutdown.
+func (d *debouncer) Flush() {
+	d.mutex.Lock()
+	keys := make([]string, 0, len(d.pending))
+	for key, timer := range d.pending {
+		timer.Stop()
+		keys = append(keys, key)
+	}
+	d.pending = make(map[string]*time.Timer)
+	d.mutex.Unlock()
+
+	for _, key := range keys {
+		d.fire(key)
+	}
+	d.firing.Wait()
+}
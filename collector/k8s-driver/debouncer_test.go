@@ -0,0 +1,79 @@
+package k8sdriver
+
+import (
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Debouncer", func() {
+
+	var (
+		mutex sync.Mutex
+		fired map[string]int
+	)
+
+	countFired := func(key string) int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return fired[key]
+	}
+
+	BeforeEach(func() {
+		fired = make(map[string]int)
+	})
+
+	fire := func(key string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		fired[key]++
+	}
+
+	It("should coalesce triggers of the same key received during the window", func() {
+		debouncer := buildDebouncer(50*time.Millisecond, fire)
+
+		for i := 0; i < 100; i++ {
+			debouncer.Trigger("default/nginx")
+		}
+		debouncer.Trigger("default/redis")
+
+		Eventually(func() int { return countFired("default/nginx") }).Should(Equal(1))
+		Eventually(func() int { return countFired("default/redis") }).Should(Equal(1))
+		Consistently(func() int { return countFired("default/nginx") }, 150*time.Millisecond).Should(Equal(1))
+	})
+
+	It("should fire again for triggers received after the window", func() {
+		debouncer := buildDebouncer(20*time.Millisecond, fire)
+
+		debouncer.Trigger("default/nginx")
+		Eventually(func() int { return countFired("default/nginx") }).Should(Equal(1))
+
+		debouncer.Trigger("default/nginx")
+		Eventually(func() int { return countFired("default/nginx") }).Should(Equal(2))
+	})
+
+	It("should fire pending keys once when flushed", func() {
+		debouncer := buildDebouncer(time.Hour, fire)
+
+		debouncer.Trigger("default/nginx")
+		debouncer.Trigger("default/nginx")
+		debouncer.Trigger("default/redis")
+		debouncer.Flush()
+
+		Expect(countFired("default/nginx")).To(Equal(1))
+		Expect(countFired("default/redis")).To(Equal(1))
+		debouncer.Flush()
+		Expect(countFired("default/nginx")).To(Equal(1))
+	})
+
+	It("should fire immediately when no delay is configured", func() {
+		debouncer := buildDebouncer(0, fire)
+
+		debouncer.Trigger("default/nginx")
+
+		Expect(countFired("default/nginx")).To(Equal(1))
+	})
+
+})
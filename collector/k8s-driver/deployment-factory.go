@@ -9,11 +9,7 @@ import (
 
 // BuildDeploymentFromK8sDeployment ...
 func buildDeploymentFromK8sDeployment(sourceDeployment *appsv1.Deployment) domain.Deployment {
-	m := make(map[string]string)
-
-	for _, c := range sourceDeployment.Spec.Template.Spec.Containers {
-		m[c.Name] = c.Image
-	}
+	containers := buildContainersFromK8sPodSpec(sourceDeployment.Spec.Template.Spec)
 
 	destinationDeployment := &domain.Deployment{
 		ID:                 string(sourceDeployment.GetUID()),
@@ -22,8 +18,14 @@ func buildDeploymentFromK8sDeployment(sourceDeployment *appsv1.Deployment) domai
 		Namespace:          sourceDeployment.GetNamespace(),
 		Labels:             sourceDeployment.GetLabels(),
 		Annotations:        sourceDeployment.GetAnnotations(),
-		Containers:         m,
-		Timestamp:          time.Now().UTC().Format(timestampFormat),
+		OwnerReferences:    buildOwnerReferencesFromK8sOwnerReferences(sourceDeployment.GetOwnerReferences()),
+		PodLabels:          sourceDeployment.Spec.Template.GetLabels(),
+		Containers:         domain.ContainersByName(containers),
+		ContainerSpecs:     containers,
+		Replicas:           buildReplicasFromK8sDeployment(sourceDeployment),
+		Strategy:           buildStrategyFromK8sDeployment(sourceDeployment),
+		Conditions:         buildConditionsFromK8sDeployment(sourceDeployment),
+		Timestamp:          time.Now().UTC().Format(collectedFormat),
 		ObservedGeneration: sourceDeployment.Status.ObservedGeneration,
 	}
 
@@ -1,11 +1,15 @@
 package k8sdriver
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/walmartdigital/katalog/domain"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 var _ = Describe("Deployment builder struct", func() {
@@ -27,6 +31,42 @@ var _ = Describe("Deployment builder struct", func() {
 
 	})
 
+	It("should capture the replica counts, the strategy and the conditions", func() {
+		k8sDeployment := buildDeployment()
+		replicas := int32(3)
+		maxSurge := intstr.FromString("25%")
+		maxUnavailable := intstr.FromInt(1)
+		k8sDeployment.Spec.Replicas = &replicas
+		k8sDeployment.Spec.Strategy = appsv1.DeploymentStrategy{
+			Type:          appsv1.RollingUpdateDeploymentStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateDeployment{MaxSurge: &maxSurge, MaxUnavailable: &maxUnavailable},
+		}
+		k8sDeployment.Status.ReadyReplicas = 2
+		k8sDeployment.Status.AvailableReplicas = 2
+		k8sDeployment.Status.UpdatedReplicas = 3
+		k8sDeployment.Status.UnavailableReplicas = 1
+		k8sDeployment.Status.Conditions = []appsv1.DeploymentCondition{{
+			Type:               appsv1.DeploymentProgressing,
+			Status:             corev1.ConditionFalse,
+			Reason:             "ProgressDeadlineExceeded",
+			Message:            "ReplicaSet api-7d4b9 has timed out progressing.",
+			LastTransitionTime: metav1.NewTime(time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC)),
+		}}
+
+		deployment := buildDeploymentFromK8sDeployment(k8sDeployment)
+
+		Expect(deployment.GetReplicas()).To(Equal(&domain.Replicas{Desired: 3, Ready: 2, Available: 2, Updated: 3, Unavailable: 1}))
+		Expect(deployment.GetStrategy()).To(Equal(&domain.Strategy{Type: "RollingUpdate", MaxSurge: "25%", MaxUnavailable: "1"}))
+		Expect(deployment.GetConditions()).To(Equal([]domain.Condition{{
+			Type:               "Progressing",
+			Status:             "False",
+			Reason:             "ProgressDeadlineExceeded",
+			Message:            "ReplicaSet api-7d4b9 has timed out progressing.",
+			LastTransitionTime: "2020-10-01 10:00:00",
+		}}))
+		Expect(deployment.GetRolloutStatus().State).To(Equal(domain.RolloutStalled))
+	})
+
 })
 
 func buildDeployment() *appsv1.Deployment {
@@ -5,15 +5,54 @@ import (
 	v1 "k8s.io/api/core/v1"
 )
 
-func buildEndpointFromK8sEndpoints(endpoints v1.Endpoints) []domain.Instance {
-	if len(endpoints.Subsets) <= 0 {
-		return make([]domain.Instance, 0)
+func buildEndpointFromK8sEndpoints(endpoints v1.Endpoints, zoneOf func(nodeName string) string) []domain.Instance {
+	output := make([]domain.Instance, 0)
+
+	for _, subset := range endpoints.Subsets {
+		ports := buildInstancePorts(subset.Ports)
+		for _, address := range subset.Addresses {
+			output = append(output, buildInstance(address, false, ports, zoneOf))
+		}
+		for _, address := range subset.NotReadyAddresses {
+			output = append(output, buildInstance(address, true, ports, zoneOf))
+		}
+	}
+
+	return output
+}
+
+func buildInstance(address v1.EndpointAddress, notReady bool, ports []domain.InstancePort, zoneOf func(nodeName string) string) domain.Instance {
+	instance := domain.Instance{
+		Address:  address.IP,
+		NotReady: notReady,
+		Ports:    ports,
+	}
+
+	if address.TargetRef != nil && address.TargetRef.Kind == "Pod" {
+		instance.PodName = address.TargetRef.Name
+	}
+
+	if address.NodeName != nil {
+		instance.NodeName = *address.NodeName
+		if zoneOf != nil {
+			instance.Zone = zoneOf(instance.NodeName)
+		}
+	}
+
+	return instance
+}
+
+func buildInstancePorts(sourcePorts []v1.EndpointPort) []domain.InstancePort {
+	if len(sourcePorts) <= 0 {
+		return nil
 	}
 
-	output := make([]domain.Instance, len(endpoints.Subsets[0].Addresses))
-	for i, address := range endpoints.Subsets[0].Addresses {
-		output[i] = domain.Instance{
-			Address: address.IP,
+	output := make([]domain.InstancePort, len(sourcePorts))
+	for i, port := range sourcePorts {
+		output[i] = domain.InstancePort{
+			Name:     port.Name,
+			Protocol: string(port.Protocol),
+			Port:     int(port.Port),
 		}
 	}
 
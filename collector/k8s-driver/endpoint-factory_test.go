@@ -0,0 +1,75 @@
+package k8sdriver
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/walmartdigital/katalog/domain"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Endpoint builder struct", func() {
+
+	BeforeEach(func() {})
+
+	It("should build no instances when endpoints have no subsets", func() {
+		instances := buildEndpointFromK8sEndpoints(corev1.Endpoints{}, nil)
+
+		Expect(instances).To(BeEmpty())
+	})
+
+	It("should merge ready and not ready addresses of every subset", func() {
+		zones := map[string]string{"node-a": "zone-1", "node-b": "zone-2"}
+
+		instances := buildEndpointFromK8sEndpoints(buildEndpoints(), func(nodeName string) string {
+			return zones[nodeName]
+		})
+
+		httpPorts := []domain.InstancePort{{Name: "http", Protocol: "TCP", Port: 8080}}
+		grpcPorts := []domain.InstancePort{{Name: "grpc", Protocol: "TCP", Port: 9000}}
+		Expect(instances).To(Equal([]domain.Instance{
+			{Address: "10.0.0.1", PodName: "pod-1", NodeName: "node-a", Zone: "zone-1", Ports: httpPorts},
+			{Address: "10.0.0.2", NotReady: true, PodName: "pod-2", NodeName: "node-b", Zone: "zone-2", Ports: httpPorts},
+			{Address: "10.0.0.3", Ports: grpcPorts},
+		}))
+	})
+
+	It("should count ready instances and their zones on the service", func() {
+		zones := map[string]string{"node-a": "zone-1", "node-b": "zone-2"}
+		service := domain.Service{}
+		for _, instance := range buildEndpointFromK8sEndpoints(buildEndpoints(), func(nodeName string) string {
+			return zones[nodeName]
+		}) {
+			service.AddInstance(instance)
+		}
+
+		Expect(service.CountReadyInstances()).To(Equal(2))
+		Expect(service.GetInstanceZones()).To(Equal([]string{"zone-1", "zone-2"}))
+	})
+
+})
+
+func buildEndpoints() corev1.Endpoints {
+	nodeA := "node-a"
+	nodeB := "node-b"
+	return corev1.Endpoints{
+		Subsets: []corev1.EndpointSubset{
+			{
+				Addresses: []corev1.EndpointAddress{{
+					IP:        "10.0.0.1",
+					NodeName:  &nodeA,
+					TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "pod-1"},
+				}},
+				NotReadyAddresses: []corev1.EndpointAddress{{
+					IP:        "10.0.0.2",
+					NodeName:  &nodeB,
+					TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "pod-2"},
+				}},
+				Ports: []corev1.EndpointPort{{Name: "http", Protocol: corev1.ProtocolTCP, Port: 8080}},
+			},
+			{
+				Addresses: []corev1.EndpointAddress{{IP: "10.0.0.3"}},
+				Ports:     []corev1.EndpointPort{{Name: "grpc", Protocol: corev1.ProtocolTCP, Port: 9000}},
+			},
+		},
+	}
+}
@@ -0,0 +1,88 @@
+package k8sdriver
+
+import (
+	"context"
+	"time"
+
+	"github.com/walmartdigital/katalog/domain"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/tools/cache"
+)
+
+// StartWatchingEndpoints publishes a service update with fresh instances every
+// time the endpoints behind a service change. Changes on the same service are
+// debounced so a rollout produces a handful of events instead of one per pod.
+// Pending changes are published when ctx is done.
+func (d *Driver) StartWatchingEndpoints(ctx context.Context, events chan interface{}, debounce time.Duration) {
+	debouncer := buildDebouncer(debounce, func(key string) {
+		d.publishServiceInstances(events, key)
+	})
+
+	listWatch := cache.NewListWatchFromClient(
+		d.clientSet.CoreV1().RESTClient(),
+		"endpoints",
+		corev1.NamespaceAll,
+		fields.Everything(),
+	)
+
+	trigger := func(obj interface{}) {
+		key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+		if err != nil {
+			log.Errorln(err)
+			return
+		}
+		debouncer.Trigger(key)
+	}
+
+	_, controller := cache.NewInformer(
+		listWatch,
+		&corev1.Endpoints{},
+		d.resyncPeriod,
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: trigger,
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				trigger(newObj)
+			},
+			DeleteFunc: trigger,
+		},
+	)
+	d.run(ctx, func(stop <-chan struct{}) {
+		controller.Run(stop)
+		debouncer.Flush()
+	})
+}
+
+func (d *Driver) publishServiceInstances(channel chan interface{}, key string) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Errorln(err)
+		return
+	}
+
+	if !d.namespaceFilter.Allows(namespace) {
+		return
+	}
+
+	k8sService, err := d.clientSet.CoreV1().Services(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			log.Errorln(err)
+		}
+		return
+	}
+
+	if isOptedOut(k8sService) {
+		return
+	}
+
+	endpoints, err := d.clientSet.CoreV1().Endpoints(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		endpoints = &corev1.Endpoints{}
+	}
+
+	k8sService = d.redact(domain.Resource{K8sResource: &domain.Service{}}, k8sService).(*corev1.Service)
+	d.send(channel, buildOperationFromK8sService(domain.OperationTypeUpdate, k8sService, *endpoints, d.getNodeZone))
+}
@@ -0,0 +1,76 @@
+package k8sdriver
+
+import (
+	"time"
+
+	"github.com/walmartdigital/katalog/domain"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// BuildJobFromK8sJob ...
+func buildJobFromK8sJob(sourceJob *batchv1.Job) domain.Job {
+	containers := buildContainersFromK8sPodSpec(sourceJob.Spec.Template.Spec)
+
+	destinationJob := &domain.Job{
+		ID:                 string(sourceJob.GetUID()),
+		Name:               sourceJob.GetName(),
+		Generation:         sourceJob.GetGeneration(),
+		Namespace:          sourceJob.GetNamespace(),
+		Labels:             sourceJob.GetLabels(),
+		Annotations:        sourceJob.GetAnnotations(),
+		OwnerReferences:    buildOwnerReferencesFromK8sOwnerReferences(sourceJob.GetOwnerReferences()),
+		PodLabels:          sourceJob.Spec.Template.GetLabels(),
+		Containers:         domain.ContainersByName(containers),
+		ContainerSpecs:     containers,
+		StartTime:          formatK8sTime(sourceJob.Status.StartTime),
+		CompletionTime:     formatK8sTime(sourceJob.Status.CompletionTime),
+		Active:             sourceJob.Status.Active,
+		Succeeded:          sourceJob.Status.Succeeded,
+		Failed:             sourceJob.Status.Failed,
+		Timestamp:          time.Now().UTC().Format(collectedFormat),
+		ObservedGeneration: 0,
+	}
+
+	for _, owner := range sourceJob.GetOwnerReferences() {
+		if owner.Kind == "CronJob" {
+			destinationJob.CronJobID = string(owner.UID)
+			destinationJob.CronJobName = owner.Name
+		}
+	}
+
+	return *destinationJob
+}
+
+func formatK8sTime(t *metav1.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(timestampFormat)
+}
+
+const ownerIndex = "owner"
+
+// indexJobByOwner indexes a job by the UIDs of its owners, so the jobs of a
+// cronjob are read from the informer cache
+func indexJobByOwner(obj interface{}) ([]string, error) {
+	job, ok := obj.(*batchv1.Job)
+	if !ok {
+		return nil, nil
+	}
+	owners := make([]string, 0, len(job.GetOwnerReferences()))
+	for _, owner := range job.GetOwnerReferences() {
+		owners = append(owners, string(owner.UID))
+	}
+	return owners, nil
+}
+
+func isOwnedBy(owners []metav1.OwnerReference, uid types.UID) bool {
+	for _, owner := range owners {
+		if owner.UID == uid {
+			return true
+		}
+	}
+	return false
+}
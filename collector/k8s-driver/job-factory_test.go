@@ -0,0 +1,85 @@
+package k8sdriver
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/walmartdigital/katalog/domain"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Job builder struct", func() {
+
+	BeforeEach(func() {})
+
+	It("should build a Job object when pass k8sJob resource", func() {
+		job := buildJobFromK8sJob(buildJob())
+
+		Expect(job.GetID()).To(Equal("UIDExample"))
+		Expect(job.GetGeneration()).To(Equal(int64(5)))
+		Expect(job.GetName()).To(Equal("NameExample"))
+		Expect(job.GetNamespace()).To(Equal("NameSpaceExample"))
+		Expect(job.GetLabels()).To(Equal(map[string]string{"keyLabelExample": "valueLabelExample"}))
+		Expect(job.GetAnnotations()).To(Equal(map[string]string{"keyAnnotationsExample": "valueAnnotationsExample"}))
+		Expect(job.GetContainers()).To(Equal(map[string]string{"containerNameExample": "containerImageExample"}))
+		Expect(job.GetCronJobID()).To(Equal("CronJobUIDExample"))
+		Expect(job.GetCronJobName()).To(Equal("CronJobNameExample"))
+		Expect(job.GetOwnerReferences()).To(Equal([]domain.OwnerReference{{Kind: "CronJob", Name: "CronJobNameExample", UID: "CronJobUIDExample"}}))
+		Expect(job.GetPodLabels()).To(Equal(map[string]string{"job-name": "NameExample"}))
+		Expect(job.GetStartTime()).To(Equal("2020-10-01 10:00:00"))
+		Expect(job.GetCompletionTime()).To(Equal("2020-10-01 10:05:00"))
+		Expect(job.Succeeded).To(Equal(int32(1)))
+		Expect(job.GetTimestamp()).Should(MatchRegexp(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}`))
+	})
+
+	It("should leave the CronJob reference empty when the job is not owned by a cronjob", func() {
+		k8sJob := buildJob()
+		k8sJob.OwnerReferences = nil
+
+		job := buildJobFromK8sJob(k8sJob)
+
+		Expect(job.GetCronJobID()).To(Equal(""))
+		Expect(job.GetCronJobName()).To(Equal(""))
+	})
+
+})
+
+func buildJob() *batchv1.Job {
+	start := metav1.NewTime(time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC))
+	completion := metav1.NewTime(time.Date(2020, 10, 1, 10, 5, 0, 0, time.UTC))
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "NameExample",
+			Namespace:   "NameSpaceExample",
+			UID:         "UIDExample",
+			Generation:  5,
+			Labels:      map[string]string{"keyLabelExample": "valueLabelExample"},
+			Annotations: map[string]string{"keyAnnotationsExample": "valueAnnotationsExample"},
+			OwnerReferences: []metav1.OwnerReference{{
+				Kind: "CronJob",
+				Name: "CronJobNameExample",
+				UID:  "CronJobUIDExample",
+			}},
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"job-name": "NameExample"}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "containerNameExample",
+						Image: "containerImageExample",
+					}},
+				},
+			},
+		},
+		Status: batchv1.JobStatus{
+			StartTime:      &start,
+			CompletionTime: &completion,
+			Succeeded:      1,
+		},
+	}
+}
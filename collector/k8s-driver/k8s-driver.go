@@ -1,15 +1,25 @@
 package k8sdriver
 
 import (
-	"reflect"
+	"context"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/walmartdigital/katalog/domain"
 	"github.com/walmartdigital/katalog/utils"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
@@ -24,31 +34,211 @@ func init() {
 	}
 }
 
-const resyncPeriod = 0
 const timestampFormat = "2006-01-02 15:04:05"
 
+// collectedFormat stamps resources with a fixed width nanosecond fraction, so
+// the server orders updates of the same generation by comparing the strings,
+// second precision stamps of older collectors included
+const collectedFormat = "2006-01-02 15:04:05.000000000"
+const zoneLabel = "topology.kubernetes.io/zone"
+const legacyZoneLabel = "failure-domain.beta.kubernetes.io/zone"
+const ignoreAnnotation = "katalog.io/ignore"
+
+// Metrics ...
+type Metrics interface {
+	IncrementCounter(string, ...string)
+	SetGauge(string, float64, ...string)
+}
+
 // Driver ...
 type Driver struct {
-	clientSet              *kubernetes.Clientset
-	excludeSystemNamespace bool
+	clientSet       kubernetes.Interface
+	dynamicClient   dynamic.Interface
+	cluster         domain.Cluster
+	resyncPeriod    time.Duration
+	published       *publishedCache
+	namespaceFilter *NamespaceFilter
+	redactionPolicy *RedactionPolicy
+	metrics         Metrics
+	nodeZones       map[string]nodeZone
+	nodeZonesMutex  sync.Mutex
+	watched         []watchedResource
+	watchedMutex    sync.Mutex
+	pods            cache.Indexer
+	jobs            cache.Indexer
+	watchers        sync.WaitGroup
+	customResources sync.Map
+}
+
+type watchedResource struct {
+	store    cache.Store
+	channel  chan interface{}
+	resource domain.Resource
 }
 
 // BuildDriver ...
-func BuildDriver(kubeconfigPath string, excludeSystemNamespace bool) *Driver {
-	return &Driver{
-		clientSet:              buildClientSet(kubeconfigPath),
-		excludeSystemNamespace: excludeSystemNamespace,
+func BuildDriver(kubeconfigPath string, cluster domain.Cluster, resyncPeriod time.Duration, namespaceFilter *NamespaceFilter, redactionPolicy *RedactionPolicy, metrics Metrics) *Driver {
+	driver := buildDriver(buildClientSet(kubeconfigPath), cluster, resyncPeriod, namespaceFilter, redactionPolicy, metrics)
+	driver.dynamicClient = buildDynamicClient(kubeconfigPath)
+	return driver
+}
+
+func buildDriver(clientSet kubernetes.Interface, cluster domain.Cluster, resyncPeriod time.Duration, namespaceFilter *NamespaceFilter, redactionPolicy *RedactionPolicy, metrics Metrics) *Driver {
+	driver := &Driver{
+		clientSet:       clientSet,
+		cluster:         cluster,
+		resyncPeriod:    resyncPeriod,
+		published:       buildPublishedCache(),
+		namespaceFilter: namespaceFilter,
+		redactionPolicy: redactionPolicy,
+		metrics:         metrics,
+		nodeZones:       make(map[string]nodeZone),
 	}
+	namespaceFilter.lookup = driver.getNamespaceLabels
+	return driver
 }
 
-// StartWatchingResources ...
-func (d *Driver) StartWatchingResources(events chan interface{}, resource domain.Resource) {
+// StartWatchingResources watches the resource kind in the background until
+// ctx is done.
+func (d *Driver) StartWatchingResources(ctx context.Context, events chan interface{}, resource domain.Resource) {
+	watcher, ok := watcherOf(resource)
+	if !ok || watcher.client == nil {
+		log.Errorf("Type %s can not be watched", resource.GetType())
+		return
+	}
+
 	listWatch := d.buildListWatchForResources(resource)
-	controller := d.buildController(listWatch, resource, d.createAddHandler(events, resource), d.createUpdateHandler(events, resource), d.createDeleteHandler(events, resource))
-	controller.Run(make(chan struct{}))
+	store, controller := d.buildController(listWatch, watcher.object, watcher.indexers, d.createAddHandler(events, resource), d.createUpdateHandler(events, resource), d.createDeleteHandler(events, resource))
+	d.addWatchedResource(watchedResource{store: store, channel: events, resource: resource})
+	if _, ok := resource.K8sResource.(*domain.Job); ok {
+		d.watchedMutex.Lock()
+		d.jobs = store
+		d.watchedMutex.Unlock()
+	}
+	d.run(ctx, controller.Run)
+}
+
+// StartWatchingCustomResources watches the configured GroupVersionResource
+// through the dynamic client in the background until ctx is done.
+func (d *Driver) StartWatchingCustomResources(ctx context.Context, events chan interface{}, config CustomResourceConfig) error {
+	fields, err := buildCustomResourceWatch(config)
+	if err != nil {
+		return err
+	}
+
+	resource := domain.Resource{K8sResource: &domain.CustomResource{
+		Group:    config.Group,
+		Version:  config.Version,
+		Resource: config.Resource,
+	}}
+	d.customResources.Store(resource.GetK8sResource().(*domain.CustomResource).GetGroupVersionResource(), fields)
+
+	resourceClient := d.dynamicClient.Resource(config.GroupVersionResource())
+	listWatch := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return resourceClient.List(options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return resourceClient.Watch(options)
+		},
+	}
+
+	store, controller := d.buildController(listWatch, &unstructured.Unstructured{}, nil, d.createAddHandler(events, resource), d.createUpdateHandler(events, resource), d.createDeleteHandler(events, resource))
+	d.addWatchedResource(watchedResource{store: store, channel: events, resource: resource})
+	d.run(ctx, controller.Run)
+
+	return nil
+}
+
+// StartWatchingNamespaces keeps the namespace labels used by the namespace
+// selector up to date, so relabeled namespaces join or leave the catalog.
+func (d *Driver) StartWatchingNamespaces(ctx context.Context) {
+	if !d.namespaceFilter.HasSelector() {
+		return
+	}
+
+	listWatch := cache.NewListWatchFromClient(
+		d.clientSet.CoreV1().RESTClient(),
+		"namespaces",
+		corev1.NamespaceAll,
+		fields.Everything(),
+	)
+
+	onChange := func(obj interface{}) {
+		namespace := obj.(*corev1.Namespace)
+		if d.namespaceFilter.UpdateNamespaceLabels(namespace.Name, namespace.Labels) {
+			d.recatalogNamespace(namespace.Name)
+		}
+	}
+
+	_, controller := cache.NewInformer(
+		listWatch,
+		&corev1.Namespace{},
+		d.resyncPeriod,
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: onChange,
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				onChange(newObj)
+			},
+			DeleteFunc: func(obj interface{}) {
+				key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+				if err == nil {
+					d.namespaceFilter.ForgetNamespace(key)
+				}
+			},
+		},
+	)
+	d.run(ctx, controller.Run)
+}
+
+// run starts a watch in the background and keeps track of it until it stops
+func (d *Driver) run(ctx context.Context, watch func(stop <-chan struct{})) {
+	d.watchers.Add(1)
+	go func() {
+		defer d.watchers.Done()
+		watch(ctx.Done())
+	}()
+}
+
+// Wait blocks until every watch stopped. Events are sent synchronously, so
+// once it returns every event the watches handled has been received.
+func (d *Driver) Wait() {
+	d.watchers.Wait()
+}
+
+func (d *Driver) addWatchedResource(watched watchedResource) {
+	d.watchedMutex.Lock()
+	defer d.watchedMutex.Unlock()
+
+	d.watched = append(d.watched, watched)
+}
+
+// recatalogNamespace publishes every known resource of the namespace again,
+// as added if the namespace is now cataloged or as deleted if it is not.
+func (d *Driver) recatalogNamespace(namespace string) {
+	kind := domain.OperationTypeDelete
+	if d.namespaceFilter.Allows(namespace) {
+		kind = domain.OperationTypeAdd
+	}
+
+	log.Infof("namespace %s relabeled, publishing its resources as %s", namespace, kind)
+
+	d.watchedMutex.Lock()
+	watched := append([]watchedResource{}, d.watched...)
+	d.watchedMutex.Unlock()
+
+	for _, w := range watched {
+		for _, obj := range w.store.List() {
+			object, err := meta.Accessor(obj)
+			if err != nil || object.GetNamespace() != namespace || isOptedOut(object) {
+				continue
+			}
+			d.publish(w.channel, kind, w.resource, obj)
+		}
+	}
 }
 
-func buildClientSet(kubeconfigPath string) *kubernetes.Clientset {
+func buildClientSet(kubeconfigPath string) kubernetes.Interface {
 	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
 	if err != nil {
 		log.Errorln(err)
@@ -60,156 +250,304 @@ func buildClientSet(kubeconfigPath string) *kubernetes.Clientset {
 	return clientset
 }
 
+func buildDynamicClient(kubeconfigPath string) dynamic.Interface {
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	if err != nil {
+		log.Errorln(err)
+	}
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		log.Errorln(err)
+	}
+	return client
+}
+
 func (d *Driver) buildListWatchForResources(resource domain.Resource) *cache.ListWatch {
-	var listWatch *cache.ListWatch
-
-	switch v := resource.GetType(); v {
-	case reflect.TypeOf(new(domain.Service)):
-		listWatch = cache.NewListWatchFromClient(
-			d.clientSet.CoreV1().RESTClient(),
-			"services",
-			corev1.NamespaceAll,
-			fields.Everything(),
-		)
-	case reflect.TypeOf(new(domain.Deployment)):
-		listWatch = cache.NewListWatchFromClient(
-			d.clientSet.AppsV1().RESTClient(),
-			"deployments",
-			corev1.NamespaceAll,
-			fields.Everything(),
-		)
-	case reflect.TypeOf(new(domain.StatefulSet)):
-		listWatch = cache.NewListWatchFromClient(
-			d.clientSet.AppsV1().RESTClient(),
-			"statefulsets",
-			corev1.NamespaceAll,
-			fields.Everything(),
-		)
-	default:
-		log.Errorf("Type %s not found", v)
-	}
-
-	return listWatch
-}
-
-func (d *Driver) buildController(listWatch *cache.ListWatch, resource domain.Resource, addFunc func(obj interface{}), updateFunc func(oldObj, newObj interface{}), deleteFunc func(obj interface{})) cache.Controller {
-	var controller cache.Controller
-
-	switch v := resource.GetType(); v {
-	case reflect.TypeOf(new(domain.Service)):
-		_, controller = cache.NewInformer(
-			listWatch,
-			&corev1.Service{},
-			resyncPeriod,
-			cache.ResourceEventHandlerFuncs{
-				AddFunc:    addFunc,
-				UpdateFunc: updateFunc,
-				DeleteFunc: deleteFunc,
-			},
-		)
-	case reflect.TypeOf(new(domain.Deployment)):
-		_, controller = cache.NewInformer(
-			listWatch,
-			&appsv1.Deployment{},
-			resyncPeriod,
-			cache.ResourceEventHandlerFuncs{
-				AddFunc:    addFunc,
-				UpdateFunc: updateFunc,
-				DeleteFunc: deleteFunc,
-			},
-		)
-	case reflect.TypeOf(new(domain.StatefulSet)):
-		_, controller = cache.NewInformer(
-			listWatch,
-			&appsv1.StatefulSet{},
-			resyncPeriod,
-			cache.ResourceEventHandlerFuncs{
-				AddFunc:    addFunc,
-				UpdateFunc: updateFunc,
-				DeleteFunc: deleteFunc,
-			},
-		)
-	default:
-		log.Errorf("Type %s not found", v)
+	watcher, ok := watcherOf(resource)
+	if !ok || watcher.client == nil {
+		log.Errorf("Type %s not found", resource.GetType())
+		return nil
 	}
 
-	return controller
+	return cache.NewListWatchFromClient(
+		watcher.client(d.clientSet),
+		watcher.resource,
+		corev1.NamespaceAll,
+		fields.Everything(),
+	)
+}
+
+func (d *Driver) buildController(listWatch cache.ListerWatcher, object runtime.Object, indexers cache.Indexers, addFunc func(obj interface{}), updateFunc func(oldObj, newObj interface{}), deleteFunc func(obj interface{})) (cache.Indexer, cache.Controller) {
+	if indexers == nil {
+		indexers = cache.Indexers{}
+	}
+	return cache.NewIndexerInformer(
+		listWatch,
+		object,
+		d.resyncPeriod,
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    addFunc,
+			UpdateFunc: updateFunc,
+			DeleteFunc: deleteFunc,
+		},
+		indexers,
+	)
 }
 
 func (d *Driver) createAddHandler(channel chan interface{}, resource domain.Resource) func(interface{}) {
 	return func(obj interface{}) {
-		switch t := resource.GetType(); t {
-		case reflect.TypeOf(new(domain.Service)):
-			k8sService := obj.(*corev1.Service)
-			if d.excludeSystemNamespace && k8sService.Namespace == "kube-system" {
-				log.Infof("%s excluded because belongs to kube-system namespace", k8sService.Name)
-				return
-			}
-			endpoints, _ := d.clientSet.CoreV1().Endpoints(k8sService.Namespace).Get(k8sService.Name, metav1.GetOptions{})
-			service := buildOperationFromK8sService(domain.OperationTypeAdd, k8sService, *endpoints)
-			channel <- service
-		case reflect.TypeOf(new(domain.Deployment)):
-			k8sDeployment := obj.(*appsv1.Deployment)
-			if d.excludeSystemNamespace && k8sDeployment.Namespace == "kube-system" {
-				log.Infof("%s excluded because belongs to kube-system namespace", k8sDeployment.Name)
-				return
-			}
-			deployment := buildOperationFromK8sDeployment(domain.OperationTypeAdd, k8sDeployment)
-			channel <- deployment
-		case reflect.TypeOf(new(domain.StatefulSet)):
-			k8sStatefulSet := obj.(*appsv1.StatefulSet)
-			if d.excludeSystemNamespace && k8sStatefulSet.Namespace == "kube-system" {
-				log.Infof("%s excluded because belongs to kube-system namespace", k8sStatefulSet.Name)
-				return
-			}
-			statefulset := buildOperationFromK8sStatefulSet(domain.OperationTypeAdd, k8sStatefulSet)
-			channel <- statefulset
-		default:
-			log.Errorf("Type %s not found", t)
+		if !d.isCataloged(obj) || d.isIgnored(resource, obj) {
+			return
 		}
+		d.publish(channel, domain.OperationTypeAdd, resource, obj)
 	}
 }
 
+// createDeleteHandler unwraps the tombstones the informer hands over when the
+// final state of a deleted object was missed during a watch disconnect.
 func (d *Driver) createDeleteHandler(channel chan interface{}, resource domain.Resource) func(interface{}) {
 	return func(obj interface{}) {
-		switch t := resource.GetType(); t {
-		case reflect.TypeOf(new(domain.Service)):
-			k8sService := obj.(*corev1.Service)
-			endpoints, _ := d.clientSet.CoreV1().Endpoints(k8sService.Namespace).Get(k8sService.Name, metav1.GetOptions{})
-			service := buildOperationFromK8sService(domain.OperationTypeDelete, k8sService, *endpoints)
-			channel <- service
-		case reflect.TypeOf(new(domain.Deployment)):
-			k8sDeployment := obj.(*appsv1.Deployment)
-			deployment := buildOperationFromK8sDeployment(domain.OperationTypeDelete, k8sDeployment)
-			channel <- deployment
-		case reflect.TypeOf(new(domain.StatefulSet)):
-			k8sStatefulSet := obj.(*appsv1.StatefulSet)
-			statefulset := buildOperationFromK8sStatefulSet(domain.OperationTypeDelete, k8sStatefulSet)
-			channel <- statefulset
-		default:
-			log.Errorf("Type %s not found", t)
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			if tombstone.Obj == nil {
+				log.Errorf("tombstone %s holds no object", tombstone.Key)
+				return
+			}
+			obj = tombstone.Obj
 		}
+		if !d.isCataloged(obj) || d.isIgnored(resource, obj) {
+			return
+		}
+		d.publish(channel, domain.OperationTypeDelete, resource, obj)
 	}
 }
 
+// createUpdateHandler also handles the opt-out annotation being set or
+// removed, publishing the resource as deleted or added accordingly.
 func (d *Driver) createUpdateHandler(channel chan interface{}, resource domain.Resource) func(oldObj interface{}, newObj interface{}) {
 	return func(oldObj interface{}, newObj interface{}) {
-		switch t := resource.GetType(); t {
-		case reflect.TypeOf(new(domain.Service)):
-			k8sService := newObj.(*corev1.Service)
-			endpoints, _ := d.clientSet.CoreV1().Endpoints(k8sService.Namespace).Get(k8sService.Name, metav1.GetOptions{})
-			service := buildOperationFromK8sService(domain.OperationTypeUpdate, k8sService, *endpoints)
-			channel <- service
-		case reflect.TypeOf(new(domain.Deployment)):
-			k8sDeployment := newObj.(*appsv1.Deployment)
-			deployment := buildOperationFromK8sDeployment(domain.OperationTypeUpdate, k8sDeployment)
-			channel <- deployment
-		case reflect.TypeOf(new(domain.StatefulSet)):
-			k8sStatefulSet := newObj.(*appsv1.StatefulSet)
-			statefulset := buildOperationFromK8sStatefulSet(domain.OperationTypeUpdate, k8sStatefulSet)
-			channel <- statefulset
+		if !d.isCataloged(newObj) {
+			return
+		}
+
+		wasIgnored := d.isIgnored(resource, oldObj)
+		isIgnored := d.isIgnored(resource, newObj)
+
+		switch {
+		case wasIgnored && isIgnored:
+			return
+		case wasIgnored:
+			d.publish(channel, domain.OperationTypeAdd, resource, newObj)
+		case isIgnored:
+			d.publish(channel, domain.OperationTypeDelete, resource, oldObj)
 		default:
-			log.Errorf("Type %s not found", t)
+			d.publish(channel, domain.OperationTypeUpdate, resource, newObj)
+		}
+	}
+}
+
+func (d *Driver) isCataloged(obj interface{}) bool {
+	object, err := meta.Accessor(obj)
+	if err != nil {
+		log.Errorln(err)
+		return false
+	}
+
+	// Cluster scoped custom resources belong to no namespace to filter on
+	if object.GetNamespace() == "" {
+		return true
+	}
+
+	if !d.namespaceFilter.Allows(object.GetNamespace()) {
+		log.Infof("%s excluded because namespace %s is not cataloged", object.GetName(), object.GetNamespace())
+		return false
+	}
+
+	return true
+}
+
+func (d *Driver) isIgnored(resource domain.Resource, obj interface{}) bool {
+	object, err := meta.Accessor(obj)
+	if err != nil || !isOptedOut(object) {
+		return false
+	}
+
+	log.Debugf("%s/%s ignored because of the %s annotation", object.GetNamespace(), object.GetName(), ignoreAnnotation)
+	d.metrics.IncrementCounter("ignoredResource", resource.GetType().Elem().Name(), object.GetNamespace())
+	return true
+}
+
+func isOptedOut(object metav1.Object) bool {
+	return strings.EqualFold(object.GetAnnotations()[ignoreAnnotation], "true")
+}
+
+// redact applies the redaction policy to a copy of obj, so the informer cache
+// keeps the original labels and annotations.
+func (d *Driver) redact(resource domain.Resource, obj interface{}) interface{} {
+	runtimeObject, ok := obj.(runtime.Object)
+	if d.redactionPolicy.IsEmpty() || !ok {
+		return obj
+	}
+
+	copied := runtimeObject.DeepCopyObject()
+	object, err := meta.Accessor(copied)
+	if err != nil {
+		log.Errorln(err)
+		return obj
+	}
+
+	kind := resource.GetType().Elem().Name()
+
+	labels, redactions := d.redactionPolicy.redact(object.GetLabels())
+	object.SetLabels(labels)
+	d.reportRedactions(kind, "label", redactions)
+
+	annotations, redactions := d.redactionPolicy.redact(object.GetAnnotations())
+	object.SetAnnotations(annotations)
+	d.reportRedactions(kind, "annotation", redactions)
+
+	// Workloads also publish the labels of their pod template
+	if template := podTemplateOf(copied); template != nil {
+		podLabels, redactions := d.redactionPolicy.redact(template.Labels)
+		template.Labels = podLabels
+		d.reportRedactions(kind, "pod-label", redactions)
+	}
+
+	return copied
+}
+
+// podTemplateOf returns the pod template of the workloads, nil otherwise
+func podTemplateOf(obj runtime.Object) *corev1.PodTemplateSpec {
+	switch workload := obj.(type) {
+	case *appsv1.Deployment:
+		return &workload.Spec.Template
+	case *appsv1.StatefulSet:
+		return &workload.Spec.Template
+	case *appsv1.DaemonSet:
+		return &workload.Spec.Template
+	case *batchv1.Job:
+		return &workload.Spec.Template
+	case *batchv1beta1.CronJob:
+		return &workload.Spec.JobTemplate.Spec.Template
+	}
+	return nil
+}
+
+func (d *Driver) reportRedactions(kind string, field string, redactions []redaction) {
+	for _, r := range redactions {
+		d.metrics.IncrementCounter("redactedKey", kind, field, r.key, r.action)
+	}
+}
+
+func (d *Driver) publish(channel chan interface{}, kind domain.OperationType, resource domain.Resource, obj interface{}) {
+	obj = d.redact(resource, obj)
+
+	watcher, ok := watcherOf(resource)
+	if !ok {
+		log.Errorf("Type %s not found", resource.GetType())
+		return
+	}
+
+	operation, ok := watcher.build(d, kind, resource, obj)
+	if !ok {
+		return
+	}
+	d.attachRunningImages(operation)
+
+	d.send(channel, operation)
+}
+
+// send stamps the cluster identity on the operation and hands it over to the
+// publishers, skipping updates that would not change what was last published.
+func (d *Driver) send(channel chan interface{}, operation domain.Operation) {
+	if d.cluster.Name != "" {
+		operation.Resource.SetCluster(d.cluster)
+	}
+
+	if !d.published.shouldPublish(operation) {
+		log.Debugf("%s/%s unchanged since last published", operation.Resource.GetNamespace(), operation.Resource.GetName())
+		return
+	}
+
+	channel <- operation
+}
+
+// ForgetPublished makes the next event of the resource be published even if
+// unchanged. Call it when publishing the operation failed, so the following
+// resync repairs the catalog.
+func (d *Driver) ForgetPublished(operation domain.Operation) {
+	d.published.forget(operation)
+}
+
+// getOwnedJobs returns the jobs of the cronjob from the informer cache of the
+// jobs, empty until the jobs are watched
+func (d *Driver) getOwnedJobs(cronJob *batchv1beta1.CronJob) []batchv1.Job {
+	d.watchedMutex.Lock()
+	jobs := d.jobs
+	d.watchedMutex.Unlock()
+
+	if jobs == nil {
+		return nil
+	}
+
+	objs, err := jobs.ByIndex(ownerIndex, string(cronJob.GetUID()))
+	if err != nil {
+		log.Errorln(err)
+		return nil
+	}
+
+	owned := make([]batchv1.Job, 0, len(objs))
+	for _, obj := range objs {
+		owned = append(owned, *obj.(*batchv1.Job))
+	}
+	return owned
+}
+
+// nodeZoneMissTTL is how long a node whose lookup failed is remembered, so
+// endpoints on a missing node do not query the API server on every event
+const nodeZoneMissTTL = time.Minute
+
+// nodeZone is a cached node zone, expiring only when the lookup failed
+type nodeZone struct {
+	zone    string
+	expires time.Time
+}
+
+// getNodeZone resolves the zone of a node from its topology labels. Zones are
+// cached because nodes rarely move and every endpoint address carries one.
+// The node is fetched without holding the cache lock, concurrent misses of a
+// node may fetch it twice.
+func (d *Driver) getNodeZone(nodeName string) string {
+	d.nodeZonesMutex.Lock()
+	cached, ok := d.nodeZones[nodeName]
+	d.nodeZonesMutex.Unlock()
+	if ok && (cached.expires.IsZero() || time.Now().Before(cached.expires)) {
+		return cached.zone
+	}
+
+	resolved := nodeZone{}
+	node, err := d.clientSet.CoreV1().Nodes().Get(nodeName, metav1.GetOptions{})
+	if err != nil {
+		log.Errorln(err)
+		resolved.expires = time.Now().Add(nodeZoneMissTTL)
+	} else {
+		resolved.zone = node.GetLabels()[zoneLabel]
+		if resolved.zone == "" {
+			resolved.zone = node.GetLabels()[legacyZoneLabel]
 		}
 	}
+
+	d.nodeZonesMutex.Lock()
+	d.nodeZones[nodeName] = resolved
+	d.nodeZonesMutex.Unlock()
+
+	return resolved.zone
+}
+
+func (d *Driver) getNamespaceLabels(namespace string) (map[string]string, bool) {
+	k8sNamespace, err := d.clientSet.CoreV1().Namespaces().Get(namespace, metav1.GetOptions{})
+	if err != nil {
+		log.Errorln(err)
+		return nil, false
+	}
+	return k8sNamespace.GetLabels(), true
 }
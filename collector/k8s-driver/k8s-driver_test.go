@@ -0,0 +1,440 @@
+package k8sdriver
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/walmartdigital/katalog/domain"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ = Describe("Driver handlers", func() {
+
+	var (
+		driver   *Driver
+		events   chan interface{}
+		resource domain.Resource
+		metrics  *fakeMetrics
+	)
+
+	BeforeEach(func() {
+		clientSet := fake.NewSimpleClientset(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"katalog.io/catalog": "enabled"}}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+		)
+		filter, _ := BuildNamespaceFilter(nil, []string{"kube-*"}, "katalog.io/catalog=enabled")
+		policy, _ := BuildRedactionPolicy([]string{"kubectl.kubernetes.io/*"}, []string{"owner-email"})
+		metrics = &fakeMetrics{counters: make(map[string]int), labels: make(map[string][]string), gauges: make(map[string]float64)}
+		driver = buildDriver(clientSet, domain.Cluster{Name: "east-1", Region: "us-east"}, 0, filter, policy, metrics)
+		events = make(chan interface{}, 10)
+		resource = domain.Resource{K8sResource: &domain.Deployment{}}
+	})
+
+	It("should publish add, update and delete events of cataloged namespaces", func() {
+		deployment := buildNamespacedDeployment("team-a", "api")
+		updated := deployment.DeepCopy()
+		updated.Generation++
+
+		driver.createAddHandler(events, resource)(deployment)
+		driver.createUpdateHandler(events, resource)(deployment, updated)
+		driver.createDeleteHandler(events, resource)(updated)
+
+		Expect(events).To(HaveLen(3))
+		Expect((<-events).(domain.Operation).Kind).To(Equal(domain.OperationTypeAdd))
+		Expect((<-events).(domain.Operation).Kind).To(Equal(domain.OperationTypeUpdate))
+		Expect((<-events).(domain.Operation).Kind).To(Equal(domain.OperationTypeDelete))
+	})
+
+	It("should stamp the cluster identity on every published resource", func() {
+		driver.createAddHandler(events, resource)(buildNamespacedDeployment("team-a", "api"))
+
+		Expect(events).To(HaveLen(1))
+		published := (<-events).(domain.Operation).Resource
+		Expect(published.GetCluster()).To(Equal(domain.Cluster{Name: "east-1", Region: "us-east"}))
+		Expect(published.GetKey()).To(Equal("east-1/" + published.GetID()))
+	})
+
+	It("should wait for the watches to stop once their context is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		stopped := make(chan bool, 1)
+		driver.run(ctx, func(stop <-chan struct{}) {
+			<-stop
+			stopped <- true
+		})
+
+		waited := make(chan bool)
+		go func() {
+			driver.Wait()
+			waited <- true
+		}()
+		Consistently(waited, 100*time.Millisecond).ShouldNot(Receive())
+
+		cancel()
+		Eventually(waited).Should(Receive())
+		Expect(stopped).To(Receive())
+	})
+
+	It("should publish the delete of a tombstone", func() {
+		deployment := buildNamespacedDeployment("team-a", "api")
+
+		driver.createDeleteHandler(events, resource)(cache.DeletedFinalStateUnknown{Key: "team-a/api", Obj: deployment})
+		driver.createDeleteHandler(events, resource)(cache.DeletedFinalStateUnknown{Key: "team-a/web"})
+
+		Expect(events).To(HaveLen(1))
+		operation := (<-events).(domain.Operation)
+		Expect(operation.Kind).To(Equal(domain.OperationTypeDelete))
+		Expect(operation.Resource.GetName()).To(Equal("api"))
+	})
+
+	It("should only publish resynced resources whose state changed since last published", func() {
+		deployment := buildNamespacedDeployment("team-a", "api")
+		driver.createAddHandler(events, resource)(deployment)
+		<-events
+
+		driver.createUpdateHandler(events, resource)(deployment, deployment)
+		Expect(events).To(BeEmpty())
+
+		relabeled := deployment.DeepCopy()
+		relabeled.Labels = map[string]string{"tier": "backend"}
+		driver.createUpdateHandler(events, resource)(deployment, relabeled)
+		Expect(events).To(HaveLen(1))
+		operation := (<-events).(domain.Operation)
+
+		driver.createUpdateHandler(events, resource)(relabeled, relabeled)
+		Expect(events).To(BeEmpty())
+
+		driver.ForgetPublished(operation)
+		driver.createUpdateHandler(events, resource)(relabeled, relabeled)
+		Expect(events).To(HaveLen(1))
+	})
+
+	It("should not publish add, update or delete events of filtered namespaces", func() {
+		for _, deployment := range []*appsv1.Deployment{
+			buildNamespacedDeployment("team-b", "api"),
+			buildNamespacedDeployment("kube-system", "coredns"),
+		} {
+			driver.createAddHandler(events, resource)(deployment)
+			driver.createUpdateHandler(events, resource)(deployment, deployment)
+			driver.createDeleteHandler(events, resource)(deployment)
+		}
+
+		Expect(events).To(BeEmpty())
+	})
+
+	It("should publish the resources of a namespace when it starts or stops matching the selector", func() {
+		store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+		store.Add(buildNamespacedDeployment("team-b", "api"))
+		store.Add(buildNamespacedDeployment("team-a", "web"))
+		driver.addWatchedResource(watchedResource{store: store, channel: events, resource: resource})
+		Expect(driver.namespaceFilter.Allows("team-b")).To(BeFalse())
+
+		if driver.namespaceFilter.UpdateNamespaceLabels("team-b", map[string]string{"katalog.io/catalog": "enabled"}) {
+			driver.recatalogNamespace("team-b")
+		}
+
+		Expect(events).To(HaveLen(1))
+		operation := (<-events).(domain.Operation)
+		Expect(operation.Kind).To(Equal(domain.OperationTypeAdd))
+		Expect(operation.Resource.GetName()).To(Equal("api"))
+
+		if driver.namespaceFilter.UpdateNamespaceLabels("team-b", map[string]string{}) {
+			driver.recatalogNamespace("team-b")
+		}
+
+		Expect(events).To(HaveLen(1))
+		Expect((<-events).(domain.Operation).Kind).To(Equal(domain.OperationTypeDelete))
+	})
+
+	It("should not publish resources annotated with the opt-out annotation", func() {
+		deployment := buildNamespacedDeployment("team-a", "api")
+		deployment.Annotations = map[string]string{ignoreAnnotation: "true"}
+
+		driver.createAddHandler(events, resource)(deployment)
+		driver.createUpdateHandler(events, resource)(deployment, deployment)
+		driver.createDeleteHandler(events, resource)(deployment)
+
+		Expect(events).To(BeEmpty())
+		Expect(metrics.counters["ignoredResource"]).To(Equal(4))
+		Expect(metrics.labels["ignoredResource"]).To(Equal([]string{"Deployment", "team-a"}))
+	})
+
+	It("should publish a delete when a resource opts out and an add when it opts back in", func() {
+		deployment := buildNamespacedDeployment("team-a", "api")
+		ignored := deployment.DeepCopy()
+		ignored.Annotations = map[string]string{ignoreAnnotation: "true"}
+
+		driver.createUpdateHandler(events, resource)(deployment, ignored)
+		driver.createUpdateHandler(events, resource)(ignored, deployment)
+
+		Expect(events).To(HaveLen(2))
+		Expect((<-events).(domain.Operation).Kind).To(Equal(domain.OperationTypeDelete))
+		Expect((<-events).(domain.Operation).Kind).To(Equal(domain.OperationTypeAdd))
+	})
+
+	It("should drop and hash redacted keys without touching the original object", func() {
+		deployment := buildNamespacedDeployment("team-a", "api")
+		deployment.Labels = map[string]string{"app": "api", "owner-email": "team@example.com"}
+		deployment.Annotations = map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "{}", "team": "a"}
+
+		driver.createAddHandler(events, resource)(deployment)
+
+		Expect(events).To(HaveLen(1))
+		published := (<-events).(domain.Operation).Resource
+		Expect(published.GetAnnotations()).To(Equal(map[string]string{"team": "a"}))
+		Expect(published.GetLabels()["app"]).To(Equal("api"))
+		Expect(published.GetLabels()["owner-email"]).To(HavePrefix("sha256:"))
+		Expect(deployment.Labels["owner-email"]).To(Equal("team@example.com"))
+		Expect(deployment.Annotations).To(HaveKey("kubectl.kubernetes.io/last-applied-configuration"))
+		Expect(metrics.counters["redactedKey"]).To(Equal(2))
+	})
+
+	It("should redact the annotations of services", func() {
+		service := buildService()
+		service.Namespace = "team-a"
+		service.Annotations = map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "{}", "owner-email": "team@example.com"}
+
+		driver.createAddHandler(events, domain.Resource{K8sResource: &domain.Service{}})(service)
+
+		Expect(events).To(HaveLen(1))
+		published := (<-events).(domain.Operation).Resource
+		annotations := published.GetAnnotations()
+		Expect(annotations).NotTo(HaveKey("kubectl.kubernetes.io/last-applied-configuration"))
+		Expect(annotations["owner-email"]).To(HavePrefix("sha256:"))
+		Expect(service.Annotations).To(HaveKey("kubectl.kubernetes.io/last-applied-configuration"))
+	})
+
+	It("should redact the pod template labels of workloads", func() {
+		deployment := buildNamespacedDeployment("team-a", "api")
+		deployment.Spec.Template.Labels = map[string]string{"app": "api", "kubectl.kubernetes.io/team": "a", "owner-email": "team@example.com"}
+
+		driver.createAddHandler(events, resource)(deployment)
+
+		Expect(events).To(HaveLen(1))
+		podLabels := (<-events).(domain.Operation).Resource.K8sResource.(*domain.Deployment).GetPodLabels()
+		Expect(podLabels).NotTo(HaveKey("kubectl.kubernetes.io/team"))
+		Expect(podLabels["app"]).To(Equal("api"))
+		Expect(podLabels["owner-email"]).To(HavePrefix("sha256:"))
+		Expect(deployment.Spec.Template.Labels).To(HaveKey("kubectl.kubernetes.io/team"))
+		Expect(metrics.counters["redactedKey"]).To(Equal(2))
+	})
+
+	It("should attach the image digests run by the pods of a workload", func() {
+		driver.pods = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{workloadIndex: indexPodByWorkload})
+		driver.pods.Add(buildOwnedPod("team-a", "api-7d4b9-x1", "ReplicaSet", "api-7d4b9", "docker-pullable://api@sha256:aaa"))
+		driver.pods.Add(buildOwnedPod("team-a", "api-7d4b9-x2", "ReplicaSet", "api-7d4b9", "docker-pullable://api@sha256:aaa"))
+		driver.pods.Add(buildOwnedPod("team-a", "api-5f6c8-x3", "ReplicaSet", "api-5f6c8", "docker-pullable://api@sha256:bbb"))
+		driver.pods.Add(buildOwnedPod("team-a", "web-5f6c8-x4", "ReplicaSet", "web-5f6c8", "docker-pullable://web@sha256:ccc"))
+
+		driver.createAddHandler(events, resource)(buildNamespacedDeployment("team-a", "api"))
+
+		Expect(events).To(HaveLen(1))
+		deployment := (<-events).(domain.Operation).Resource.K8sResource.(*domain.Deployment)
+		Expect(deployment.GetRunningImages()).To(Equal([]domain.RunningImage{
+			{Container: "containerNameExample", Digest: "sha256:aaa", Pods: 2},
+			{Container: "containerNameExample", Digest: "sha256:bbb", Pods: 1},
+		}))
+	})
+
+	It("should only count the pods reporting a repository digest", func() {
+		driver.pods = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{workloadIndex: indexPodByWorkload})
+		driver.pods.Add(buildOwnedPod("team-a", "api-7d4b9-x1", "ReplicaSet", "api-7d4b9", "docker-pullable://api@sha256:aaa"))
+		driver.pods.Add(buildOwnedPod("team-a", "api-7d4b9-x2", "ReplicaSet", "api-7d4b9", "docker.io/library/api@sha256:aaa"))
+		driver.pods.Add(buildOwnedPod("team-a", "api-7d4b9-x3", "ReplicaSet", "api-7d4b9", "docker://sha256:bbb"))
+		driver.pods.Add(buildOwnedPod("team-a", "api-7d4b9-x4", "ReplicaSet", "api-7d4b9", "sha256:ccc"))
+		driver.pods.Add(buildOwnedPod("team-a", "api-7d4b9-x5", "ReplicaSet", "api-7d4b9", ""))
+
+		driver.createAddHandler(events, resource)(buildNamespacedDeployment("team-a", "api"))
+
+		Expect(events).To(HaveLen(1))
+		deployment := (<-events).(domain.Operation).Resource.K8sResource.(*domain.Deployment)
+		Expect(deployment.GetRunningImages()).To(Equal([]domain.RunningImage{
+			{Container: "containerNameExample", Digest: "sha256:aaa", Pods: 2},
+		}))
+	})
+
+	It("should publish a workload again when the digests run by its pods change", func() {
+		deployment := buildNamespacedDeployment("team-a", "api")
+		store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+		store.Add(deployment)
+		driver.addWatchedResource(watchedResource{store: store, channel: events, resource: resource})
+		driver.pods = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{workloadIndex: indexPodByWorkload})
+
+		driver.createAddHandler(events, resource)(deployment)
+		<-events
+
+		driver.pods.Add(buildOwnedPod("team-a", "api-7d4b9-x1", "ReplicaSet", "api-7d4b9", "docker-pullable://api@sha256:aaa"))
+		driver.publishWorkloadImages("Deployment/team-a/api")
+		driver.publishWorkloadImages("Deployment/team-a/web")
+
+		Expect(events).To(HaveLen(1))
+		operation := (<-events).(domain.Operation)
+		Expect(operation.Kind).To(Equal(domain.OperationTypeUpdate))
+		Expect(operation.Resource.K8sResource.(*domain.Deployment).GetRunningImages()).To(HaveLen(1))
+
+		driver.publishWorkloadImages("Deployment/team-a/api")
+		Expect(events).To(BeEmpty())
+	})
+
+	It("should read the jobs of a cronjob from the informer cache", func() {
+		driver.jobs = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{ownerIndex: indexJobByOwner})
+		for i, job := range []batchv1.Job{
+			buildCompletedJob("UIDExample", 1, time.Date(2020, 10, 1, 9, 30, 0, 0, time.UTC)),
+			buildCompletedJob("OtherUID", 1, time.Date(2020, 10, 1, 9, 50, 0, 0, time.UTC)),
+		} {
+			job.Namespace = "team-a"
+			job.Name = "job-" + string(rune('a'+i))
+			driver.jobs.Add(job.DeepCopy())
+		}
+		cronJob := buildCronJob()
+		cronJob.Namespace = "team-a"
+
+		driver.createAddHandler(events, domain.Resource{K8sResource: &domain.CronJob{}})(cronJob)
+
+		Expect(events).To(HaveLen(1))
+		published := (<-events).(domain.Operation).Resource.K8sResource.(*domain.CronJob)
+		Expect(published.GetLastSuccessfulTime()).To(Equal("2020-10-01 09:30:00"))
+		for _, action := range driver.clientSet.(*fake.Clientset).Actions() {
+			Expect(action.GetResource().Resource).NotTo(Equal("jobs"))
+		}
+	})
+
+	It("should find the workload controlling a pod", func() {
+		key, ok := workloadOfPod(buildOwnedPod("team-a", "api-7d4b9-x1", "ReplicaSet", "api-7d4b9", ""))
+		Expect(ok).To(BeTrue())
+		Expect(key).To(Equal("Deployment/team-a/api"))
+
+		key, ok = workloadOfPod(buildOwnedPod("team-a", "db-0", "StatefulSet", "db", ""))
+		Expect(ok).To(BeTrue())
+		Expect(key).To(Equal("StatefulSet/team-a/db"))
+
+		_, ok = workloadOfPod(buildOwnedPod("team-a", "bare-x1", "ReplicaSet", "bare", ""))
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should cache the zones of nodes and, for a while, the nodes not found", func() {
+		clientSet := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{zoneLabel: "zone-1"}}})
+		gets := 0
+		clientSet.PrependReactor("get", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			gets++
+			return false, nil, nil
+		})
+		driver.clientSet = clientSet
+
+		Expect(driver.getNodeZone("node-a")).To(Equal("zone-1"))
+		Expect(driver.getNodeZone("node-a")).To(Equal("zone-1"))
+		Expect(driver.getNodeZone("node-gone")).To(Equal(""))
+		Expect(driver.getNodeZone("node-gone")).To(Equal(""))
+		Expect(gets).To(Equal(2))
+
+		driver.nodeZones["node-gone"] = nodeZone{expires: time.Now().Add(-time.Second)}
+		Expect(driver.getNodeZone("node-gone")).To(Equal(""))
+		Expect(gets).To(Equal(3))
+	})
+
+	It("should watch every registered kind with a watcher, except the custom resources", func() {
+		var names []string
+		for _, kind := range WatchableKinds() {
+			names = append(names, kind.Name)
+			Expect(kindWatchers[kind.Name].object).NotTo(BeNil())
+		}
+
+		Expect(names).To(ConsistOf("Service", "Deployment", "ReplicaSet", "StatefulSet", "DaemonSet", "Job", "CronJob"))
+		for _, kind := range domain.Kinds() {
+			Expect(kindWatchers).To(HaveKey(kind.Name))
+		}
+	})
+
+	It("should watch custom resources through the dynamic client", func() {
+		rollout := buildRollout()
+		rollout.SetNamespace("team-a")
+		driver.dynamicClient = dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), rollout)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		err := driver.StartWatchingCustomResources(ctx, events, CustomResourceConfig{
+			Group:    "argoproj.io",
+			Version:  "v1alpha1",
+			Resource: "rollouts",
+			Fields:   map[string]string{"replicas": ".spec.replicas"},
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		var operation domain.Operation
+		Eventually(events).Should(Receive(&operation))
+		Expect(operation.Kind).To(Equal(domain.OperationTypeAdd))
+		customResource := operation.Resource.GetK8sResource().(*domain.CustomResource)
+		Expect(customResource.GetKind()).To(Equal("Rollout"))
+		Expect(customResource.GetFields()).To(Equal(map[string]interface{}{"replicas": int64(4)}))
+		Expect(operation.Resource.GetKey()).To(Equal("east-1/UIDExample"))
+	})
+
+})
+
+type fakeMetrics struct {
+	counters map[string]int
+	labels   map[string][]string
+	gauges   map[string]float64
+	mutex    sync.Mutex
+}
+
+func (f *fakeMetrics) IncrementCounter(key string, labels ...string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.counters[key]++
+	if f.labels != nil {
+		f.labels[key] = labels
+	}
+}
+
+func (f *fakeMetrics) SetGauge(key string, value float64, labels ...string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.gauges[key+"/"+strings.Join(labels, "/")] = value
+}
+
+func (f *fakeMetrics) getGauge(key string) float64 {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.gauges[key]
+}
+
+// buildOwnedPod builds a pod controlled by owner, labeled with the hash
+// suffixing the name of a ReplicaSet the way deployments do
+func buildOwnedPod(namespace string, name string, ownerKind string, ownerName string, imageID string) *corev1.Pod {
+	controller := true
+	labels := map[string]string{}
+	if i := strings.LastIndex(ownerName, "-"); ownerKind == "ReplicaSet" && i >= 0 {
+		labels[appsv1.DefaultDeploymentUniqueLabelKey] = ownerName[i+1:]
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       namespace,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{{Kind: ownerKind, Name: ownerName, Controller: &controller}},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "containerNameExample", ImageID: imageID}},
+		},
+	}
+}
+
+func buildNamespacedDeployment(namespace string, name string) *appsv1.Deployment {
+	deployment := buildDeployment()
+	deployment.Namespace = namespace
+	deployment.Name = name
+	deployment.UID = ""
+	return deployment
+}
@@ -0,0 +1,125 @@
+package k8sdriver
+
+import (
+	"github.com/walmartdigital/katalog/domain"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// kindWatcher tells the driver how to watch the objects of a registered
+// domain.Kind and map them to catalog resources. Kinds without a client are
+// watched by other means, like custom resources through the dynamic client.
+// The indexers, if any, index the informer cache of the kind.
+type kindWatcher struct {
+	client   func(clientSet kubernetes.Interface) cache.Getter
+	resource string
+	object   runtime.Object
+	indexers cache.Indexers
+	build    func(d *Driver, kind domain.OperationType, template domain.Resource, obj interface{}) (domain.Operation, bool)
+}
+
+// kindWatchers holds the watcher of every kind the driver handles, by kind name
+var kindWatchers = map[string]kindWatcher{
+	"Service": {
+		client:   func(clientSet kubernetes.Interface) cache.Getter { return clientSet.CoreV1().RESTClient() },
+		resource: "services",
+		object:   &corev1.Service{},
+		build: func(d *Driver, kind domain.OperationType, template domain.Resource, obj interface{}) (domain.Operation, bool) {
+			k8sService := obj.(*corev1.Service)
+			endpoints, err := d.clientSet.CoreV1().Endpoints(k8sService.Namespace).Get(k8sService.Name, metav1.GetOptions{})
+			if err != nil {
+				endpoints = &corev1.Endpoints{}
+			}
+			return buildOperationFromK8sService(kind, k8sService, *endpoints, d.getNodeZone), true
+		},
+	},
+	"Deployment": {
+		client:   func(clientSet kubernetes.Interface) cache.Getter { return clientSet.AppsV1().RESTClient() },
+		resource: "deployments",
+		object:   &appsv1.Deployment{},
+		build: func(d *Driver, kind domain.OperationType, template domain.Resource, obj interface{}) (domain.Operation, bool) {
+			return buildOperationFromK8sDeployment(kind, obj.(*appsv1.Deployment)), true
+		},
+	},
+	"ReplicaSet": {
+		client:   func(clientSet kubernetes.Interface) cache.Getter { return clientSet.AppsV1().RESTClient() },
+		resource: "replicasets",
+		object:   &appsv1.ReplicaSet{},
+		build: func(d *Driver, kind domain.OperationType, template domain.Resource, obj interface{}) (domain.Operation, bool) {
+			return buildOperationFromK8sReplicaSet(kind, obj.(*appsv1.ReplicaSet)), true
+		},
+	},
+	"StatefulSet": {
+		client:   func(clientSet kubernetes.Interface) cache.Getter { return clientSet.AppsV1().RESTClient() },
+		resource: "statefulsets",
+		object:   &appsv1.StatefulSet{},
+		build: func(d *Driver, kind domain.OperationType, template domain.Resource, obj interface{}) (domain.Operation, bool) {
+			return buildOperationFromK8sStatefulSet(kind, obj.(*appsv1.StatefulSet)), true
+		},
+	},
+	"DaemonSet": {
+		client:   func(clientSet kubernetes.Interface) cache.Getter { return clientSet.AppsV1().RESTClient() },
+		resource: "daemonsets",
+		object:   &appsv1.DaemonSet{},
+		build: func(d *Driver, kind domain.OperationType, template domain.Resource, obj interface{}) (domain.Operation, bool) {
+			return buildOperationFromK8sDaemonSet(kind, obj.(*appsv1.DaemonSet)), true
+		},
+	},
+	"Job": {
+		client:   func(clientSet kubernetes.Interface) cache.Getter { return clientSet.BatchV1().RESTClient() },
+		resource: "jobs",
+		object:   &batchv1.Job{},
+		indexers: cache.Indexers{ownerIndex: indexJobByOwner},
+		build: func(d *Driver, kind domain.OperationType, template domain.Resource, obj interface{}) (domain.Operation, bool) {
+			return buildOperationFromK8sJob(kind, obj.(*batchv1.Job)), true
+		},
+	},
+	"CronJob": {
+		client:   func(clientSet kubernetes.Interface) cache.Getter { return clientSet.BatchV1beta1().RESTClient() },
+		resource: "cronjobs",
+		object:   &batchv1beta1.CronJob{},
+		build: func(d *Driver, kind domain.OperationType, template domain.Resource, obj interface{}) (domain.Operation, bool) {
+			k8sCronJob := obj.(*batchv1beta1.CronJob)
+			return buildOperationFromK8sCronJob(kind, k8sCronJob, d.getOwnedJobs(k8sCronJob)), true
+		},
+	},
+	"CustomResource": {
+		build: func(d *Driver, kind domain.OperationType, template domain.Resource, obj interface{}) (domain.Operation, bool) {
+			customResource := template.GetK8sResource().(*domain.CustomResource)
+			watched, ok := d.customResources.Load(customResource.GetGroupVersionResource())
+			if !ok {
+				log.Errorf("Custom resource %s not watched", customResource.GetGroupVersionResource())
+				return domain.Operation{}, false
+			}
+			return buildOperationFromCustomResource(kind, obj.(*unstructured.Unstructured), watched.(*customResourceWatch)), true
+		},
+	},
+}
+
+// WatchableKinds returns the registered kinds the driver can watch with
+// StartWatchingResources
+func WatchableKinds() []domain.Kind {
+	var watchable []domain.Kind
+	for _, kind := range domain.Kinds() {
+		if watcher, ok := kindWatchers[kind.Name]; ok && watcher.client != nil {
+			watchable = append(watchable, kind)
+		}
+	}
+	return watchable
+}
+
+func watcherOf(resource domain.Resource) (kindWatcher, bool) {
+	kind, ok := domain.KindOf(resource.K8sResource)
+	if !ok {
+		return kindWatcher{}, false
+	}
+	watcher, ok := kindWatchers[kind.Name]
+	return watcher, ok
+}
@@ -0,0 +1,129 @@
+package k8sdriver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// LeaderElectionConfig ...
+type LeaderElectionConfig struct {
+	LeaseName      string
+	LeaseNamespace string
+	Identity       string
+	LeaseDuration  time.Duration
+	RenewDeadline  time.Duration
+	RetryPeriod    time.Duration
+}
+
+// LeaderElector runs the collector watches only on the replica holding the
+// lease, the other replicas wait to take over.
+type LeaderElector struct {
+	clientSet        kubernetes.Interface
+	config           LeaderElectionConfig
+	metrics          Metrics
+	leader           bool
+	currentLeader    string
+	mutex            sync.RWMutex
+	onStoppedLeading func()
+}
+
+// BuildLeaderElector ...
+func (d *Driver) BuildLeaderElector(config LeaderElectionConfig) *LeaderElector {
+	return &LeaderElector{
+		clientSet: d.clientSet,
+		config:    config,
+		metrics:   d.metrics,
+		onStoppedLeading: func() {
+			log.Fatalf("%s lost the %s/%s lease, restarting as follower", config.Identity, config.LeaseNamespace, config.LeaseName)
+		},
+	}
+}
+
+// Run blocks campaigning for the lease until ctx is done, calling lead with a
+// context that is done when the lease is lost once it is acquired. Losing the
+// lease for any other reason than ctx being done stops the collector.
+func (e *LeaderElector) Run(ctx context.Context, lead func(context.Context)) error {
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      e.config.LeaseName,
+			Namespace: e.config.LeaseNamespace,
+		},
+		Client: e.clientSet.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: e.config.Identity,
+		},
+	}
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   e.config.LeaseDuration,
+		RenewDeadline:   e.config.RenewDeadline,
+		RetryPeriod:     e.config.RetryPeriod,
+		ReleaseOnCancel: true,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leading context.Context) {
+				log.Infof("%s acquired the %s/%s lease", e.config.Identity, e.config.LeaseNamespace, e.config.LeaseName)
+				e.setLeader(true)
+				lead(leading)
+			},
+			OnStoppedLeading: func() {
+				e.setLeader(false)
+				if ctx.Err() == nil {
+					e.onStoppedLeading()
+				}
+			},
+			OnNewLeader: func(identity string) {
+				log.Infof("%s/%s lease held by %s", e.config.LeaseNamespace, e.config.LeaseName, identity)
+				e.mutex.Lock()
+				e.currentLeader = identity
+				e.mutex.Unlock()
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	e.setLeader(false)
+	elector.Run(ctx)
+	return nil
+}
+
+// IsLeader ...
+func (e *LeaderElector) IsLeader() bool {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	return e.leader
+}
+
+// GetLeader returns the identity of the replica holding the lease
+func (e *LeaderElector) GetLeader() string {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	return e.currentLeader
+}
+
+// GetIdentity ...
+func (e *LeaderElector) GetIdentity() string {
+	return e.config.Identity
+}
+
+func (e *LeaderElector) setLeader(leader bool) {
+	e.mutex.Lock()
+	e.leader = leader
+	e.mutex.Unlock()
+
+	value := 0.0
+	if leader {
+		value = 1
+	}
+	e.metrics.SetGauge("leader", value, e.config.Identity)
+}
@@ -0,0 +1,74 @@
+package k8sdriver
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/walmartdigital/katalog/domain"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Leader election", func() {
+
+	var (
+		metrics *fakeMetrics
+		first   *LeaderElector
+		second  *LeaderElector
+	)
+
+	buildElector := func(driver *Driver, identity string) *LeaderElector {
+		return driver.BuildLeaderElector(LeaderElectionConfig{
+			LeaseName:      "katalog-collector",
+			LeaseNamespace: "katalog",
+			Identity:       identity,
+			LeaseDuration:  time.Second,
+			RenewDeadline:  500 * time.Millisecond,
+			RetryPeriod:    100 * time.Millisecond,
+		})
+	}
+
+	BeforeEach(func() {
+		clientSet := fake.NewSimpleClientset()
+		filter, _ := BuildNamespaceFilter(nil, nil, "")
+		metrics = &fakeMetrics{counters: make(map[string]int), gauges: make(map[string]float64)}
+		driver := buildDriver(clientSet, domain.Cluster{}, 0, filter, nil, metrics)
+		first = buildElector(driver, "collector-a")
+		second = buildElector(driver, "collector-b")
+	})
+
+	It("should only lead on one replica and hand over the lease when the leader stops", func() {
+		firstCtx, stopFirst := context.WithCancel(context.Background())
+		secondCtx, stopSecond := context.WithCancel(context.Background())
+		defer stopSecond()
+		firstLeads := make(chan bool, 1)
+		secondLeads := make(chan bool, 1)
+
+		go first.Run(firstCtx, func(context.Context) { firstLeads <- true })
+		Eventually(firstLeads, 5*time.Second).Should(Receive())
+		Expect(first.IsLeader()).To(BeTrue())
+		Expect(metrics.getGauge("leader/collector-a")).To(Equal(1.0))
+
+		go second.Run(secondCtx, func(context.Context) { secondLeads <- true })
+		Eventually(second.GetLeader, 5*time.Second).Should(Equal("collector-a"))
+		Consistently(secondLeads, 300*time.Millisecond).ShouldNot(Receive())
+		Expect(second.IsLeader()).To(BeFalse())
+		Expect(metrics.getGauge("leader/collector-b")).To(Equal(0.0))
+
+		stopFirst()
+		Eventually(secondLeads, 5*time.Second).Should(Receive())
+		Expect(second.IsLeader()).To(BeTrue())
+		Eventually(first.IsLeader).Should(BeFalse())
+	})
+
+	It("should fail to campaign with inconsistent durations", func() {
+		elector := first
+		elector.config.RenewDeadline = 2 * time.Second
+
+		err := elector.Run(context.Background(), func(context.Context) {})
+
+		Expect(err).To(HaveOccurred())
+	})
+
+})
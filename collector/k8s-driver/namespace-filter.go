@@ -0,0 +1,123 @@
+package k8sdriver
+
+import (
+	"path"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// NamespaceFilter decides which namespaces are cataloged. A namespace is
+// cataloged when it matches one of the include globs (if any), none of the
+// exclude globs and, when a selector is configured, its labels match it.
+type NamespaceFilter struct {
+	include  []string
+	exclude  []string
+	selector labels.Selector
+	labels   map[string]map[string]string
+	lookup   func(namespace string) (map[string]string, bool)
+	mutex    sync.Mutex
+}
+
+// BuildNamespaceFilter ...
+func BuildNamespaceFilter(include []string, exclude []string, selector string) (*NamespaceFilter, error) {
+	for _, pattern := range append(append([]string{}, include...), exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	filter := &NamespaceFilter{
+		include: include,
+		exclude: exclude,
+		labels:  make(map[string]map[string]string),
+	}
+
+	if selector != "" {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return nil, err
+		}
+		filter.selector = parsed
+	}
+
+	return filter, nil
+}
+
+// HasSelector ...
+func (f *NamespaceFilter) HasSelector() bool {
+	return f.selector != nil
+}
+
+// Allows tells whether resources of the namespace must be cataloged. Unknown
+// namespaces are looked up without holding the lock, so handlers of other
+// namespaces do not wait for the API server.
+func (f *NamespaceFilter) Allows(namespace string) bool {
+	f.mutex.Lock()
+	nsLabels, ok := f.labels[namespace]
+	f.mutex.Unlock()
+
+	if !ok && f.selector != nil && f.lookup != nil {
+		nsLabels, ok = f.lookup(namespace)
+		if ok {
+			f.mutex.Lock()
+			// The namespace watch may have recorded fresher labels meanwhile
+			if known, recorded := f.labels[namespace]; recorded {
+				nsLabels = known
+			} else {
+				f.labels[namespace] = nsLabels
+			}
+			f.mutex.Unlock()
+		}
+	}
+
+	return f.allows(namespace, nsLabels, ok)
+}
+
+// UpdateNamespaceLabels records the current labels of a namespace and returns
+// true when the change flips whether the namespace is cataloged.
+func (f *NamespaceFilter) UpdateNamespaceLabels(namespace string, nsLabels map[string]string) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	previousLabels, known := f.labels[namespace]
+	f.labels[namespace] = nsLabels
+	if !known {
+		return false
+	}
+
+	return f.allows(namespace, previousLabels, true) != f.allows(namespace, nsLabels, true)
+}
+
+// ForgetNamespace ...
+func (f *NamespaceFilter) ForgetNamespace(namespace string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	delete(f.labels, namespace)
+}
+
+func (f *NamespaceFilter) allows(namespace string, nsLabels map[string]string, labelsKnown bool) bool {
+	if len(f.include) > 0 && !matchesAny(f.include, namespace) {
+		return false
+	}
+
+	if matchesAny(f.exclude, namespace) {
+		return false
+	}
+
+	if f.selector == nil {
+		return true
+	}
+
+	return labelsKnown && f.selector.Matches(labels.Set(nsLabels))
+}
+
+func matchesAny(patterns []string, namespace string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, namespace); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package k8sdriver
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Namespace filter", func() {
+
+	It("should catalog every namespace when nothing is configured", func() {
+		filter, err := BuildNamespaceFilter(nil, nil, "")
+
+		Expect(err).To(BeNil())
+		Expect(filter.Allows("default")).To(BeTrue())
+		Expect(filter.Allows("kube-system")).To(BeTrue())
+	})
+
+	It("should only catalog namespaces matching the include globs", func() {
+		filter, _ := BuildNamespaceFilter([]string{"team-*", "payments"}, nil, "")
+
+		Expect(filter.Allows("team-a")).To(BeTrue())
+		Expect(filter.Allows("payments")).To(BeTrue())
+		Expect(filter.Allows("payments-dev")).To(BeFalse())
+		Expect(filter.Allows("default")).To(BeFalse())
+	})
+
+	It("should not catalog namespaces matching the exclude globs even if included", func() {
+		filter, _ := BuildNamespaceFilter([]string{"team-*"}, []string{"kube-*", "team-?-sandbox"}, "")
+
+		Expect(filter.Allows("team-a")).To(BeTrue())
+		Expect(filter.Allows("team-a-sandbox")).To(BeFalse())
+		Expect(filter.Allows("kube-system")).To(BeFalse())
+	})
+
+	It("should fail with invalid globs or selectors", func() {
+		_, errGlob := BuildNamespaceFilter([]string{"team-["}, nil, "")
+		_, errSelector := BuildNamespaceFilter(nil, nil, "katalog.io/catalog in (")
+
+		Expect(errGlob).NotTo(BeNil())
+		Expect(errSelector).NotTo(BeNil())
+	})
+
+	It("should catalog namespaces whose labels match the selector", func() {
+		filter, _ := BuildNamespaceFilter(nil, nil, "katalog.io/catalog=enabled")
+		filter.lookup = func(namespace string) (map[string]string, bool) {
+			if namespace == "team-a" {
+				return map[string]string{"katalog.io/catalog": "enabled"}, true
+			}
+			return map[string]string{}, true
+		}
+
+		Expect(filter.HasSelector()).To(BeTrue())
+		Expect(filter.Allows("team-a")).To(BeTrue())
+		Expect(filter.Allows("team-b")).To(BeFalse())
+	})
+
+	It("should not catalog namespaces whose labels cannot be resolved", func() {
+		filter, _ := BuildNamespaceFilter(nil, nil, "katalog.io/catalog=enabled")
+
+		Expect(filter.Allows("team-a")).To(BeFalse())
+	})
+
+	It("should report when a label change flips whether a namespace is cataloged", func() {
+		filter, _ := BuildNamespaceFilter(nil, nil, "katalog.io/catalog=enabled")
+
+		Expect(filter.UpdateNamespaceLabels("team-a", map[string]string{})).To(BeFalse())
+		Expect(filter.Allows("team-a")).To(BeFalse())
+
+		Expect(filter.UpdateNamespaceLabels("team-a", map[string]string{"katalog.io/catalog": "enabled"})).To(BeTrue())
+		Expect(filter.Allows("team-a")).To(BeTrue())
+
+		Expect(filter.UpdateNamespaceLabels("team-a", map[string]string{"katalog.io/catalog": "enabled", "team": "a"})).To(BeFalse())
+
+		Expect(filter.UpdateNamespaceLabels("team-a", map[string]string{"katalog.io/catalog": "disabled"})).To(BeTrue())
+		Expect(filter.Allows("team-a")).To(BeFalse())
+	})
+
+	It("should not block other namespaces while looking a namespace up", func() {
+		filter, _ := BuildNamespaceFilter(nil, nil, "katalog.io/catalog=enabled")
+		filter.UpdateNamespaceLabels("team-a", map[string]string{"katalog.io/catalog": "enabled"})
+		lookingUp := make(chan struct{})
+		release := make(chan struct{})
+		filter.lookup = func(namespace string) (map[string]string, bool) {
+			close(lookingUp)
+			<-release
+			return map[string]string{}, true
+		}
+		allowed := make(chan bool)
+		go func() {
+			allowed <- filter.Allows("team-b")
+		}()
+		<-lookingUp
+
+		Expect(filter.Allows("team-a")).To(BeTrue())
+
+		// Labels recorded by the namespace watch during the lookup are fresher
+		filter.UpdateNamespaceLabels("team-b", map[string]string{"katalog.io/catalog": "enabled"})
+		close(release)
+		Expect(<-allowed).To(BeTrue())
+		Expect(filter.Allows("team-b")).To(BeTrue())
+	})
+
+})
@@ -3,12 +3,15 @@ package k8sdriver
 import (
 	"github.com/walmartdigital/katalog/domain"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
-func buildOperationFromK8sService(kind domain.OperationType, sourceService *corev1.Service, endpoints corev1.Endpoints) domain.Operation {
+func buildOperationFromK8sService(kind domain.OperationType, sourceService *corev1.Service, endpoints corev1.Endpoints, zoneOf func(string) string) domain.Operation {
 	destinationService := buildServiceFromK8sService(sourceService)
-	for _, endpoint := range buildEndpointFromK8sEndpoints(endpoints) {
+	for _, endpoint := range buildEndpointFromK8sEndpoints(endpoints, zoneOf) {
 		destinationService.AddInstance(endpoint)
 	}
 	resource := &domain.Resource{
@@ -44,3 +47,63 @@ func buildOperationFromK8sStatefulSet(kind domain.OperationType, sourceStatefulS
 	}
 	return *operation
 }
+
+func buildOperationFromK8sReplicaSet(kind domain.OperationType, sourceReplicaSet *appsv1.ReplicaSet) domain.Operation {
+	destinationReplicaSet := buildReplicaSetFromK8sReplicaSet(sourceReplicaSet)
+	resource := &domain.Resource{
+		K8sResource: &destinationReplicaSet,
+	}
+	operation := &domain.Operation{
+		Kind:     kind,
+		Resource: *resource,
+	}
+	return *operation
+}
+
+func buildOperationFromK8sDaemonSet(kind domain.OperationType, sourceDaemonSet *appsv1.DaemonSet) domain.Operation {
+	destinationDaemonSet := buildDaemonSetFromK8sDaemonSet(sourceDaemonSet)
+	resource := &domain.Resource{
+		K8sResource: &destinationDaemonSet,
+	}
+	operation := &domain.Operation{
+		Kind:     kind,
+		Resource: *resource,
+	}
+	return *operation
+}
+
+func buildOperationFromK8sCronJob(kind domain.OperationType, sourceCronJob *batchv1beta1.CronJob, jobs []batchv1.Job) domain.Operation {
+	destinationCronJob := buildCronJobFromK8sCronJob(sourceCronJob, jobs)
+	resource := &domain.Resource{
+		K8sResource: &destinationCronJob,
+	}
+	operation := &domain.Operation{
+		Kind:     kind,
+		Resource: *resource,
+	}
+	return *operation
+}
+
+func buildOperationFromK8sJob(kind domain.OperationType, sourceJob *batchv1.Job) domain.Operation {
+	destinationJob := buildJobFromK8sJob(sourceJob)
+	resource := &domain.Resource{
+		K8sResource: &destinationJob,
+	}
+	operation := &domain.Operation{
+		Kind:     kind,
+		Resource: *resource,
+	}
+	return *operation
+}
+
+func buildOperationFromCustomResource(kind domain.OperationType, source *unstructured.Unstructured, watch *customResourceWatch) domain.Operation {
+	destinationCustomResource := buildCustomResourceFromUnstructured(source, watch)
+	resource := &domain.Resource{
+		K8sResource: &destinationCustomResource,
+	}
+	operation := &domain.Operation{
+		Kind:     kind,
+		Resource: *resource,
+	}
+	return *operation
+}
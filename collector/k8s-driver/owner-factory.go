@@ -0,0 +1,24 @@
+package k8sdriver
+
+import (
+	"github.com/walmartdigital/katalog/domain"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func buildOwnerReferencesFromK8sOwnerReferences(sourceReferences []metav1.OwnerReference) []domain.OwnerReference {
+	if len(sourceReferences) == 0 {
+		return nil
+	}
+
+	references := make([]domain.OwnerReference, 0, len(sourceReferences))
+	for _, reference := range sourceReferences {
+		references = append(references, domain.OwnerReference{
+			APIVersion: reference.APIVersion,
+			Kind:       reference.Kind,
+			Name:       reference.Name,
+			UID:        string(reference.UID),
+			Controller: reference.Controller != nil && *reference.Controller,
+		})
+	}
+	return references
+}
@@ -0,0 +1,199 @@
+package k8sdriver
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/walmartdigital/katalog/domain"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/tools/cache"
+)
+
+const workloadIndex = "workload"
+
+// StartWatchingPods keeps the image digests run by the pods of every workload
+// and publishes a workload update when they change. Changes on the same
+// workload are debounced so a rollout produces a handful of events instead of
+// one per pod. Pending changes are published when ctx is done.
+func (d *Driver) StartWatchingPods(ctx context.Context, debounce time.Duration) {
+	debouncer := buildDebouncer(debounce, func(key string) {
+		d.publishWorkloadImages(key)
+	})
+
+	listWatch := cache.NewListWatchFromClient(
+		d.clientSet.CoreV1().RESTClient(),
+		"pods",
+		corev1.NamespaceAll,
+		fields.Everything(),
+	)
+
+	trigger := func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			return
+		}
+		if key, ok := workloadOfPod(pod); ok {
+			debouncer.Trigger(key)
+		}
+	}
+
+	indexer, controller := cache.NewIndexerInformer(
+		listWatch,
+		&corev1.Pod{},
+		d.resyncPeriod,
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: trigger,
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				trigger(newObj)
+			},
+			DeleteFunc: trigger,
+		},
+		cache.Indexers{workloadIndex: indexPodByWorkload},
+	)
+
+	d.watchedMutex.Lock()
+	d.pods = indexer
+	d.watchedMutex.Unlock()
+
+	d.run(ctx, func(stop <-chan struct{}) {
+		controller.Run(stop)
+		debouncer.Flush()
+	})
+}
+
+// workloadOfPod returns the key of the workload controlling the pod, e.g.
+// Deployment/default/api. Deployments are found through the name of their
+// ReplicaSet, which is suffixed by the pod-template-hash label of the pod.
+func workloadOfPod(pod *corev1.Pod) (string, bool) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return "", false
+	}
+
+	switch owner.Kind {
+	case "ReplicaSet":
+		suffix := "-" + pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+		if suffix == "-" || !strings.HasSuffix(owner.Name, suffix) {
+			return "", false
+		}
+		return workloadKey("Deployment", pod.Namespace, strings.TrimSuffix(owner.Name, suffix)), true
+	case "StatefulSet", "DaemonSet", "Job":
+		return workloadKey(owner.Kind, pod.Namespace, owner.Name), true
+	}
+
+	return "", false
+}
+
+func workloadKey(kind string, namespace string, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+func indexPodByWorkload(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil, nil
+	}
+	if key, ok := workloadOfPod(pod); ok {
+		return []string{key}, nil
+	}
+	return nil, nil
+}
+
+// getRunningImages counts the pods of the workload by container and digest
+func (d *Driver) getRunningImages(key string) []domain.RunningImage {
+	d.watchedMutex.Lock()
+	pods := d.pods
+	d.watchedMutex.Unlock()
+
+	if pods == nil {
+		return nil
+	}
+
+	objs, err := pods.ByIndex(workloadIndex, key)
+	if err != nil {
+		log.Errorln(err)
+		return nil
+	}
+
+	counts := make(map[domain.RunningImage]int32)
+	for _, obj := range objs {
+		pod := obj.(*corev1.Pod)
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			digest := domain.DigestOf(status.ImageID)
+			if digest == "" {
+				continue
+			}
+			counts[domain.RunningImage{Container: status.Name, Digest: digest}]++
+		}
+	}
+
+	if len(counts) == 0 {
+		return nil
+	}
+
+	runningImages := make([]domain.RunningImage, 0, len(counts))
+	for runningImage, count := range counts {
+		runningImage.Pods = count
+		runningImages = append(runningImages, runningImage)
+	}
+	sort.Slice(runningImages, func(i, j int) bool {
+		if runningImages[i].Container != runningImages[j].Container {
+			return runningImages[i].Container < runningImages[j].Container
+		}
+		return runningImages[i].Digest < runningImages[j].Digest
+	})
+
+	return runningImages
+}
+
+// attachRunningImages sets the digests run by the pods of the workload the
+// operation publishes
+func (d *Driver) attachRunningImages(operation domain.Operation) {
+	workload, ok := operation.Resource.K8sResource.(domain.PodRunner)
+	if !ok || operation.Kind == domain.OperationTypeDelete {
+		return
+	}
+
+	kind := operation.Resource.GetType().Elem().Name()
+	workload.SetRunningImages(d.getRunningImages(workloadKey(kind, workload.GetNamespace(), workload.GetName())))
+}
+
+// publishWorkloadImages publishes the workload again from the informer cache,
+// with the digests its pods run now
+func (d *Driver) publishWorkloadImages(key string) {
+	parts := strings.SplitN(key, "/", 2)
+	if len(parts) != 2 {
+		return
+	}
+
+	d.watchedMutex.Lock()
+	watched := append([]watchedResource{}, d.watched...)
+	d.watchedMutex.Unlock()
+
+	for _, w := range watched {
+		if w.resource.GetType().Elem().Name() != parts[0] {
+			continue
+		}
+
+		obj, exists, err := w.store.GetByKey(parts[1])
+		if err != nil {
+			log.Errorln(err)
+			return
+		}
+		if !exists || !d.isCataloged(obj) || d.isIgnored(w.resource, obj) {
+			return
+		}
+
+		d.publish(w.channel, domain.OperationTypeUpdate, w.resource, obj)
+		return
+	}
+}
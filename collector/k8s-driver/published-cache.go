@@ -0,0 +1,82 @@
+package k8sdriver
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/walmartdigital/katalog/domain"
+)
+
+// publishedCache remembers a fingerprint of the last state published for each
+// resource, so informer resyncs only publish resources that actually changed.
+type publishedCache struct {
+	fingerprints map[string]string
+	mutex        sync.Mutex
+}
+
+func buildPublishedCache() *publishedCache {
+	return &publishedCache{fingerprints: make(map[string]string)}
+}
+
+// shouldPublish records the operation and tells whether it must be published.
+// Adds and deletes are always published, updates only when the state differs.
+func (c *publishedCache) shouldPublish(operation domain.Operation) bool {
+	key := publishedKey(operation.Resource)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if operation.Kind == domain.OperationTypeDelete {
+		delete(c.fingerprints, key)
+		return true
+	}
+
+	current, err := fingerprint(operation.Resource)
+	if err != nil {
+		log.Errorln(err)
+		return true
+	}
+
+	if operation.Kind == domain.OperationTypeUpdate && c.fingerprints[key] == current {
+		return false
+	}
+
+	c.fingerprints[key] = current
+	return true
+}
+
+func (c *publishedCache) forget(operation domain.Operation) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.fingerprints, publishedKey(operation.Resource))
+}
+
+func publishedKey(resource domain.Resource) string {
+	return resource.GetType().Elem().Name() + "/" + resource.GetKey()
+}
+
+// fingerprint hashes the resource without its timestamp, which the factories
+// set to the time the event was handled.
+func fingerprint(resource domain.Resource) (string, error) {
+	serialized, err := json.Marshal(resource.GetK8sResource())
+	if err != nil {
+		return "", err
+	}
+
+	var fields map[string]interface{}
+	err = json.Unmarshal(serialized, &fields)
+	if err != nil {
+		return "", err
+	}
+	delete(fields, "Timestamp")
+
+	serialized, err = json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(serialized)), nil
+}
@@ -0,0 +1,71 @@
+package k8sdriver
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"path"
+)
+
+const redactionActionDrop = "drop"
+const redactionActionHash = "hash"
+
+// RedactionPolicy drops or hashes the label and annotation keys matching its
+// globs before resources leave the collector.
+type RedactionPolicy struct {
+	drop []string
+	hash []string
+}
+
+type redaction struct {
+	key    string
+	action string
+}
+
+// BuildRedactionPolicy ...
+func BuildRedactionPolicy(drop []string, hash []string) (*RedactionPolicy, error) {
+	for _, pattern := range append(append([]string{}, drop...), hash...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return &RedactionPolicy{drop: drop, hash: hash}, nil
+}
+
+// IsEmpty ...
+func (p *RedactionPolicy) IsEmpty() bool {
+	return p == nil || (len(p.drop) == 0 && len(p.hash) == 0)
+}
+
+// redact returns a copy of values with the matching keys dropped or hashed,
+// along with what was redacted. Drop wins when a key matches both lists.
+func (p *RedactionPolicy) redact(values map[string]string) (map[string]string, []redaction) {
+	if p.IsEmpty() || len(values) == 0 {
+		return values, nil
+	}
+
+	output := make(map[string]string, len(values))
+	redactions := make([]redaction, 0)
+	for key, value := range values {
+		switch {
+		case matchesAnyKey(p.drop, key):
+			redactions = append(redactions, redaction{key: key, action: redactionActionDrop})
+		case matchesAnyKey(p.hash, key):
+			output[key] = fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(value)))
+			redactions = append(redactions, redaction{key: key, action: redactionActionHash})
+		default:
+			output[key] = value
+		}
+	}
+
+	return output, redactions
+}
+
+func matchesAnyKey(patterns []string, key string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package k8sdriver
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Redaction policy", func() {
+
+	It("should drop keys matching drop patterns and hash keys matching hash patterns", func() {
+		policy, err := BuildRedactionPolicy([]string{"kubectl.kubernetes.io/*", "secret"}, []string{"secret", "owner"})
+		Expect(err).NotTo(HaveOccurred())
+
+		output, redactions := policy.redact(map[string]string{
+			"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			"secret": "value",
+			"owner":  "value",
+			"app":    "api",
+		})
+
+		Expect(output).To(HaveLen(2))
+		Expect(output["app"]).To(Equal("api"))
+		Expect(output["owner"]).To(Equal("sha256:cd42404d52ad55ccfa9aca4adc828aa5800ad9d385a0671fbcbf724118320619"))
+		Expect(redactions).To(ConsistOf(
+			redaction{key: "kubectl.kubernetes.io/last-applied-configuration", action: redactionActionDrop},
+			redaction{key: "secret", action: redactionActionDrop},
+			redaction{key: "owner", action: redactionActionHash},
+		))
+	})
+
+	It("should leave values untouched when the policy is empty", func() {
+		policy, _ := BuildRedactionPolicy(nil, nil)
+		values := map[string]string{"app": "api"}
+
+		output, redactions := policy.redact(values)
+
+		Expect(policy.IsEmpty()).To(BeTrue())
+		Expect(output).To(Equal(values))
+		Expect(redactions).To(BeEmpty())
+	})
+
+	It("should reject malformed patterns", func() {
+		_, err := BuildRedactionPolicy([]string{"["}, nil)
+
+		Expect(err).To(HaveOccurred())
+	})
+
+})
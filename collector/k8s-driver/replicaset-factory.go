@@ -0,0 +1,35 @@
+package k8sdriver
+
+import (
+	"time"
+
+	"github.com/walmartdigital/katalog/domain"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// buildReplicaSetFromK8sReplicaSet ...
+func buildReplicaSetFromK8sReplicaSet(sourceReplicaSet *appsv1.ReplicaSet) domain.ReplicaSet {
+	destinationReplicaSet := &domain.ReplicaSet{
+		ID:                 string(sourceReplicaSet.GetUID()),
+		Name:               sourceReplicaSet.GetName(),
+		Generation:         sourceReplicaSet.GetGeneration(),
+		Namespace:          sourceReplicaSet.GetNamespace(),
+		Labels:             sourceReplicaSet.GetLabels(),
+		Annotations:        sourceReplicaSet.GetAnnotations(),
+		OwnerReferences:    buildOwnerReferencesFromK8sOwnerReferences(sourceReplicaSet.GetOwnerReferences()),
+		Replicas:           sourceReplicaSet.Status.Replicas,
+		ReadyReplicas:      sourceReplicaSet.Status.ReadyReplicas,
+		AvailableReplicas:  sourceReplicaSet.Status.AvailableReplicas,
+		Timestamp:          time.Now().UTC().Format(collectedFormat),
+		ObservedGeneration: sourceReplicaSet.Status.ObservedGeneration,
+	}
+
+	for _, owner := range sourceReplicaSet.GetOwnerReferences() {
+		if owner.Kind == "Deployment" {
+			destinationReplicaSet.DeploymentID = string(owner.UID)
+			destinationReplicaSet.DeploymentName = owner.Name
+		}
+	}
+
+	return *destinationReplicaSet
+}
@@ -0,0 +1,67 @@
+package k8sdriver
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/walmartdigital/katalog/domain"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("ReplicaSet builder struct", func() {
+
+	BeforeEach(func() {})
+
+	It("should build a ReplicaSet object when pass k8sReplicaSet resource", func() {
+		replicaSet := buildReplicaSetFromK8sReplicaSet(buildReplicaSet())
+
+		Expect(replicaSet.GetID()).To(Equal("UIDExample"))
+		Expect(replicaSet.GetGeneration()).To(Equal(int64(5)))
+		Expect(replicaSet.GetName()).To(Equal("NameExample-7d4b9"))
+		Expect(replicaSet.GetNamespace()).To(Equal("NameSpaceExample"))
+		Expect(replicaSet.GetLabels()).To(Equal(map[string]string{"pod-template-hash": "7d4b9"}))
+		Expect(replicaSet.GetAnnotations()).To(Equal(map[string]string{"deployment.kubernetes.io/revision": "3"}))
+		Expect(replicaSet.GetDeploymentID()).To(Equal("DeploymentUIDExample"))
+		Expect(replicaSet.GetDeploymentName()).To(Equal("NameExample"))
+		Expect(replicaSet.GetOwnerReferences()).To(Equal([]domain.OwnerReference{{Kind: "Deployment", Name: "NameExample", UID: "DeploymentUIDExample"}}))
+		Expect(replicaSet.Replicas).To(Equal(int32(3)))
+		Expect(replicaSet.ReadyReplicas).To(Equal(int32(2)))
+		Expect(replicaSet.GetObservedGeneration()).To(Equal(int64(5)))
+		Expect(replicaSet.GetTimestamp()).Should(MatchRegexp(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}`))
+	})
+
+	It("should leave the Deployment reference empty when the replicaset is not owned by a deployment", func() {
+		k8sReplicaSet := buildReplicaSet()
+		k8sReplicaSet.OwnerReferences = nil
+
+		replicaSet := buildReplicaSetFromK8sReplicaSet(k8sReplicaSet)
+
+		Expect(replicaSet.GetDeploymentID()).To(Equal(""))
+		Expect(replicaSet.GetDeploymentName()).To(Equal(""))
+	})
+
+})
+
+func buildReplicaSet() *appsv1.ReplicaSet {
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "NameExample-7d4b9",
+			Namespace:   "NameSpaceExample",
+			UID:         "UIDExample",
+			Generation:  5,
+			Labels:      map[string]string{"pod-template-hash": "7d4b9"},
+			Annotations: map[string]string{"deployment.kubernetes.io/revision": "3"},
+			OwnerReferences: []metav1.OwnerReference{{
+				Kind: "Deployment",
+				Name: "NameExample",
+				UID:  "DeploymentUIDExample",
+			}},
+		},
+		Status: appsv1.ReplicaSetStatus{
+			Replicas:           3,
+			ReadyReplicas:      2,
+			AvailableReplicas:  2,
+			ObservedGeneration: 5,
+		},
+	}
+}
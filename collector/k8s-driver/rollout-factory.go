@@ -0,0 +1,89 @@
+package k8sdriver
+
+import (
+	"github.com/walmartdigital/katalog/domain"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func buildReplicasFromK8sDeployment(sourceDeployment *appsv1.Deployment) *domain.Replicas {
+	return &domain.Replicas{
+		Desired:     desiredReplicas(sourceDeployment.Spec.Replicas),
+		Ready:       sourceDeployment.Status.ReadyReplicas,
+		Available:   sourceDeployment.Status.AvailableReplicas,
+		Updated:     sourceDeployment.Status.UpdatedReplicas,
+		Unavailable: sourceDeployment.Status.UnavailableReplicas,
+	}
+}
+
+func buildStrategyFromK8sDeployment(sourceDeployment *appsv1.Deployment) *domain.Strategy {
+	strategy := &domain.Strategy{Type: string(sourceDeployment.Spec.Strategy.Type)}
+	if rollingUpdate := sourceDeployment.Spec.Strategy.RollingUpdate; rollingUpdate != nil {
+		strategy.MaxSurge = formatIntOrString(rollingUpdate.MaxSurge)
+		strategy.MaxUnavailable = formatIntOrString(rollingUpdate.MaxUnavailable)
+	}
+	return strategy
+}
+
+func buildConditionsFromK8sDeployment(sourceDeployment *appsv1.Deployment) []domain.Condition {
+	var conditions []domain.Condition
+	for _, c := range sourceDeployment.Status.Conditions {
+		conditions = append(conditions, domain.Condition{
+			Type:               string(c.Type),
+			Status:             string(c.Status),
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastUpdateTime:     formatK8sTime(&c.LastUpdateTime),
+			LastTransitionTime: formatK8sTime(&c.LastTransitionTime),
+		})
+	}
+	return conditions
+}
+
+func buildReplicasFromK8sStatefulSet(sourceStatefulSet *appsv1.StatefulSet) *domain.Replicas {
+	return &domain.Replicas{
+		Desired: desiredReplicas(sourceStatefulSet.Spec.Replicas),
+		Current: sourceStatefulSet.Status.CurrentReplicas,
+		Ready:   sourceStatefulSet.Status.ReadyReplicas,
+		// Statefulsets do not report available replicas yet, ready ones are
+		Available: sourceStatefulSet.Status.ReadyReplicas,
+		Updated:   sourceStatefulSet.Status.UpdatedReplicas,
+	}
+}
+
+func buildStrategyFromK8sStatefulSet(sourceStatefulSet *appsv1.StatefulSet) *domain.Strategy {
+	strategy := &domain.Strategy{Type: string(sourceStatefulSet.Spec.UpdateStrategy.Type)}
+	if rollingUpdate := sourceStatefulSet.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil {
+		strategy.Partition = rollingUpdate.Partition
+	}
+	return strategy
+}
+
+func buildConditionsFromK8sStatefulSet(sourceStatefulSet *appsv1.StatefulSet) []domain.Condition {
+	var conditions []domain.Condition
+	for _, c := range sourceStatefulSet.Status.Conditions {
+		conditions = append(conditions, domain.Condition{
+			Type:               string(c.Type),
+			Status:             string(c.Status),
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastTransitionTime: formatK8sTime(&c.LastTransitionTime),
+		})
+	}
+	return conditions
+}
+
+// desiredReplicas defaults to one replica like the API server does
+func desiredReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+func formatIntOrString(value *intstr.IntOrString) string {
+	if value == nil {
+		return ""
+	}
+	return value.String()
+}
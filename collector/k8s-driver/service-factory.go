@@ -15,15 +15,60 @@ func buildServiceFromK8sService(sourceService *corev1.Service) domain.Service {
 	}
 
 	destinationService := &domain.Service{
-		ID:                 string(sourceService.GetUID()),
-		Name:               sourceService.GetName(),
-		Address:            sourceService.Spec.ClusterIP,
-		Port:               port,
-		Namespace:          sourceService.GetNamespace(),
-		Labels:             sourceService.GetLabels(),
-		Timestamp:          time.Now().UTC().Format(timestampFormat),
-		ObservedGeneration: 0,
+		ID:                  string(sourceService.GetUID()),
+		Name:                sourceService.GetName(),
+		Address:             sourceService.Spec.ClusterIP,
+		Port:                port,
+		Ports:               buildServicePorts(sourceService.Spec.Ports),
+		Type:                string(sourceService.Spec.Type),
+		ExternalIPs:         sourceService.Spec.ExternalIPs,
+		ExternalName:        sourceService.Spec.ExternalName,
+		LoadBalancerIngress: buildLoadBalancerIngress(sourceService.Status.LoadBalancer.Ingress),
+		Namespace:           sourceService.GetNamespace(),
+		Labels:              sourceService.GetLabels(),
+		Annotations:         sourceService.GetAnnotations(),
+		OwnerReferences:     buildOwnerReferencesFromK8sOwnerReferences(sourceService.GetOwnerReferences()),
+		Selector:            sourceService.Spec.Selector,
+		Timestamp:           time.Now().UTC().Format(collectedFormat),
+		ObservedGeneration:  0,
 	}
 
 	return *destinationService
 }
+
+func buildServicePorts(sourcePorts []corev1.ServicePort) []domain.ServicePort {
+	if len(sourcePorts) <= 0 {
+		return nil
+	}
+
+	output := make([]domain.ServicePort, len(sourcePorts))
+	for i, port := range sourcePorts {
+		output[i] = domain.ServicePort{
+			Name:       port.Name,
+			Protocol:   string(port.Protocol),
+			Port:       int(port.Port),
+			TargetPort: port.TargetPort.String(),
+			NodePort:   int(port.NodePort),
+		}
+	}
+
+	return output
+}
+
+func buildLoadBalancerIngress(ingresses []corev1.LoadBalancerIngress) []string {
+	if len(ingresses) <= 0 {
+		return nil
+	}
+
+	output := make([]string, 0, len(ingresses))
+	for _, ingress := range ingresses {
+		if ingress.IP != "" {
+			output = append(output, ingress.IP)
+		}
+		if ingress.Hostname != "" {
+			output = append(output, ingress.Hostname)
+		}
+	}
+
+	return output
+}
@@ -3,8 +3,10 @@ package k8sdriver
 import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/walmartdigital/katalog/domain"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 var _ = Describe("Service builder struct", func() {
@@ -21,24 +23,74 @@ var _ = Describe("Service builder struct", func() {
 		Expect(service.GetAddress()).To(Equal("127.0.0.1"))
 		Expect(service.GetNamespace()).To(Equal("ServiceNameSpaceExample"))
 		Expect(service.GetLabels()).To(Equal(map[string]string{"keyLabelExample": "valueLabelExample"}))
-		Expect(service.GetTimestamp()).Should(MatchRegexp(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}`))
+		Expect(service.GetAnnotations()).To(Equal(map[string]string{"keyAnnotationExample": "valueAnnotationExample"}))
+		Expect(service.GetSelector()).To(Equal(map[string]string{"app": "example"}))
+		Expect(service.GetTimestamp()).Should(MatchRegexp(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\.\d{9}$`))
+	})
+
+	It("should keep every port and the exposure details of the k8sService", func() {
+		service := buildServiceFromK8sService(buildLoadBalancerService())
+
+		Expect(service.GetServiceType()).To(Equal("LoadBalancer"))
+		Expect(service.GetPorts()).To(Equal([]domain.ServicePort{
+			{Name: "http", Protocol: "TCP", Port: 80, TargetPort: "8080", NodePort: 30080},
+			{Name: "metrics", Protocol: "TCP", Port: 9090, TargetPort: "metrics", NodePort: 30090},
+		}))
+		Expect(service.GetExternalIPs()).To(Equal([]string{"10.0.0.10"}))
+		Expect(service.GetLoadBalancerIngress()).To(Equal([]string{"34.1.2.3", "lb.example.com"}))
+		Expect(service.IsExposed()).To(BeTrue())
+	})
+
+	It("should build an ExternalName Service", func() {
+		k8sService := buildService()
+		k8sService.Spec.Type = corev1.ServiceTypeExternalName
+		k8sService.Spec.ExternalName = "db.example.com"
+		k8sService.Spec.Ports = nil
+
+		service := buildServiceFromK8sService(k8sService)
+
+		Expect(service.GetServiceType()).To(Equal("ExternalName"))
+		Expect(service.GetExternalName()).To(Equal("db.example.com"))
+		Expect(service.GetPort()).To(Equal(0))
+		Expect(service.GetPorts()).To(BeEmpty())
+		Expect(service.IsExposed()).To(BeFalse())
+	})
+
+	It("should expose the single port sent by old collectors as a port list", func() {
+		service := domain.Service{Port: 3200}
+
+		Expect(service.GetPorts()).To(Equal([]domain.ServicePort{{Port: 3200}}))
 	})
 
 })
 
+func buildLoadBalancerService() *corev1.Service {
+	service := buildService()
+	service.Spec.Type = corev1.ServiceTypeLoadBalancer
+	service.Spec.ExternalIPs = []string{"10.0.0.10"}
+	service.Spec.Ports = []corev1.ServicePort{
+		{Name: "http", Protocol: corev1.ProtocolTCP, Port: 80, TargetPort: intstr.FromInt(8080), NodePort: 30080},
+		{Name: "metrics", Protocol: corev1.ProtocolTCP, Port: 9090, TargetPort: intstr.FromString("metrics"), NodePort: 30090},
+	}
+	service.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "34.1.2.3"}, {Hostname: "lb.example.com"}}
+	return service
+}
+
 func buildService() *corev1.Service {
 	return &corev1.Service{
 		TypeMeta: metav1.TypeMeta{},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:       "ServiceNameExample",
-			Namespace:  "ServiceNameSpaceExample",
-			UID:        "UIDExample",
-			Generation: 5,
-			Labels:     map[string]string{"keyLabelExample": "valueLabelExample"},
+			Name:        "ServiceNameExample",
+			Namespace:   "ServiceNameSpaceExample",
+			UID:         "UIDExample",
+			Generation:  5,
+			Labels:      map[string]string{"keyLabelExample": "valueLabelExample"},
+			Annotations: map[string]string{"keyAnnotationExample": "valueAnnotationExample"},
 		},
 		Spec: corev1.ServiceSpec{
 			ClusterIP: "127.0.0.1",
 			Ports:     []corev1.ServicePort{{Port: 3200}, {Port: 8900}},
+			Selector:  map[string]string{"app": "example"},
 		},
 	}
 }
@@ -5,6 +5,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/walmartdigital/katalog/domain"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,6 +33,31 @@ var _ = Describe("StatefulSet builder struct", func() {
 		Expect(statefulSet.GetTimestamp()).Should(MatchRegexp(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}`))
 	})
 
+	It("should capture the replica counts, the strategy and the revisions", func() {
+		k8sStatefulSet := buildStatefulSet()
+		replicas := int32(3)
+		partition := int32(2)
+		k8sStatefulSet.Spec.Replicas = &replicas
+		k8sStatefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+			Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+		}
+		k8sStatefulSet.Generation = 1
+		k8sStatefulSet.Status.CurrentReplicas = 2
+		k8sStatefulSet.Status.ReadyReplicas = 3
+		k8sStatefulSet.Status.UpdatedReplicas = 1
+		k8sStatefulSet.Status.CurrentRevision = "db-5c7d"
+		k8sStatefulSet.Status.UpdateRevision = "db-8f2a"
+
+		statefulSet := buildStatefulSetFromK8sStatefulSet(k8sStatefulSet)
+
+		Expect(statefulSet.GetReplicas()).To(Equal(&domain.Replicas{Desired: 3, Current: 2, Ready: 3, Available: 3, Updated: 1}))
+		Expect(statefulSet.GetStrategy()).To(Equal(&domain.Strategy{Type: "RollingUpdate", Partition: &partition}))
+		Expect(statefulSet.CurrentRevision).To(Equal("db-5c7d"))
+		Expect(statefulSet.UpdateRevision).To(Equal("db-8f2a"))
+		Expect(statefulSet.GetRolloutStatus().State).To(Equal(domain.RolloutComplete))
+	})
+
 })
 
 func buildStatefulSet() *appsv1.StatefulSet {
@@ -9,11 +9,7 @@ import (
 
 // BuildStatefulSetFromK8sStatefulSet ...
 func buildStatefulSetFromK8sStatefulSet(sourceStatefulSet *appsv1.StatefulSet) domain.StatefulSet {
-	m := make(map[string]string)
-
-	for _, c := range sourceStatefulSet.Spec.Template.Spec.Containers {
-		m[c.Name] = c.Image
-	}
+	containers := buildContainersFromK8sPodSpec(sourceStatefulSet.Spec.Template.Spec)
 
 	destinationStatefulSet := &domain.StatefulSet{
 		ID:                 string(sourceStatefulSet.GetUID()),
@@ -21,8 +17,16 @@ func buildStatefulSetFromK8sStatefulSet(sourceStatefulSet *appsv1.StatefulSet) d
 		Generation:         sourceStatefulSet.GetGeneration(),
 		Namespace:          sourceStatefulSet.GetNamespace(),
 		Labels:             sourceStatefulSet.GetLabels(),
-		Containers:         m,
-		Timestamp:          time.Now().UTC().Format(timestampFormat),
+		OwnerReferences:    buildOwnerReferencesFromK8sOwnerReferences(sourceStatefulSet.GetOwnerReferences()),
+		PodLabels:          sourceStatefulSet.Spec.Template.GetLabels(),
+		Containers:         domain.ContainersByName(containers),
+		ContainerSpecs:     containers,
+		Replicas:           buildReplicasFromK8sStatefulSet(sourceStatefulSet),
+		Strategy:           buildStrategyFromK8sStatefulSet(sourceStatefulSet),
+		Conditions:         buildConditionsFromK8sStatefulSet(sourceStatefulSet),
+		CurrentRevision:    sourceStatefulSet.Status.CurrentRevision,
+		UpdateRevision:     sourceStatefulSet.Status.UpdateRevision,
+		Timestamp:          time.Now().UTC().Format(collectedFormat),
 		ObservedGeneration: sourceStatefulSet.Status.ObservedGeneration,
 	}
 
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var mutex = &sync.Mutex{}
+var metrics map[string]interface{}
+
+// PrometheusMetrics ...
+type PrometheusMetrics struct {
+}
+
+// InitMetrics ...
+func (p PrometheusMetrics) InitMetrics() {
+	mutex.Lock()
+	if metrics == nil {
+		metrics = make(map[string]interface{})
+
+		metrics["redactedKey"] = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "katalog",
+				Subsystem: "collector",
+				Name:      "redacted_keys",
+				Help:      "Total number of label and annotation keys dropped or hashed before publishing",
+			},
+			[]string{"kind", "field", "key", "action"},
+		)
+		prometheus.MustRegister(metrics["redactedKey"].(*prometheus.CounterVec))
+
+		metrics["ignoredResource"] = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "katalog",
+				Subsystem: "collector",
+				Name:      "ignored_resources",
+				Help:      "Total number of resource events skipped because of the opt-out annotation",
+			},
+			// Names are logged at debug level, resources come and go
+			[]string{"kind", "ns"},
+		)
+		prometheus.MustRegister(metrics["ignoredResource"].(*prometheus.CounterVec))
+
+		metrics["leader"] = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "katalog",
+				Subsystem: "collector",
+				Name:      "leader",
+				Help:      "Whether this collector holds the leader election lease (1) or follows (0)",
+			},
+			[]string{"identity"},
+		)
+		prometheus.MustRegister(metrics["leader"].(*prometheus.GaugeVec))
+	}
+	mutex.Unlock()
+}
+
+// IncrementCounter ...
+func (p PrometheusMetrics) IncrementCounter(key string, labels ...string) {
+	metrics[key].(*prometheus.CounterVec).WithLabelValues(labels...).Inc()
+}
+
+// SetGauge ...
+func (p PrometheusMetrics) SetGauge(key string, value float64, labels ...string) {
+	metrics[key].(*prometheus.GaugeVec).WithLabelValues(labels...).Set(value)
+}
+
+// DestroyMetrics ...
+func (p PrometheusMetrics) DestroyMetrics() {
+	mutex.Lock()
+	for _, v := range metrics {
+		prometheus.Unregister(v.(prometheus.Collector))
+	}
+	mutex.Unlock()
+}
@@ -2,10 +2,10 @@ package publishers
 
 import (
 	"bytes"
-	"encoding/json"
 	"errors"
 	"net/http"
-	"reflect"
+	"net/url"
+	"strings"
 
 	"github.com/avast/retry-go"
 	"github.com/walmartdigital/katalog/domain"
@@ -30,6 +30,11 @@ func (c *HTTPPublisher) Check() bool {
 	return true
 }
 
+// Close ...
+func (c *HTTPPublisher) Close() error {
+	return nil
+}
+
 // Publish ...
 func (c *HTTPPublisher) Publish(obj interface{}) error {
 	operation := obj.(domain.Operation)
@@ -50,169 +55,55 @@ func (c *HTTPPublisher) Publish(obj interface{}) error {
 }
 
 func (c *HTTPPublisher) post(resource domain.Resource) error {
-	reqBodyBytes := new(bytes.Buffer)
-
-	switch v := resource.GetType(); v {
-	case reflect.TypeOf(new(domain.Service)):
-		service := resource.GetK8sResource().(*domain.Service)
-		err := json.NewEncoder(reqBodyBytes).Encode(*service)
-		if err != nil {
-			log.Error("Error serializing HTTP request body")
-			return err
-		}
-		req, _ := http.NewRequest(http.MethodPost, c.url+"/services/"+service.ID, reqBodyBytes)
-		req.Header.Add("Content-Type", "application/json")
-		res, err := http.DefaultClient.Do(req)
-		if err != nil || res.StatusCode != 200 {
-			log.Error(err)
-			return errors.New("post service failed")
-		}
-		defer res.Body.Close()
-		return nil
+	return c.send(http.MethodPost, resource)
+}
 
-	case reflect.TypeOf(new(domain.Deployment)):
-		deployment := resource.GetK8sResource().(*domain.Deployment)
-		err := json.NewEncoder(reqBodyBytes).Encode(*deployment)
-		if err != nil {
-			log.Error("Error serializing HTTP request body")
-			return err
-		}
-		req, _ := http.NewRequest(http.MethodPost, c.url+"/deployments/"+deployment.ID, reqBodyBytes)
-		req.Header.Add("Content-Type", "application/json")
-		res, err := http.DefaultClient.Do(req)
-		if err != nil || res.StatusCode != 200 {
-			log.Error(err)
-			return errors.New("post deployment failed")
-		}
-		defer res.Body.Close()
-		return nil
+func (c *HTTPPublisher) put(resource domain.Resource) error {
+	return c.send(http.MethodPut, resource)
+}
 
-	case reflect.TypeOf(new(domain.StatefulSet)):
-		statefulset := resource.GetK8sResource().(*domain.StatefulSet)
-		err := json.NewEncoder(reqBodyBytes).Encode(*statefulset)
-		if err != nil {
-			log.Error("Error serializing HTTP request body")
-			return err
-		}
-		req, _ := http.NewRequest(http.MethodPost, c.url+"/statefulsets/"+statefulset.ID, reqBodyBytes)
-		req.Header.Add("Content-Type", "application/json")
-		res, err := http.DefaultClient.Do(req)
-		if err != nil || res.StatusCode != 200 {
-			log.Error(err)
-			return errors.New("post statefulset failed")
-		}
-		defer res.Body.Close()
-		return nil
+func (c *HTTPPublisher) delete(resource domain.Resource) error {
+	return c.send(http.MethodDelete, resource)
+}
 
-	default:
-		log.Errorf("Type %s not found", v)
+// clusterQuery tells the server which cluster a deleted resource belongs to
+func clusterQuery(resource domain.Resource) string {
+	cluster := resource.GetCluster().Name
+	if cluster == "" {
+		return ""
 	}
-
-	return nil
+	return "?cluster=" + url.QueryEscape(cluster)
 }
 
-func (c *HTTPPublisher) put(resource domain.Resource) error {
-	reqBodyBytes := new(bytes.Buffer)
-
-	switch v := resource.GetType(); v {
-	case reflect.TypeOf(new(domain.Service)):
-		service := resource.GetK8sResource().(*domain.Service)
-		err := json.NewEncoder(reqBodyBytes).Encode(*service)
-		if err != nil {
-			log.Error("Error serializing HTTP request body")
-			return err
-		}
-		req, _ := http.NewRequest(http.MethodPut, c.url+"/services/"+service.ID, reqBodyBytes)
-		req.Header.Add("Content-Type", "application/json")
-		res, err := http.DefaultClient.Do(req)
-		if err != nil || res.StatusCode != 200 {
-			log.Error(err)
-			return errors.New("put service failed")
-		}
-		defer res.Body.Close()
-		return nil
-
-	case reflect.TypeOf(new(domain.Deployment)):
-		deployment := resource.GetK8sResource().(*domain.Deployment)
-		err := json.NewEncoder(reqBodyBytes).Encode(*deployment)
-		if err != nil {
-			log.Error("Error serializing HTTP request body")
-			return err
-		}
-		req, _ := http.NewRequest(http.MethodPut, c.url+"/deployments/"+deployment.ID, reqBodyBytes)
-		req.Header.Add("Content-Type", "application/json")
-		res, err := http.DefaultClient.Do(req)
-		if err != nil || res.StatusCode != 200 {
-			log.Error(err)
-			return errors.New("put deployment failed")
-		}
-		defer res.Body.Close()
+// send calls the route of the resource kind, the resource being the body of
+// creations and updates
+func (c *HTTPPublisher) send(method string, resource domain.Resource) error {
+	kind, ok := domain.KindOf(resource.K8sResource)
+	if !ok {
+		log.Errorf("Type %s not found", resource.GetType())
 		return nil
+	}
 
-	case reflect.TypeOf(new(domain.StatefulSet)):
-		statefulset := resource.GetK8sResource().(*domain.StatefulSet)
-		err := json.NewEncoder(reqBodyBytes).Encode(*statefulset)
+	target := c.url + kind.PathOf(resource.K8sResource) + resource.GetID()
+	reqBodyBytes := new(bytes.Buffer)
+	if method == http.MethodDelete {
+		target += clusterQuery(resource)
+	} else {
+		payload, err := kind.Encode(resource.K8sResource)
 		if err != nil {
 			log.Error("Error serializing HTTP request body")
 			return err
 		}
-		req, _ := http.NewRequest(http.MethodPut, c.url+"/statefulsets/"+statefulset.ID, reqBodyBytes)
-		req.Header.Add("Content-Type", "application/json")
-		res, err := http.DefaultClient.Do(req)
-		if err != nil || res.StatusCode != 200 {
-			log.Error(err)
-			return errors.New("put statefulset failed")
-		}
-		defer res.Body.Close()
-		return nil
-
-	default:
-		log.Errorf("Type %s not found", v)
+		reqBodyBytes.Write(payload)
 	}
 
-	return nil
-}
-
-func (c *HTTPPublisher) delete(resource domain.Resource) error {
-	switch v := resource.GetType(); v {
-	case reflect.TypeOf(new(domain.Service)):
-		service := resource.GetK8sResource().(*domain.Service)
-		req, _ := http.NewRequest(http.MethodDelete, c.url+"/services/"+service.ID, nil)
-		req.Header.Add("Content-Type", "application/json")
-		res, err := http.DefaultClient.Do(req)
-		if err != nil || res.StatusCode != 200 {
-			log.Error(err)
-			return errors.New("delete service failed")
-		}
-		defer res.Body.Close()
-		return nil
-
-	case reflect.TypeOf(new(domain.Deployment)):
-		deployment := resource.GetK8sResource().(*domain.Deployment)
-		req, _ := http.NewRequest(http.MethodDelete, c.url+"/deployments/"+deployment.ID, nil)
-		req.Header.Add("Content-Type", "application/json")
-		res, err := http.DefaultClient.Do(req)
-		if err != nil || res.StatusCode != 200 {
-			log.Error(err)
-			return errors.New("delete deployment failed")
-		}
-		defer res.Body.Close()
-		return nil
-
-	case reflect.TypeOf(new(domain.StatefulSet)):
-		statefulset := resource.GetK8sResource().(*domain.StatefulSet)
-		req, _ := http.NewRequest(http.MethodDelete, c.url+"/statefulsets/"+statefulset.ID, nil)
-		req.Header.Add("Content-Type", "application/json")
-		res, err := http.DefaultClient.Do(req)
-		if err != nil || res.StatusCode != 200 {
-			log.Error(err)
-			return errors.New("delete statefulset failed")
-		}
-		defer res.Body.Close()
-		return nil
-
-	default:
-		log.Errorf("Type %s not found", v)
+	req, _ := http.NewRequest(method, target, reqBodyBytes)
+	req.Header.Add("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil || res.StatusCode != 200 {
+		log.Error(err)
+		return errors.New(strings.ToLower(method) + " " + kind.Label + " failed")
 	}
+	defer res.Body.Close()
 	return nil
 }
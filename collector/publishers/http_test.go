@@ -72,6 +72,13 @@ func (s *DummyK8sResource) GetObservedGeneration() int64 {
 	return s.GetObservedGeneration()
 }
 
+func (s *DummyK8sResource) GetCluster() domain.Cluster {
+	return domain.Cluster{}
+}
+
+func (s *DummyK8sResource) SetCluster(cluster domain.Cluster) {
+}
+
 var ctrl *gomock.Controller
 
 func TestAll(t *testing.T) {
@@ -278,6 +285,192 @@ var _ = Describe("add", func() {
 		Expect(output.Error()).To(Equal("post statefulset failed"))
 	})
 
+	It("should return nil error when add daemonset request succeed", func() {
+		daemonSetID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/daemonsets/" + daemonSetID
+		statusCode := 200
+		body := `{"ID": "` + daemonSetID + `"}`
+		fakeDaemonSet := createCreateFakeServer(path, statusCode, body)
+		defer fakeDaemonSet.Server.Close()
+		url := fakeDaemonSet.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeAdd,
+			Resource: domain.Resource{
+				K8sResource: &domain.DaemonSet{ID: daemonSetID},
+			},
+		})
+
+		Expect(output).To(BeNil())
+	})
+
+	It("should return an error when add daemonset request fail with status code 404", func() {
+		daemonSetID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/daemonsets/" + daemonSetID
+		statusCode := 404
+		body := `{"status": "fail"}`
+		fakeDaemonSet := createCreateFakeServer(path, statusCode, body)
+		defer fakeDaemonSet.Server.Close()
+		url := "localhost:5000"
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeAdd,
+			Resource: domain.Resource{
+				K8sResource: &domain.DaemonSet{},
+			},
+		})
+
+		Expect(output).ToNot(BeNil())
+		Expect(output.Error()).To(Equal("post daemonset failed"))
+	})
+
+	It("should return an error when add daemonset request fail with status code 500", func() {
+		daemonSetID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/daemonsets/" + daemonSetID
+		statusCode := 500
+		body := `{"status": "fail"}`
+		fakeDaemonSet := createCreateFakeServer(path, statusCode, body)
+		defer fakeDaemonSet.Server.Close()
+		url := fakeDaemonSet.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeAdd,
+			Resource: domain.Resource{
+				K8sResource: &domain.DaemonSet{},
+			},
+		})
+
+		Expect(output).ToNot(BeNil())
+		Expect(output.Error()).To(Equal("post daemonset failed"))
+	})
+
+	It("should return nil error when add job request succeed", func() {
+		jobID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/jobs/" + jobID
+		statusCode := 200
+		body := `{"ID": "` + jobID + `"}`
+		fakeJob := createCreateFakeServer(path, statusCode, body)
+		defer fakeJob.Server.Close()
+		url := fakeJob.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeAdd,
+			Resource: domain.Resource{
+				K8sResource: &domain.Job{ID: jobID},
+			},
+		})
+
+		Expect(output).To(BeNil())
+	})
+
+	It("should return an error when add job request fail with status code 404", func() {
+		jobID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/jobs/" + jobID
+		statusCode := 404
+		body := `{"status": "fail"}`
+		fakeJob := createCreateFakeServer(path, statusCode, body)
+		defer fakeJob.Server.Close()
+		url := "localhost:5000"
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeAdd,
+			Resource: domain.Resource{
+				K8sResource: &domain.Job{},
+			},
+		})
+
+		Expect(output).ToNot(BeNil())
+		Expect(output.Error()).To(Equal("post job failed"))
+	})
+
+	It("should return an error when add job request fail with status code 500", func() {
+		jobID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/jobs/" + jobID
+		statusCode := 500
+		body := `{"status": "fail"}`
+		fakeJob := createCreateFakeServer(path, statusCode, body)
+		defer fakeJob.Server.Close()
+		url := fakeJob.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeAdd,
+			Resource: domain.Resource{
+				K8sResource: &domain.Job{},
+			},
+		})
+
+		Expect(output).ToNot(BeNil())
+		Expect(output.Error()).To(Equal("post job failed"))
+	})
+
+	It("should return nil error when add cronjob request succeed", func() {
+		cronJobID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/cronjobs/" + cronJobID
+		statusCode := 200
+		body := `{"ID": "` + cronJobID + `"}`
+		fakeCronJob := createCreateFakeServer(path, statusCode, body)
+		defer fakeCronJob.Server.Close()
+		url := fakeCronJob.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeAdd,
+			Resource: domain.Resource{
+				K8sResource: &domain.CronJob{ID: cronJobID},
+			},
+		})
+
+		Expect(output).To(BeNil())
+	})
+
+	It("should return an error when add cronjob request fail with status code 404", func() {
+		cronJobID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/cronjobs/" + cronJobID
+		statusCode := 404
+		body := `{"status": "fail"}`
+		fakeCronJob := createCreateFakeServer(path, statusCode, body)
+		defer fakeCronJob.Server.Close()
+		url := "localhost:5000"
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeAdd,
+			Resource: domain.Resource{
+				K8sResource: &domain.CronJob{},
+			},
+		})
+
+		Expect(output).ToNot(BeNil())
+		Expect(output.Error()).To(Equal("post cronjob failed"))
+	})
+
+	It("should return an error when add cronjob request fail with status code 500", func() {
+		cronJobID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/cronjobs/" + cronJobID
+		statusCode := 500
+		body := `{"status": "fail"}`
+		fakeCronJob := createCreateFakeServer(path, statusCode, body)
+		defer fakeCronJob.Server.Close()
+		url := fakeCronJob.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeAdd,
+			Resource: domain.Resource{
+				K8sResource: &domain.CronJob{},
+			},
+		})
+
+		Expect(output).ToNot(BeNil())
+		Expect(output.Error()).To(Equal("post cronjob failed"))
+	})
+
 	It("should return nil if resource Type is not handled", func() {
 		serviceID := "6425377e-badd-4c46-828a-00c9afa7a156"
 		path := "/services/" + serviceID
@@ -494,6 +687,192 @@ var _ = Describe("update", func() {
 		Expect(output.Error()).To(Equal("put statefulset failed"))
 	})
 
+	It("should return nil error when update daemonset request succeed", func() {
+		daemonSettID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/daemonsets/" + daemonSettID
+		statusCode := 200
+		body := `{"ID": "` + daemonSettID + `"}`
+		fakeDaemonSet := createUpdateFakeServer(path, statusCode, body)
+		defer fakeDaemonSet.Server.Close()
+		url := fakeDaemonSet.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeUpdate,
+			Resource: domain.Resource{
+				K8sResource: &domain.DaemonSet{ID: daemonSettID},
+			},
+		})
+
+		Expect(output).To(BeNil())
+	})
+
+	It("should return an error when update daemonset request fail with status code 404", func() {
+		deploymentID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/daemonsets/" + deploymentID
+		statusCode := 404
+		body := `{"status": "fail"}`
+		fakeDaemonSet := createUpdateFakeServer(path, statusCode, body)
+		defer fakeDaemonSet.Server.Close()
+		url := "localhost:5000"
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeUpdate,
+			Resource: domain.Resource{
+				K8sResource: &domain.DaemonSet{},
+			},
+		})
+
+		Expect(output).ToNot(BeNil())
+		Expect(output.Error()).To(Equal("put daemonset failed"))
+	})
+
+	It("should return an error when update daemonset request fail with status code 500", func() {
+		daemonSetID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/daemonsets/" + daemonSetID
+		statusCode := 500
+		body := `{"status": "fail"}`
+		fakeDaemonSet := createUpdateFakeServer(path, statusCode, body)
+		defer fakeDaemonSet.Server.Close()
+		url := fakeDaemonSet.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeUpdate,
+			Resource: domain.Resource{
+				K8sResource: &domain.DaemonSet{},
+			},
+		})
+
+		Expect(output).ToNot(BeNil())
+		Expect(output.Error()).To(Equal("put daemonset failed"))
+	})
+
+	It("should return nil error when update job request succeed", func() {
+		jobtID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/jobs/" + jobtID
+		statusCode := 200
+		body := `{"ID": "` + jobtID + `"}`
+		fakeJob := createUpdateFakeServer(path, statusCode, body)
+		defer fakeJob.Server.Close()
+		url := fakeJob.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeUpdate,
+			Resource: domain.Resource{
+				K8sResource: &domain.Job{ID: jobtID},
+			},
+		})
+
+		Expect(output).To(BeNil())
+	})
+
+	It("should return an error when update job request fail with status code 404", func() {
+		deploymentID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/jobs/" + deploymentID
+		statusCode := 404
+		body := `{"status": "fail"}`
+		fakeJob := createUpdateFakeServer(path, statusCode, body)
+		defer fakeJob.Server.Close()
+		url := "localhost:5000"
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeUpdate,
+			Resource: domain.Resource{
+				K8sResource: &domain.Job{},
+			},
+		})
+
+		Expect(output).ToNot(BeNil())
+		Expect(output.Error()).To(Equal("put job failed"))
+	})
+
+	It("should return an error when update job request fail with status code 500", func() {
+		jobID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/jobs/" + jobID
+		statusCode := 500
+		body := `{"status": "fail"}`
+		fakeJob := createUpdateFakeServer(path, statusCode, body)
+		defer fakeJob.Server.Close()
+		url := fakeJob.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeUpdate,
+			Resource: domain.Resource{
+				K8sResource: &domain.Job{},
+			},
+		})
+
+		Expect(output).ToNot(BeNil())
+		Expect(output.Error()).To(Equal("put job failed"))
+	})
+
+	It("should return nil error when update cronjob request succeed", func() {
+		cronJobtID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/cronjobs/" + cronJobtID
+		statusCode := 200
+		body := `{"ID": "` + cronJobtID + `"}`
+		fakeCronJob := createUpdateFakeServer(path, statusCode, body)
+		defer fakeCronJob.Server.Close()
+		url := fakeCronJob.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeUpdate,
+			Resource: domain.Resource{
+				K8sResource: &domain.CronJob{ID: cronJobtID},
+			},
+		})
+
+		Expect(output).To(BeNil())
+	})
+
+	It("should return an error when update cronjob request fail with status code 404", func() {
+		deploymentID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/cronjobs/" + deploymentID
+		statusCode := 404
+		body := `{"status": "fail"}`
+		fakeCronJob := createUpdateFakeServer(path, statusCode, body)
+		defer fakeCronJob.Server.Close()
+		url := "localhost:5000"
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeUpdate,
+			Resource: domain.Resource{
+				K8sResource: &domain.CronJob{},
+			},
+		})
+
+		Expect(output).ToNot(BeNil())
+		Expect(output.Error()).To(Equal("put cronjob failed"))
+	})
+
+	It("should return an error when update cronjob request fail with status code 500", func() {
+		cronJobID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/cronjobs/" + cronJobID
+		statusCode := 500
+		body := `{"status": "fail"}`
+		fakeCronJob := createUpdateFakeServer(path, statusCode, body)
+		defer fakeCronJob.Server.Close()
+		url := fakeCronJob.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeUpdate,
+			Resource: domain.Resource{
+				K8sResource: &domain.CronJob{},
+			},
+		})
+
+		Expect(output).ToNot(BeNil())
+		Expect(output.Error()).To(Equal("put cronjob failed"))
+	})
+
 	It("should return nil if resource Type is not handled", func() {
 		deploymentID := "6425377e-badd-4c46-828a-00c9afa7a156"
 		path := "/deployments/" + deploymentID
@@ -701,6 +1080,183 @@ var _ = Describe("delete", func() {
 		Expect(output.Error()).To(Equal("delete statefulset failed"))
 	})
 
+	It("should return nil error when daemonset request succeed", func() {
+		daemonSetID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/daemonsets/" + daemonSetID
+		statusCode := 200
+		fakeDaemonSet := createDeleteFakeServer(path, statusCode)
+		defer fakeDaemonSet.Server.Close()
+		url := fakeDaemonSet.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeDelete,
+			Resource: domain.Resource{
+				K8sResource: &domain.DaemonSet{ID: daemonSetID},
+			},
+		})
+
+		Expect(output).To(BeNil())
+	})
+
+	It("should return an error when daemonset request fail with status code 404", func() {
+		daemonSetID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/daemonsets/" + daemonSetID
+		statusCode := 404
+		fakeDaemonSet := createDeleteFakeServer(path, statusCode)
+		defer fakeDaemonSet.Server.Close()
+		url := fakeDaemonSet.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeDelete,
+			Resource: domain.Resource{
+				K8sResource: &domain.DaemonSet{ID: daemonSetID},
+			},
+		})
+
+		Expect(output).ToNot(BeNil())
+		Expect(output.Error()).To(Equal("delete daemonset failed"))
+	})
+
+	It("should return an error when daemonset request fail with status code 500", func() {
+		daemonSetID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/daemonsets/" + daemonSetID
+		statusCode := 500
+		fakeDaemonSet := createDeleteFakeServer(path, statusCode)
+		defer fakeDaemonSet.Server.Close()
+		url := fakeDaemonSet.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeDelete,
+			Resource: domain.Resource{
+				K8sResource: &domain.DaemonSet{ID: daemonSetID},
+			},
+		})
+
+		Expect(output).ToNot(BeNil())
+		Expect(output.Error()).To(Equal("delete daemonset failed"))
+	})
+
+	It("should return nil error when job request succeed", func() {
+		jobID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/jobs/" + jobID
+		statusCode := 200
+		fakeJob := createDeleteFakeServer(path, statusCode)
+		defer fakeJob.Server.Close()
+		url := fakeJob.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeDelete,
+			Resource: domain.Resource{
+				K8sResource: &domain.Job{ID: jobID},
+			},
+		})
+
+		Expect(output).To(BeNil())
+	})
+
+	It("should return an error when job request fail with status code 404", func() {
+		jobID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/jobs/" + jobID
+		statusCode := 404
+		fakeJob := createDeleteFakeServer(path, statusCode)
+		defer fakeJob.Server.Close()
+		url := fakeJob.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeDelete,
+			Resource: domain.Resource{
+				K8sResource: &domain.Job{ID: jobID},
+			},
+		})
+
+		Expect(output).ToNot(BeNil())
+		Expect(output.Error()).To(Equal("delete job failed"))
+	})
+
+	It("should return an error when job request fail with status code 500", func() {
+		jobID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/jobs/" + jobID
+		statusCode := 500
+		fakeJob := createDeleteFakeServer(path, statusCode)
+		defer fakeJob.Server.Close()
+		url := fakeJob.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeDelete,
+			Resource: domain.Resource{
+				K8sResource: &domain.Job{ID: jobID},
+			},
+		})
+
+		Expect(output).ToNot(BeNil())
+		Expect(output.Error()).To(Equal("delete job failed"))
+	})
+
+	It("should return nil error when cronjob request succeed", func() {
+		cronJobID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/cronjobs/" + cronJobID
+		statusCode := 200
+		fakeCronJob := createDeleteFakeServer(path, statusCode)
+		defer fakeCronJob.Server.Close()
+		url := fakeCronJob.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeDelete,
+			Resource: domain.Resource{
+				K8sResource: &domain.CronJob{ID: cronJobID},
+			},
+		})
+
+		Expect(output).To(BeNil())
+	})
+
+	It("should return an error when cronjob request fail with status code 404", func() {
+		cronJobID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/cronjobs/" + cronJobID
+		statusCode := 404
+		fakeCronJob := createDeleteFakeServer(path, statusCode)
+		defer fakeCronJob.Server.Close()
+		url := fakeCronJob.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeDelete,
+			Resource: domain.Resource{
+				K8sResource: &domain.CronJob{ID: cronJobID},
+			},
+		})
+
+		Expect(output).ToNot(BeNil())
+		Expect(output.Error()).To(Equal("delete cronjob failed"))
+	})
+
+	It("should return an error when cronjob request fail with status code 500", func() {
+		cronJobID := "6425377e-badd-4c46-828a-00c9afa7a156"
+		path := "/cronjobs/" + cronJobID
+		statusCode := 500
+		fakeCronJob := createDeleteFakeServer(path, statusCode)
+		defer fakeCronJob.Server.Close()
+		url := fakeCronJob.ResolveURL("")
+		publisher := publishers.BuildHTTPPublisher(url, retryDoDouble)
+
+		output := publisher.Publish(domain.Operation{
+			Kind: domain.OperationTypeDelete,
+			Resource: domain.Resource{
+				K8sResource: &domain.CronJob{ID: cronJobID},
+			},
+		})
+
+		Expect(output).ToNot(BeNil())
+		Expect(output.Error()).To(Equal("delete cronjob failed"))
+	})
+
 	It("should return nil if resource Type is not handled", func() {
 		serviceID := "6425377e-badd-4c46-828a-00c9afa7a156"
 		path := "/services/" + serviceID
@@ -2,10 +2,8 @@ package publishers
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"reflect"
 
 	"github.com/sirupsen/logrus"
 	"github.com/walmartdigital/katalog/domain"
@@ -58,28 +56,20 @@ func (c *KafkaPublisher) CreateProducers(factory WriterFactory) error {
 	return nil
 }
 
-// Close ...
+// Close flushes the messages buffered by the writers and closes them
 func (c *KafkaPublisher) Close() error {
 	var err error
-	errCreated := (*c.kafkaWriters["created"]).Close()
-	if errCreated != nil {
-		err = errCreated
+	for _, name := range []string{"created", "updated", "deleted", "health"} {
+		errClosing := (*c.kafkaWriters[name]).Close()
+		if errClosing != nil {
+			log.WithFields(logrus.Fields{
+				"msg":    errClosing.Error(),
+				"writer": name,
+			}).Error("Closing kafka publishers")
+			err = errClosing
+		}
 	}
 
-	errDeleted := (*c.kafkaWriters["deleted"]).Close()
-	if errDeleted != nil {
-		err = errDeleted
-	}
-
-	errUpdated := (*c.kafkaWriters["updated"]).Close()
-	if errUpdated != nil {
-		err = errUpdated
-	}
-
-	log.WithFields(logrus.Fields{
-		"msg": err.Error(),
-	}).Error("Closing kafka publishers")
-
 	return err
 }
 
@@ -103,46 +93,30 @@ func (c *KafkaPublisher) getWriter(operation domain.Operation) *Writer {
 
 // getPayload ...
 func (c *KafkaPublisher) getPayload(resource domain.Resource) ([]byte, error) {
-	var payload []byte
-	var err error
-	switch v := resource.GetType(); v {
-	case reflect.TypeOf(new(domain.Service)):
-		service := resource.GetK8sResource().(*domain.Service)
-		payload, err = json.Marshal(service)
-	case reflect.TypeOf(new(domain.Deployment)):
-		deployment := resource.GetK8sResource().(*domain.Deployment)
-		payload, err = json.Marshal(deployment)
-	case reflect.TypeOf(new(domain.StatefulSet)):
-		statefulset := resource.GetK8sResource().(*domain.StatefulSet)
-		payload, err = json.Marshal(statefulset)
-	default:
-		err = fmt.Errorf("Type %s not found", v)
+	kind, ok := domain.KindOf(resource.K8sResource)
+	if !ok {
+		err := fmt.Errorf("Type %s not found", resource.GetType())
+		log.Error(err)
+		return nil, err
 	}
+
+	payload, err := kind.Encode(resource.K8sResource)
 	if err != nil {
 		log.Error(err)
 	}
 	return payload, err
 }
 
-// getKey ...
+// getKey builds the message key, the consumer takes everything after the
+// kind path as the resource key so the cluster name travels with it
 func (c *KafkaPublisher) getKey(resource domain.Resource) string {
-	switch v := resource.GetType(); v {
-	case reflect.TypeOf(new(domain.Service)):
-		service := resource.GetK8sResource().(*domain.Service)
-		return "/services/" + service.ID
-
-	case reflect.TypeOf(new(domain.Deployment)):
-		deployment := resource.GetK8sResource().(*domain.Deployment)
-		return "/deployments/" + deployment.ID
-
-	case reflect.TypeOf(new(domain.StatefulSet)):
-		statefulset := resource.GetK8sResource().(*domain.StatefulSet)
-		return "/statefulsets/" + statefulset.ID
-
-	default:
-		log.Errorf("Type %s not found", v)
+	kind, ok := domain.KindOf(resource.K8sResource)
+	if !ok {
+		log.Errorf("Type %s not found", resource.GetType())
 		panic(errors.New("Type %s not found"))
 	}
+
+	return kind.PathOf(resource.K8sResource) + resource.GetKey()
 }
 
 // Check ...
@@ -2,6 +2,7 @@ package publishers_test
 
 import (
 	"encoding/json"
+	"errors"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -83,6 +84,72 @@ var _ = Describe("Run Consumer on 'created' topic", func() {
 		publisher.Publish(operation)
 	})
 
+	It("should close every writer", func() {
+		fakeWriter.EXPECT().Close().Return(nil).Times(4)
+
+		Expect(publisher.Close()).To(BeNil())
+	})
+
+	It("should close every writer and report the failure of one of them", func() {
+		fakeWriter.EXPECT().Close().Return(nil).Times(3)
+		fakeWriter.EXPECT().Close().Return(errors.New("broker unavailable")).Times(1)
+
+		Expect(publisher.Close()).NotTo(BeNil())
+	})
+
+	It("should prefix the message key with the cluster name", func() {
+		deployment := domain.Deployment{
+			ID:        "276797fa-b207-11e9-8527-000d3af9d6b6",
+			Name:      "queue-node",
+			Namespace: "amida",
+			Cluster:   &domain.Cluster{Name: "east"},
+		}
+
+		operation := domain.Operation{
+			Kind:     domain.OperationTypeAdd,
+			Resource: domain.Resource{K8sResource: &deployment},
+		}
+
+		dbytes, _ := json.Marshal(deployment)
+
+		message := kafka.Message{
+			Key:   []byte("/deployments/east/276797fa-b207-11e9-8527-000d3af9d6b6"),
+			Value: dbytes,
+		}
+
+		fakeWriter.EXPECT().WriteMessages(ctx, message).Return(
+			nil,
+		).Times(1)
+		publisher.Publish(operation)
+	})
+
+	It("should key custom resources by group and kind", func() {
+		customResource := domain.CustomResource{
+			ID:      "8d3d9c1a-3e2b-4a8f-9c43-5f1d7a2b6e01",
+			Name:    "checkout",
+			Kind:    "ConfigMap",
+			Version: "v1",
+			Cluster: &domain.Cluster{Name: "east"},
+		}
+
+		operation := domain.Operation{
+			Kind:     domain.OperationTypeAdd,
+			Resource: domain.Resource{K8sResource: &customResource},
+		}
+
+		dbytes, _ := json.Marshal(customResource)
+
+		message := kafka.Message{
+			Key:   []byte("/resources/core/ConfigMap/east/8d3d9c1a-3e2b-4a8f-9c43-5f1d7a2b6e01"),
+			Value: dbytes,
+		}
+
+		fakeWriter.EXPECT().WriteMessages(ctx, message).Return(
+			nil,
+		).Times(1)
+		publisher.Publish(operation)
+	})
+
 	It("should publish a StatefulSet creation event", func() {
 		ss := domain.StatefulSet{
 			ID:         "276797fa-b207-11e9-8527-000d3af9d6b6",
@@ -118,6 +185,111 @@ var _ = Describe("Run Consumer on 'created' topic", func() {
 		publisher.Publish(operation)
 	})
 
+	It("should publish a DaemonSet creation event", func() {
+		ss := domain.DaemonSet{
+			ID:         "276797fa-b207-11e9-8527-000d3af9d6b6",
+			Name:       "queue-node",
+			Generation: 7,
+			Namespace:  "amida",
+			Labels: map[string]string{
+				"HEAD":                   "569de2ecd9f9357b3380664f43c90d07ec6acaff",
+				"app":                    "nats",
+				"fluxcd.io/sync-gc-mark": "sha256.0fRlq9kqkh2eSDRqXANMzgN8_8jeguja3eDLoE5E0Xo",
+			},
+			Containers: map[string]string{
+				"nats-exporter":  "synadia/prometheus-nats-exporter:0.4.0",
+				"nats-streaming": "nats-streaming:0.15.1",
+			},
+		}
+
+		operation := domain.Operation{
+			Kind:     domain.OperationTypeAdd,
+			Resource: domain.Resource{K8sResource: &ss},
+		}
+
+		ssbytes, _ := json.Marshal(ss)
+
+		message := kafka.Message{
+			Key:   []byte("/daemonsets/276797fa-b207-11e9-8527-000d3af9d6b6"),
+			Value: ssbytes,
+		}
+
+		fakeWriter.EXPECT().WriteMessages(ctx, message).Return(
+			nil,
+		).Times(1)
+		publisher.Publish(operation)
+	})
+
+	It("should publish a Job creation event", func() {
+		ss := domain.Job{
+			ID:         "276797fa-b207-11e9-8527-000d3af9d6b6",
+			Name:       "queue-node",
+			Generation: 7,
+			Namespace:  "amida",
+			Labels: map[string]string{
+				"HEAD":                   "569de2ecd9f9357b3380664f43c90d07ec6acaff",
+				"app":                    "nats",
+				"fluxcd.io/sync-gc-mark": "sha256.0fRlq9kqkh2eSDRqXANMzgN8_8jeguja3eDLoE5E0Xo",
+			},
+			Containers: map[string]string{
+				"nats-exporter":  "synadia/prometheus-nats-exporter:0.4.0",
+				"nats-streaming": "nats-streaming:0.15.1",
+			},
+		}
+
+		operation := domain.Operation{
+			Kind:     domain.OperationTypeAdd,
+			Resource: domain.Resource{K8sResource: &ss},
+		}
+
+		ssbytes, _ := json.Marshal(ss)
+
+		message := kafka.Message{
+			Key:   []byte("/jobs/276797fa-b207-11e9-8527-000d3af9d6b6"),
+			Value: ssbytes,
+		}
+
+		fakeWriter.EXPECT().WriteMessages(ctx, message).Return(
+			nil,
+		).Times(1)
+		publisher.Publish(operation)
+	})
+
+	It("should publish a CronJob creation event", func() {
+		ss := domain.CronJob{
+			ID:         "276797fa-b207-11e9-8527-000d3af9d6b6",
+			Name:       "queue-node",
+			Generation: 7,
+			Namespace:  "amida",
+			Labels: map[string]string{
+				"HEAD":                   "569de2ecd9f9357b3380664f43c90d07ec6acaff",
+				"app":                    "nats",
+				"fluxcd.io/sync-gc-mark": "sha256.0fRlq9kqkh2eSDRqXANMzgN8_8jeguja3eDLoE5E0Xo",
+			},
+			Containers: map[string]string{
+				"nats-exporter":  "synadia/prometheus-nats-exporter:0.4.0",
+				"nats-streaming": "nats-streaming:0.15.1",
+			},
+		}
+
+		operation := domain.Operation{
+			Kind:     domain.OperationTypeAdd,
+			Resource: domain.Resource{K8sResource: &ss},
+		}
+
+		ssbytes, _ := json.Marshal(ss)
+
+		message := kafka.Message{
+			Key:   []byte("/cronjobs/276797fa-b207-11e9-8527-000d3af9d6b6"),
+			Value: ssbytes,
+		}
+
+		fakeWriter.EXPECT().WriteMessages(ctx, message).Return(
+			nil,
+		).Times(1)
+		publisher.Publish(operation)
+	})
+
 	It("should publish a Service creation event", func() {
 		i := domain.Instance{Address: "hello"}
 		ss := domain.Service{
@@ -18,4 +18,5 @@ func init() {
 type Publisher interface {
 	Publish(obj interface{}) error
 	Check() bool
+	Close() error
 }
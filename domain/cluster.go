@@ -0,0 +1,17 @@
+package domain
+
+// Cluster identifies the cluster a resource was collected from
+type Cluster struct {
+	Name        string `json:",omitempty"`
+	Region      string `json:",omitempty"`
+	Environment string `json:",omitempty"`
+}
+
+// BuildResourceKey builds the key a resource is stored with. Resources of
+// unnamed clusters keep their bare ID so single-cluster setups are unchanged.
+func BuildResourceKey(cluster string, id string) string {
+	if cluster == "" {
+		return id
+	}
+	return cluster + "/" + id
+}
@@ -0,0 +1,61 @@
+package domain
+
+// Container describes a container of a pod template. Init containers are
+// flagged so the regular ones can be told apart.
+type Container struct {
+	Name            string
+	Image           string
+	Init            bool            `json:",omitempty"`
+	ImagePullPolicy string          `json:",omitempty"`
+	Requests        *Resources      `json:",omitempty"`
+	Limits          *Resources      `json:",omitempty"`
+	Ports           []ContainerPort `json:",omitempty"`
+	LivenessProbe   *Probe          `json:",omitempty"`
+	ReadinessProbe  *Probe          `json:",omitempty"`
+	StartupProbe    *Probe          `json:",omitempty"`
+}
+
+// Resources holds CPU and memory quantities, e.g. 250m and 128Mi
+type Resources struct {
+	CPU    string `json:",omitempty"`
+	Memory string `json:",omitempty"`
+}
+
+// ContainerPort ...
+type ContainerPort struct {
+	Name          string `json:",omitempty"`
+	ContainerPort int32
+	Protocol      string `json:",omitempty"`
+}
+
+// Probe types
+const (
+	ProbeExec      = "exec"
+	ProbeHTTPGet   = "httpGet"
+	ProbeTCPSocket = "tcpSocket"
+)
+
+// Probe tells how a probe checks a container, e.g. httpGet
+type Probe struct {
+	Type string
+}
+
+// Workload is implemented by the kinds running a pod template
+type Workload interface {
+	K8sResource
+	GetContainers() map[string]string
+	GetContainerSpecs() []Container
+	GetPodLabels() map[string]string
+}
+
+// ContainersByName returns the name to image map of the regular containers,
+// the compatibility view of the container specs
+func ContainersByName(containers []Container) map[string]string {
+	m := make(map[string]string)
+	for _, c := range containers {
+		if !c.Init {
+			m[c.Name] = c.Image
+		}
+	}
+	return m
+}
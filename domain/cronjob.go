@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"reflect"
+)
+
+// CronJob ...
+type CronJob struct {
+	ID              string            `json:",omitempty"`
+	Name            string            `json:",omitempty"`
+	Generation      int64             `json:",omitempty"`
+	Namespace       string            `json:",omitempty"`
+	Labels          map[string]string `json:",omitempty"`
+	Annotations     map[string]string `json:",omitempty"`
+	OwnerReferences []OwnerReference  `json:",omitempty"`
+	PodLabels       map[string]string `json:",omitempty"`
+	// Containers maps the regular containers to their image, it is kept for
+	// the consumers of the catalog predating ContainerSpecs
+	Containers         map[string]string `json:",omitempty"`
+	ContainerSpecs     []Container       `json:",omitempty"`
+	Schedule           string            `json:",omitempty"`
+	Suspend            bool              `json:",omitempty"`
+	ConcurrencyPolicy  string            `json:",omitempty"`
+	LastScheduleTime   string            `json:",omitempty"`
+	LastSuccessfulTime string            `json:",omitempty"`
+	Cluster            *Cluster          `json:",omitempty"`
+	Timestamp          string            `json:"Timestamp"`
+	ObservedGeneration int64             `json:",omitempty"`
+}
+
+// GetID ...
+func (s *CronJob) GetID() string {
+	return s.ID
+}
+
+// GetType ...
+func (s *CronJob) GetType() reflect.Type {
+	return reflect.TypeOf(s)
+}
+
+// GetK8sResource ...
+func (s *CronJob) GetK8sResource() interface{} {
+	return s
+}
+
+// GetGeneration ...
+func (s *CronJob) GetGeneration() int64 {
+	return s.Generation
+}
+
+// GetNamespace ...
+func (s *CronJob) GetNamespace() string {
+	return s.Namespace
+}
+
+// GetName ...
+func (s *CronJob) GetName() string {
+	return s.Name
+}
+
+// GetLabels ...
+func (s *CronJob) GetLabels() map[string]string {
+	return s.Labels
+}
+
+// GetAnnotations ...
+func (s *CronJob) GetAnnotations() map[string]string {
+	return s.Annotations
+}
+
+// GetOwnerReferences ...
+func (s *CronJob) GetOwnerReferences() []OwnerReference {
+	return s.OwnerReferences
+}
+
+// GetPodLabels returns the labels of the pod template
+func (s *CronJob) GetPodLabels() map[string]string {
+	return s.PodLabels
+}
+
+// GetContainers ...
+func (s *CronJob) GetContainers() map[string]string {
+	return s.Containers
+}
+
+// GetContainerSpecs ...
+func (s *CronJob) GetContainerSpecs() []Container {
+	return s.ContainerSpecs
+}
+
+// GetSchedule ...
+func (s *CronJob) GetSchedule() string {
+	return s.Schedule
+}
+
+// IsSuspended ...
+func (s *CronJob) IsSuspended() bool {
+	return s.Suspend
+}
+
+// GetConcurrencyPolicy ...
+func (s *CronJob) GetConcurrencyPolicy() string {
+	return s.ConcurrencyPolicy
+}
+
+// GetLastScheduleTime ...
+func (s *CronJob) GetLastScheduleTime() string {
+	return s.LastScheduleTime
+}
+
+// GetLastSuccessfulTime ...
+func (s *CronJob) GetLastSuccessfulTime() string {
+	return s.LastSuccessfulTime
+}
+
+// GetTimestamp ...
+func (s *CronJob) GetTimestamp() string {
+	return s.Timestamp
+}
+
+// GetObservedGeneration ...
+func (s *CronJob) GetObservedGeneration() int64 {
+	return s.ObservedGeneration
+}
+
+// GetCluster ...
+func (s *CronJob) GetCluster() Cluster {
+	if s.Cluster == nil {
+		return Cluster{}
+	}
+	return *s.Cluster
+}
+
+// SetCluster ...
+func (s *CronJob) SetCluster(cluster Cluster) {
+	s.Cluster = &cluster
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+)
+
+// CustomResource is the catalog entry of an object of any watched
+// GroupVersionResource, typically a CRD. Fields holds the values extracted
+// with the JSONPath expressions configured for its resource.
+type CustomResource struct {
+	ID                 string                 `json:",omitempty"`
+	Name               string                 `json:",omitempty"`
+	Group              string                 `json:",omitempty"`
+	Version            string                 `json:",omitempty"`
+	Kind               string                 `json:",omitempty"`
+	Resource           string                 `json:",omitempty"`
+	APIVersion         string                 `json:",omitempty"`
+	Generation         int64                  `json:",omitempty"`
+	Namespace          string                 `json:",omitempty"`
+	Labels             map[string]string      `json:",omitempty"`
+	Annotations        map[string]string      `json:",omitempty"`
+	OwnerReferences    []OwnerReference       `json:",omitempty"`
+	Fields             map[string]interface{} `json:",omitempty"`
+	Cluster            *Cluster               `json:",omitempty"`
+	Timestamp          string                 `json:"Timestamp"`
+	ObservedGeneration int64                  `json:",omitempty"`
+}
+
+// GetID ...
+func (s *CustomResource) GetID() string {
+	return s.ID
+}
+
+// GetType ...
+func (s *CustomResource) GetType() reflect.Type {
+	return reflect.TypeOf(s)
+}
+
+// GetK8sResource ...
+func (s *CustomResource) GetK8sResource() interface{} {
+	return s
+}
+
+// GetGeneration ...
+func (s *CustomResource) GetGeneration() int64 {
+	return s.Generation
+}
+
+// GetNamespace ...
+func (s *CustomResource) GetNamespace() string {
+	return s.Namespace
+}
+
+// GetName ...
+func (s *CustomResource) GetName() string {
+	return s.Name
+}
+
+// GetLabels ...
+func (s *CustomResource) GetLabels() map[string]string {
+	return s.Labels
+}
+
+// GetGroup ...
+func (s *CustomResource) GetGroup() string {
+	return s.Group
+}
+
+// GetKind ...
+func (s *CustomResource) GetKind() string {
+	return s.Kind
+}
+
+// GetResource returns the plural resource name, e.g. rollouts
+func (s *CustomResource) GetResource() string {
+	return s.Resource
+}
+
+// GetFields ...
+func (s *CustomResource) GetFields() map[string]interface{} {
+	return s.Fields
+}
+
+// IsKind tells whether kind names the resource, either by its kind or its
+// plural resource name, regardless of case
+func (s *CustomResource) IsKind(kind string) bool {
+	return strings.EqualFold(s.Kind, kind) || strings.EqualFold(s.Resource, kind)
+}
+
+// GetAnnotations ...
+func (s *CustomResource) GetAnnotations() map[string]string {
+	return s.Annotations
+}
+
+// GetOwnerReferences ...
+func (s *CustomResource) GetOwnerReferences() []OwnerReference {
+	return s.OwnerReferences
+}
+
+// GetTimestamp ...
+func (s *CustomResource) GetTimestamp() string {
+	return s.Timestamp
+}
+
+// GetObservedGeneration ...
+func (s *CustomResource) GetObservedGeneration() int64 {
+	return s.ObservedGeneration
+}
+
+// GetCluster ...
+func (s *CustomResource) GetCluster() Cluster {
+	if s.Cluster == nil {
+		return Cluster{}
+	}
+	return *s.Cluster
+}
+
+// SetCluster ...
+func (s *CustomResource) SetCluster(cluster Cluster) {
+	s.Cluster = &cluster
+}
+
+// CoreGroupName stands for the empty core API group in routes and keys
+const CoreGroupName = "core"
+
+// GetGroupName returns the API group, or CoreGroupName for the core group
+func (s *CustomResource) GetGroupName() string {
+	if s.Group == "" {
+		return CoreGroupName
+	}
+	return s.Group
+}
+
+// GetGroupVersionResource returns the group, version and resource as
+// group/version/resource, the way the collector identifies watched resources
+func (s *CustomResource) GetGroupVersionResource() string {
+	return s.Group + "/" + s.Version + "/" + s.Resource
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"reflect"
+)
+
+// DaemonSet ...
+type DaemonSet struct {
+	ID              string            `json:",omitempty"`
+	Name            string            `json:",omitempty"`
+	Generation      int64             `json:",omitempty"`
+	Namespace       string            `json:",omitempty"`
+	Labels          map[string]string `json:",omitempty"`
+	Annotations     map[string]string `json:",omitempty"`
+	OwnerReferences []OwnerReference  `json:",omitempty"`
+	PodLabels       map[string]string `json:",omitempty"`
+	// Containers maps the regular containers to their image, it is kept for
+	// the consumers of the catalog predating ContainerSpecs
+	Containers         map[string]string `json:",omitempty"`
+	ContainerSpecs     []Container       `json:",omitempty"`
+	RunningImages      []RunningImage    `json:",omitempty"`
+	Cluster            *Cluster          `json:",omitempty"`
+	Timestamp          string            `json:"Timestamp"`
+	ObservedGeneration int64             `json:",omitempty"`
+}
+
+// GetID ...
+func (s *DaemonSet) GetID() string {
+	return s.ID
+}
+
+// GetType ...
+func (s *DaemonSet) GetType() reflect.Type {
+	return reflect.TypeOf(s)
+}
+
+// GetK8sResource ...
+func (s *DaemonSet) GetK8sResource() interface{} {
+	return s
+}
+
+// GetGeneration ...
+func (s *DaemonSet) GetGeneration() int64 {
+	return s.Generation
+}
+
+// GetNamespace ...
+func (s *DaemonSet) GetNamespace() string {
+	return s.Namespace
+}
+
+// GetName ...
+func (s *DaemonSet) GetName() string {
+	return s.Name
+}
+
+// GetLabels ...
+func (s *DaemonSet) GetLabels() map[string]string {
+	return s.Labels
+}
+
+// GetContainers ...
+func (s *DaemonSet) GetContainers() map[string]string {
+	return s.Containers
+}
+
+// GetContainerSpecs ...
+func (s *DaemonSet) GetContainerSpecs() []Container {
+	return s.ContainerSpecs
+}
+
+// GetRunningImages ...
+func (s *DaemonSet) GetRunningImages() []RunningImage {
+	return s.RunningImages
+}
+
+// SetRunningImages ...
+func (s *DaemonSet) SetRunningImages(runningImages []RunningImage) {
+	s.RunningImages = runningImages
+}
+
+// GetAnnotations ...
+func (s *DaemonSet) GetAnnotations() map[string]string {
+	return s.Annotations
+}
+
+// GetOwnerReferences ...
+func (s *DaemonSet) GetOwnerReferences() []OwnerReference {
+	return s.OwnerReferences
+}
+
+// GetPodLabels returns the labels of the pod template
+func (s *DaemonSet) GetPodLabels() map[string]string {
+	return s.PodLabels
+}
+
+// GetTimestamp ...
+func (s *DaemonSet) GetTimestamp() string {
+	return s.Timestamp
+}
+
+// GetObservedGeneration ...
+func (s *DaemonSet) GetObservedGeneration() int64 {
+	return s.ObservedGeneration
+}
+
+// GetCluster ...
+func (s *DaemonSet) GetCluster() Cluster {
+	if s.Cluster == nil {
+		return Cluster{}
+	}
+	return *s.Cluster
+}
+
+// SetCluster ...
+func (s *DaemonSet) SetCluster(cluster Cluster) {
+	s.Cluster = &cluster
+}
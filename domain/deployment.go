@@ -6,13 +6,23 @@ import (
 
 // Deployment ...
 type Deployment struct {
-	ID                 string            `json:",omitempty"`
-	Name               string            `json:",omitempty"`
-	Generation         int64             `json:",omitempty"`
-	Namespace          string            `json:",omitempty"`
-	Labels             map[string]string `json:",omitempty"`
-	Annotations        map[string]string `json:",omitempty"`
+	ID              string            `json:",omitempty"`
+	Name            string            `json:",omitempty"`
+	Generation      int64             `json:",omitempty"`
+	Namespace       string            `json:",omitempty"`
+	Labels          map[string]string `json:",omitempty"`
+	Annotations     map[string]string `json:",omitempty"`
+	OwnerReferences []OwnerReference  `json:",omitempty"`
+	PodLabels       map[string]string `json:",omitempty"`
+	// Containers maps the regular containers to their image, it is kept for
+	// the consumers of the catalog predating ContainerSpecs
 	Containers         map[string]string `json:",omitempty"`
+	ContainerSpecs     []Container       `json:",omitempty"`
+	RunningImages      []RunningImage    `json:",omitempty"`
+	Replicas           *Replicas         `json:",omitempty"`
+	Strategy           *Strategy         `json:",omitempty"`
+	Conditions         []Condition       `json:",omitempty"`
+	Cluster            *Cluster          `json:",omitempty"`
 	Timestamp          string            `json:"Timestamp"`
 	ObservedGeneration int64             `json:",omitempty"`
 }
@@ -57,11 +67,36 @@ func (s *Deployment) GetAnnotations() map[string]string {
 	return s.Annotations
 }
 
+// GetOwnerReferences ...
+func (s *Deployment) GetOwnerReferences() []OwnerReference {
+	return s.OwnerReferences
+}
+
+// GetPodLabels returns the labels of the pod template
+func (s *Deployment) GetPodLabels() map[string]string {
+	return s.PodLabels
+}
+
 // GetContainers ...
 func (s *Deployment) GetContainers() map[string]string {
 	return s.Containers
 }
 
+// GetContainerSpecs ...
+func (s *Deployment) GetContainerSpecs() []Container {
+	return s.ContainerSpecs
+}
+
+// GetRunningImages ...
+func (s *Deployment) GetRunningImages() []RunningImage {
+	return s.RunningImages
+}
+
+// SetRunningImages ...
+func (s *Deployment) SetRunningImages(runningImages []RunningImage) {
+	s.RunningImages = runningImages
+}
+
 // GetTimestamp ...
 func (s *Deployment) GetTimestamp() string {
 	return s.Timestamp
@@ -71,3 +106,63 @@ func (s *Deployment) GetTimestamp() string {
 func (s *Deployment) GetObservedGeneration() int64 {
 	return s.ObservedGeneration
 }
+
+// GetCluster ...
+func (s *Deployment) GetCluster() Cluster {
+	if s.Cluster == nil {
+		return Cluster{}
+	}
+	return *s.Cluster
+}
+
+// SetCluster ...
+func (s *Deployment) SetCluster(cluster Cluster) {
+	s.Cluster = &cluster
+}
+
+// GetReplicas ...
+func (s *Deployment) GetReplicas() *Replicas {
+	return s.Replicas
+}
+
+// GetStrategy ...
+func (s *Deployment) GetStrategy() *Strategy {
+	return s.Strategy
+}
+
+// GetConditions ...
+func (s *Deployment) GetConditions() []Condition {
+	return s.Conditions
+}
+
+// GetRolloutStatus tells a stalled rollout, which exceeded its progress
+// deadline, from a degraded one, missing available replicas or failing to
+// create them, and from one still progressing.
+func (s *Deployment) GetRolloutStatus() RolloutStatus {
+	if s.Replicas == nil {
+		return RolloutStatus{State: RolloutUnknown}
+	}
+
+	if progressing := findCondition(s.Conditions, ConditionProgressing); progressing != nil &&
+		progressing.Status == "False" && progressing.Reason == progressDeadlineExceeded {
+		return RolloutStatus{State: RolloutStalled, Reason: conditionReason(progressing)}
+	}
+
+	if failure := findCondition(s.Conditions, ConditionReplicaFailure); failure != nil && failure.Status == "True" {
+		return RolloutStatus{State: RolloutDegraded, Reason: conditionReason(failure)}
+	}
+
+	if available := findCondition(s.Conditions, ConditionAvailable); available != nil && available.Status == "False" {
+		return RolloutStatus{State: RolloutDegraded, Reason: conditionReason(available)}
+	}
+
+	if s.ObservedGeneration < s.Generation || s.Replicas.Updated < s.Replicas.Desired {
+		return RolloutStatus{State: RolloutProgressing}
+	}
+
+	if s.Replicas.Available < s.Replicas.Desired {
+		return RolloutStatus{State: RolloutDegraded, Reason: "MinimumReplicasUnavailable"}
+	}
+
+	return RolloutStatus{State: RolloutComplete}
+}
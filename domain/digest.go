@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// RunningImage counts the pods of a workload running a container on an image
+// digest, as reported by the pod statuses
+type RunningImage struct {
+	Container string
+	Digest    string
+	Pods      int32
+}
+
+// PodRunner is implemented by the workloads whose pods are tracked to know
+// the image digests they actually run
+type PodRunner interface {
+	Workload
+	GetRunningImages() []RunningImage
+	SetRunningImages([]RunningImage)
+}
+
+// ImageDrift describes a container of a workload running several digests,
+// usually mid-rollout, or a digest other than the one pinned by its template
+type ImageDrift struct {
+	Container string
+	Image     string
+	Digests   []RunningImage
+	Mixed     bool
+	Diverged  bool
+}
+
+// DigestOf returns the repository digest of an image reference or an image ID
+// reported by the kubelet, e.g. docker-pullable://nginx@sha256:..., or an
+// empty string when it is unknown. Image IDs such as docker://sha256:... or a
+// bare sha256:... are local image config IDs, not repository digests
+func DigestOf(image string) string {
+	if i := strings.LastIndex(image, "@sha256:"); i >= 0 {
+		return image[i+1:]
+	}
+	return ""
+}
+
+// GetImageDrifts returns the containers of the workload running mixed digests
+// or a digest different from the template, sorted by container name
+func GetImageDrifts(workload PodRunner) []ImageDrift {
+	byContainer := make(map[string][]RunningImage)
+	for _, running := range workload.GetRunningImages() {
+		byContainer[running.Container] = append(byContainer[running.Container], running)
+	}
+
+	drifts := make([]ImageDrift, 0)
+	for _, container := range workload.GetContainerSpecs() {
+		digests, ok := byContainer[container.Name]
+		if !ok {
+			continue
+		}
+
+		drift := ImageDrift{
+			Container: container.Name,
+			Image:     container.Image,
+			Digests:   digests,
+			Mixed:     len(digests) > 1,
+		}
+		if pinned := DigestOf(container.Image); pinned != "" {
+			for _, running := range digests {
+				if running.Digest != pinned {
+					drift.Diverged = true
+				}
+			}
+		}
+
+		if drift.Mixed || drift.Diverged {
+			drifts = append(drifts, drift)
+		}
+	}
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].Container < drifts[j].Container
+	})
+
+	return drifts
+}
@@ -0,0 +1,103 @@
+package domain
+
+import "strings"
+
+// DefaultRegistry is the registry of the images naming none, e.g. nginx:1.19
+const DefaultRegistry = "docker.io"
+
+const defaultTag = "latest"
+const officialRepositoryPrefix = "library/"
+
+// ImageReference is an image split into its parts, normalized the way the
+// container runtimes resolve it: docker.io/library/nginx:latest for nginx
+type ImageReference struct {
+	Registry   string
+	Repository string
+	Tag        string `json:",omitempty"`
+	Digest     string `json:",omitempty"`
+}
+
+// ParseImage splits an image of a container into registry, repository, tag
+// and digest. Images pinned by digest only have no tag.
+func ParseImage(image string) ImageReference {
+	reference := ImageReference{}
+	name := image
+
+	if i := strings.Index(name, "@"); i >= 0 {
+		reference.Digest = name[i+1:]
+		name = name[:i]
+	}
+
+	if i := strings.LastIndex(name, ":"); i >= 0 && !strings.Contains(name[i+1:], "/") {
+		reference.Tag = name[i+1:]
+		name = name[:i]
+	}
+
+	reference.Registry = DefaultRegistry
+	if i := strings.Index(name, "/"); i >= 0 && isRegistry(name[:i]) {
+		reference.Registry = name[:i]
+		name = name[i+1:]
+	}
+	if reference.Registry == "index.docker.io" {
+		reference.Registry = DefaultRegistry
+	}
+
+	if reference.Registry == DefaultRegistry && !strings.Contains(name, "/") {
+		name = officialRepositoryPrefix + name
+	}
+	reference.Repository = name
+
+	if reference.Tag == "" && reference.Digest == "" {
+		reference.Tag = defaultTag
+	}
+
+	return reference
+}
+
+// isRegistry tells a registry host from the first path component of a
+// repository, the way the docker reference grammar does
+func isRegistry(component string) bool {
+	return strings.ContainsAny(component, ".:") || component == "localhost"
+}
+
+// Name returns the registry and repository, e.g. docker.io/library/nginx
+func (r ImageReference) Name() string {
+	return r.Registry + "/" + r.Repository
+}
+
+// String returns the normalized image
+func (r ImageReference) String() string {
+	image := r.Name()
+	if r.Tag != "" {
+		image += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		image += "@" + r.Digest
+	}
+	return image
+}
+
+// MatchesRepository tells whether the image belongs to the repository at any
+// tag. The repository may name its registry, otherwise any registry matches.
+func (r ImageReference) MatchesRepository(repository string) bool {
+	if i := strings.Index(repository, "/"); i >= 0 && isRegistry(repository[:i]) {
+		return ParseImage(repository).Name() == r.Name()
+	}
+	return repository == r.Repository || officialRepositoryPrefix+repository == r.Repository
+}
+
+// ImagesOf returns the images of every container of the workload, init
+// containers included, falling back on the Containers map for resources
+// cataloged before ContainerSpecs
+func ImagesOf(workload Workload) map[string]string {
+	specs := workload.GetContainerSpecs()
+	if len(specs) == 0 {
+		return workload.GetContainers()
+	}
+
+	images := make(map[string]string, len(specs))
+	for _, container := range specs {
+		images[container.Name] = container.Image
+	}
+	return images
+}
@@ -2,5 +2,22 @@ package domain
 
 // Instance ...
 type Instance struct {
-	Address string `json:",omitempty"`
+	Address  string         `json:",omitempty"`
+	NotReady bool           `json:",omitempty"`
+	PodName  string         `json:",omitempty"`
+	NodeName string         `json:",omitempty"`
+	Zone     string         `json:",omitempty"`
+	Ports    []InstancePort `json:",omitempty"`
+}
+
+// InstancePort ...
+type InstancePort struct {
+	Name     string `json:",omitempty"`
+	Protocol string `json:",omitempty"`
+	Port     int    `json:",omitempty"`
+}
+
+// IsReady ...
+func (i *Instance) IsReady() bool {
+	return !i.NotReady
 }
@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"reflect"
+)
+
+// Job ...
+type Job struct {
+	ID              string            `json:",omitempty"`
+	Name            string            `json:",omitempty"`
+	Generation      int64             `json:",omitempty"`
+	Namespace       string            `json:",omitempty"`
+	Labels          map[string]string `json:",omitempty"`
+	Annotations     map[string]string `json:",omitempty"`
+	OwnerReferences []OwnerReference  `json:",omitempty"`
+	PodLabels       map[string]string `json:",omitempty"`
+	// Containers maps the regular containers to their image, it is kept for
+	// the consumers of the catalog predating ContainerSpecs
+	Containers         map[string]string `json:",omitempty"`
+	ContainerSpecs     []Container       `json:",omitempty"`
+	RunningImages      []RunningImage    `json:",omitempty"`
+	CronJobID          string            `json:",omitempty"`
+	CronJobName        string            `json:",omitempty"`
+	StartTime          string            `json:",omitempty"`
+	CompletionTime     string            `json:",omitempty"`
+	Active             int32             `json:",omitempty"`
+	Succeeded          int32             `json:",omitempty"`
+	Failed             int32             `json:",omitempty"`
+	Cluster            *Cluster          `json:",omitempty"`
+	Timestamp          string            `json:"Timestamp"`
+	ObservedGeneration int64             `json:",omitempty"`
+}
+
+// GetID ...
+func (s *Job) GetID() string {
+	return s.ID
+}
+
+// GetType ...
+func (s *Job) GetType() reflect.Type {
+	return reflect.TypeOf(s)
+}
+
+// GetK8sResource ...
+func (s *Job) GetK8sResource() interface{} {
+	return s
+}
+
+// GetGeneration ...
+func (s *Job) GetGeneration() int64 {
+	return s.Generation
+}
+
+// GetNamespace ...
+func (s *Job) GetNamespace() string {
+	return s.Namespace
+}
+
+// GetName ...
+func (s *Job) GetName() string {
+	return s.Name
+}
+
+// GetLabels ...
+func (s *Job) GetLabels() map[string]string {
+	return s.Labels
+}
+
+// GetAnnotations ...
+func (s *Job) GetAnnotations() map[string]string {
+	return s.Annotations
+}
+
+// GetOwnerReferences ...
+func (s *Job) GetOwnerReferences() []OwnerReference {
+	return s.OwnerReferences
+}
+
+// GetPodLabels returns the labels of the pod template
+func (s *Job) GetPodLabels() map[string]string {
+	return s.PodLabels
+}
+
+// GetContainers ...
+func (s *Job) GetContainers() map[string]string {
+	return s.Containers
+}
+
+// GetContainerSpecs ...
+func (s *Job) GetContainerSpecs() []Container {
+	return s.ContainerSpecs
+}
+
+// GetRunningImages ...
+func (s *Job) GetRunningImages() []RunningImage {
+	return s.RunningImages
+}
+
+// SetRunningImages ...
+func (s *Job) SetRunningImages(runningImages []RunningImage) {
+	s.RunningImages = runningImages
+}
+
+// GetCronJobID ...
+func (s *Job) GetCronJobID() string {
+	return s.CronJobID
+}
+
+// GetCronJobName ...
+func (s *Job) GetCronJobName() string {
+	return s.CronJobName
+}
+
+// GetStartTime ...
+func (s *Job) GetStartTime() string {
+	return s.StartTime
+}
+
+// GetCompletionTime ...
+func (s *Job) GetCompletionTime() string {
+	return s.CompletionTime
+}
+
+// GetTimestamp ...
+func (s *Job) GetTimestamp() string {
+	return s.Timestamp
+}
+
+// GetObservedGeneration ...
+func (s *Job) GetObservedGeneration() int64 {
+	return s.ObservedGeneration
+}
+
+// GetCluster ...
+func (s *Job) GetCluster() Cluster {
+	if s.Cluster == nil {
+		return Cluster{}
+	}
+	return *s.Cluster
+}
+
+// SetCluster ...
+func (s *Job) SetCluster(cluster Cluster) {
+	s.Cluster = &cluster
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Kind describes a catalog resource kind to the subsystems that handle it:
+// the publishers, the HTTP routes, the Kafka consumers, the service and the
+// metrics all iterate the registered kinds instead of switching on types.
+type Kind struct {
+	// Name is the kind name, e.g. Deployment. Metric names derive from it
+	Name string
+	// Label is the kind in lower case words, e.g. custom resource
+	Label string
+	// Segment is the URL segment and Kafka key prefix, e.g. deployments
+	Segment string
+	// Route lists the path variables between the segment and the id, e.g.
+	// {group}/{kind}. RouteValues fills them in from a resource
+	Route       string
+	RouteValues func(resource K8sResource) []string
+	// Matches tells whether a resource matches the route variables of a
+	// list request. Every resource of the kind matches when it is nil
+	Matches func(resource K8sResource, vars map[string]string) bool
+	// Silent kinds are stored without info logs or metrics, and deleting a
+	// missing one succeeds, the way services always were
+	Silent bool
+	// New returns an empty resource of the kind to decode into
+	New func() K8sResource
+}
+
+var kindsMutex sync.RWMutex
+var kinds []Kind
+
+// RegisterKind adds a kind to the registry. Kinds must be registered before
+// the subsystems using them start, typically from an init function.
+func RegisterKind(kind Kind) {
+	kindsMutex.Lock()
+	defer kindsMutex.Unlock()
+
+	kinds = append(kinds, kind)
+}
+
+// Kinds returns the registered kinds in registration order
+func Kinds() []Kind {
+	kindsMutex.RLock()
+	defer kindsMutex.RUnlock()
+
+	return append([]Kind{}, kinds...)
+}
+
+// KindOf returns the registered kind of the resource
+func KindOf(resource K8sResource) (Kind, bool) {
+	resourceType := reflect.TypeOf(resource)
+	for _, kind := range Kinds() {
+		if kind.Type() == resourceType {
+			return kind, true
+		}
+	}
+	return Kind{}, false
+}
+
+// KindByName returns the registered kind of the name, e.g. Deployment
+func KindByName(name string) (Kind, bool) {
+	for _, kind := range Kinds() {
+		if kind.Name == name {
+			return kind, true
+		}
+	}
+	return Kind{}, false
+}
+
+// KindBySegment returns the registered kind served under the URL segment
+func KindBySegment(segment string) (Kind, bool) {
+	for _, kind := range Kinds() {
+		if kind.Segment == segment {
+			return kind, true
+		}
+	}
+	return Kind{}, false
+}
+
+// Type returns the type of the resources of the kind
+func (k Kind) Type() reflect.Type {
+	return reflect.TypeOf(k.New())
+}
+
+// Path returns the route of the kind, e.g. /resources/{group}/{kind}
+func (k Kind) Path() string {
+	if k.Route == "" {
+		return "/" + k.Segment
+	}
+	return "/" + k.Segment + "/" + k.Route
+}
+
+// PathOf returns the path prefix of the resource, up to and including the
+// slash preceding its id, e.g. /resources/argoproj.io/Rollout/
+func (k Kind) PathOf(resource K8sResource) string {
+	path := "/" + k.Segment + "/"
+	if k.RouteValues != nil {
+		for _, value := range k.RouteValues(resource) {
+			path += value + "/"
+		}
+	}
+	return path
+}
+
+// KeyFromPath returns the resource key of a path following the segment,
+// dropping the route values, e.g. argoproj.io/Rollout/east/uid gives east/uid
+func (k Kind) KeyFromPath(path string) string {
+	if k.Route == "" {
+		return path
+	}
+	parts := strings.SplitN(path, "/", strings.Count(k.Route, "/")+2)
+	return parts[len(parts)-1]
+}
+
+// Decode ...
+func (k Kind) Decode(data []byte) (K8sResource, error) {
+	resource := k.New()
+	err := json.Unmarshal(data, resource)
+	return resource, err
+}
+
+// Encode ...
+func (k Kind) Encode(resource K8sResource) ([]byte, error) {
+	return json.Marshal(resource)
+}
+
+// MetricName returns the name of the counter of an action on the kind, e.g.
+// createDeployment
+func (k Kind) MetricName(action string) string {
+	return action + k.Name
+}
+
+// MetricSubsystem ...
+func (k Kind) MetricSubsystem() string {
+	return strings.Replace(k.Label, " ", "", -1)
+}
@@ -0,0 +1,62 @@
+package domain
+
+func init() {
+	RegisterKind(Kind{
+		Name:    "Service",
+		Label:   "service",
+		Segment: "services",
+		Silent:  true,
+		New:     func() K8sResource { return &Service{} },
+	})
+	RegisterKind(Kind{
+		Name:    "Deployment",
+		Label:   "deployment",
+		Segment: "deployments",
+		New:     func() K8sResource { return &Deployment{} },
+	})
+	RegisterKind(Kind{
+		Name:    "ReplicaSet",
+		Label:   "replicaset",
+		Segment: "replicasets",
+		New:     func() K8sResource { return &ReplicaSet{} },
+	})
+	RegisterKind(Kind{
+		Name:    "StatefulSet",
+		Label:   "statefulset",
+		Segment: "statefulsets",
+		New:     func() K8sResource { return &StatefulSet{} },
+	})
+	RegisterKind(Kind{
+		Name:    "DaemonSet",
+		Label:   "daemonset",
+		Segment: "daemonsets",
+		New:     func() K8sResource { return &DaemonSet{} },
+	})
+	RegisterKind(Kind{
+		Name:    "Job",
+		Label:   "job",
+		Segment: "jobs",
+		New:     func() K8sResource { return &Job{} },
+	})
+	RegisterKind(Kind{
+		Name:    "CronJob",
+		Label:   "cronjob",
+		Segment: "cronjobs",
+		New:     func() K8sResource { return &CronJob{} },
+	})
+	RegisterKind(Kind{
+		Name:    "CustomResource",
+		Label:   "custom resource",
+		Segment: "resources",
+		Route:   "{group}/{kind}",
+		RouteValues: func(resource K8sResource) []string {
+			customResource := resource.(*CustomResource)
+			return []string{customResource.GetGroupName(), customResource.GetKind()}
+		},
+		Matches: func(resource K8sResource, vars map[string]string) bool {
+			customResource := resource.(*CustomResource)
+			return customResource.GetGroupName() == vars["group"] && customResource.IsKind(vars["kind"])
+		},
+		New: func() K8sResource { return &CustomResource{} },
+	})
+}
@@ -0,0 +1,39 @@
+package domain
+
+// OwnerReference points to the resource managing another one, e.g. the
+// CronJob of a Job. UID is the ID of the owner in the catalog.
+type OwnerReference struct {
+	APIVersion string `json:",omitempty"`
+	Kind       string
+	Name       string
+	UID        string
+	Controller bool `json:",omitempty"`
+}
+
+// Owned is implemented by the kinds recording their owner references
+type Owned interface {
+	K8sResource
+	GetOwnerReferences() []OwnerReference
+}
+
+// Selecting is implemented by the kinds selecting pods by label, a Service
+// selecting the workloads whose pod template carries every selector label
+type Selecting interface {
+	K8sResource
+	GetSelector() map[string]string
+}
+
+// Selects tells whether the selector matches the pod labels. An empty
+// selector matches nothing, as for services whose endpoints are managed by
+// hand.
+func Selects(selector map[string]string, podLabels map[string]string) bool {
+	if len(selector) == 0 {
+		return false
+	}
+	for key, value := range selector {
+		if podLabels[key] != value {
+			return false
+		}
+	}
+	return true
+}
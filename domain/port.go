@@ -0,0 +1,10 @@
+package domain
+
+// ServicePort ...
+type ServicePort struct {
+	Name       string `json:",omitempty"`
+	Protocol   string `json:",omitempty"`
+	Port       int    `json:",omitempty"`
+	TargetPort string `json:",omitempty"`
+	NodePort   int    `json:",omitempty"`
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"reflect"
+)
+
+// ReplicaSet is cataloged to link deployments to their revisions, it carries
+// no containers so it is left out of the image and workload queries
+type ReplicaSet struct {
+	ID                 string            `json:",omitempty"`
+	Name               string            `json:",omitempty"`
+	Generation         int64             `json:",omitempty"`
+	Namespace          string            `json:",omitempty"`
+	Labels             map[string]string `json:",omitempty"`
+	Annotations        map[string]string `json:",omitempty"`
+	OwnerReferences    []OwnerReference  `json:",omitempty"`
+	DeploymentID       string            `json:",omitempty"`
+	DeploymentName     string            `json:",omitempty"`
+	Replicas           int32             `json:",omitempty"`
+	ReadyReplicas      int32             `json:",omitempty"`
+	AvailableReplicas  int32             `json:",omitempty"`
+	Cluster            *Cluster          `json:",omitempty"`
+	Timestamp          string            `json:"Timestamp"`
+	ObservedGeneration int64             `json:",omitempty"`
+}
+
+// GetID ...
+func (s *ReplicaSet) GetID() string {
+	return s.ID
+}
+
+// GetType ...
+func (s *ReplicaSet) GetType() reflect.Type {
+	return reflect.TypeOf(s)
+}
+
+// GetK8sResource ...
+func (s *ReplicaSet) GetK8sResource() interface{} {
+	return s
+}
+
+// GetGeneration ...
+func (s *ReplicaSet) GetGeneration() int64 {
+	return s.Generation
+}
+
+// GetNamespace ...
+func (s *ReplicaSet) GetNamespace() string {
+	return s.Namespace
+}
+
+// GetName ...
+func (s *ReplicaSet) GetName() string {
+	return s.Name
+}
+
+// GetLabels ...
+func (s *ReplicaSet) GetLabels() map[string]string {
+	return s.Labels
+}
+
+// GetAnnotations ...
+func (s *ReplicaSet) GetAnnotations() map[string]string {
+	return s.Annotations
+}
+
+// GetOwnerReferences ...
+func (s *ReplicaSet) GetOwnerReferences() []OwnerReference {
+	return s.OwnerReferences
+}
+
+// GetDeploymentID ...
+func (s *ReplicaSet) GetDeploymentID() string {
+	return s.DeploymentID
+}
+
+// GetDeploymentName ...
+func (s *ReplicaSet) GetDeploymentName() string {
+	return s.DeploymentName
+}
+
+// GetTimestamp ...
+func (s *ReplicaSet) GetTimestamp() string {
+	return s.Timestamp
+}
+
+// GetObservedGeneration ...
+func (s *ReplicaSet) GetObservedGeneration() int64 {
+	return s.ObservedGeneration
+}
+
+// GetCluster ...
+func (s *ReplicaSet) GetCluster() Cluster {
+	if s.Cluster == nil {
+		return Cluster{}
+	}
+	return *s.Cluster
+}
+
+// SetCluster ...
+func (s *ReplicaSet) SetCluster(cluster Cluster) {
+	s.Cluster = &cluster
+}
@@ -16,6 +16,8 @@ type K8sResource interface {
 	GetAnnotations() map[string]string
 	GetTimestamp() string
 	GetObservedGeneration() int64
+	GetCluster() Cluster
+	SetCluster(Cluster)
 }
 
 // Resource ...
@@ -72,3 +74,18 @@ func (r *Resource) GetTimestamp() string {
 func (r *Resource) GetObservedGeneration() int64 {
 	return r.K8sResource.GetObservedGeneration()
 }
+
+// GetCluster ...
+func (r *Resource) GetCluster() Cluster {
+	return r.K8sResource.GetCluster()
+}
+
+// SetCluster ...
+func (r *Resource) SetCluster(cluster Cluster) {
+	r.K8sResource.SetCluster(cluster)
+}
+
+// GetKey returns the key the resource is stored with, unique across clusters
+func (r *Resource) GetKey() string {
+	return BuildResourceKey(r.GetCluster().Name, r.GetID())
+}
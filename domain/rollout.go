@@ -0,0 +1,85 @@
+package domain
+
+// Replicas counts the pods of a workload, desired and by state
+type Replicas struct {
+	Desired     int32
+	Current     int32 `json:",omitempty"`
+	Ready       int32
+	Available   int32
+	Updated     int32
+	Unavailable int32 `json:",omitempty"`
+}
+
+// Strategy is the update strategy of a workload. MaxSurge and MaxUnavailable
+// apply to rolling updates of deployments, Partition to statefulsets.
+type Strategy struct {
+	Type           string
+	MaxSurge       string `json:",omitempty"`
+	MaxUnavailable string `json:",omitempty"`
+	Partition      *int32 `json:",omitempty"`
+}
+
+// Condition is a status condition of a workload, e.g. Progressing
+type Condition struct {
+	Type               string
+	Status             string
+	Reason             string `json:",omitempty"`
+	Message            string `json:",omitempty"`
+	LastUpdateTime     string `json:",omitempty"`
+	LastTransitionTime string `json:",omitempty"`
+}
+
+// Condition types reported by deployments
+const (
+	ConditionProgressing    = "Progressing"
+	ConditionAvailable      = "Available"
+	ConditionReplicaFailure = "ReplicaFailure"
+)
+
+const progressDeadlineExceeded = "ProgressDeadlineExceeded"
+
+// RolloutState ...
+type RolloutState string
+
+// Rollout states, unknown when the collector did not report replicas
+const (
+	RolloutUnknown     RolloutState = "unknown"
+	RolloutComplete    RolloutState = "complete"
+	RolloutProgressing RolloutState = "progressing"
+	RolloutDegraded    RolloutState = "degraded"
+	RolloutStalled     RolloutState = "stalled"
+)
+
+// RolloutStatus tells how the rollout of a workload is going and why
+type RolloutStatus struct {
+	State  RolloutState
+	Reason string `json:",omitempty"`
+}
+
+// IsHealthy is false for degraded and stalled rollouts
+func (s RolloutStatus) IsHealthy() bool {
+	return s.State != RolloutDegraded && s.State != RolloutStalled
+}
+
+// RolledOut is implemented by the workloads whose rollout is tracked
+type RolledOut interface {
+	K8sResource
+	GetReplicas() *Replicas
+	GetRolloutStatus() RolloutStatus
+}
+
+func findCondition(conditions []Condition, conditionType string) *Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+func conditionReason(condition *Condition) string {
+	if condition.Message != "" {
+		return condition.Reason + ": " + condition.Message
+	}
+	return condition.Reason
+}
@@ -6,17 +6,25 @@ import (
 
 // Service ...
 type Service struct {
-	ID                 string            `json:"ID"`
-	Name               string            `json:"Name"`
-	Port               int               `json:"Port"`
-	Address            string            `json:"Address"`
-	Generation         int64             `json:"Generation"`
-	Namespace          string            `json:"Namespace"`
-	Instances          []Instance        `json:"Instances"`
-	Labels             map[string]string `json:",omitempty"`
-	Annotations        map[string]string `json:",omitempty"`
-	Timestamp          string            `json:"Timestamp"`
-	ObservedGeneration int64             `json:",omitempty"`
+	ID                  string            `json:"ID"`
+	Name                string            `json:"Name"`
+	Port                int               `json:"Port"`
+	Ports               []ServicePort     `json:"Ports,omitempty"`
+	Type                string            `json:"Type,omitempty"`
+	Address             string            `json:"Address"`
+	ExternalIPs         []string          `json:"ExternalIPs,omitempty"`
+	ExternalName        string            `json:"ExternalName,omitempty"`
+	LoadBalancerIngress []string          `json:"LoadBalancerIngress,omitempty"`
+	Generation          int64             `json:"Generation"`
+	Namespace           string            `json:"Namespace"`
+	Instances           []Instance        `json:"Instances"`
+	Labels              map[string]string `json:",omitempty"`
+	Annotations         map[string]string `json:",omitempty"`
+	OwnerReferences     []OwnerReference  `json:",omitempty"`
+	Selector            map[string]string `json:",omitempty"`
+	Cluster             *Cluster          `json:",omitempty"`
+	Timestamp           string            `json:"Timestamp"`
+	ObservedGeneration  int64             `json:",omitempty"`
 }
 
 // AddInstance ...
@@ -24,6 +32,31 @@ func (s *Service) AddInstance(endpoint Instance) {
 	s.Instances = append(s.Instances, endpoint)
 }
 
+// CountReadyInstances ...
+func (s *Service) CountReadyInstances() int {
+	count := 0
+	for i := range s.Instances {
+		if s.Instances[i].IsReady() {
+			count++
+		}
+	}
+	return count
+}
+
+// GetInstanceZones returns the distinct zones where the service instances run.
+func (s *Service) GetInstanceZones() []string {
+	seen := make(map[string]bool)
+	zones := make([]string, 0)
+	for _, instance := range s.Instances {
+		if instance.Zone == "" || seen[instance.Zone] {
+			continue
+		}
+		seen[instance.Zone] = true
+		zones = append(zones, instance.Zone)
+	}
+	return zones
+}
+
 // GetID ...
 func (s *Service) GetID() string {
 	return s.ID
@@ -64,6 +97,44 @@ func (s *Service) GetPort() int {
 	return s.Port
 }
 
+// GetPorts returns every port exposed by the service. Services published by
+// collectors that only send the single Port field get it wrapped as a list.
+func (s *Service) GetPorts() []ServicePort {
+	if len(s.Ports) == 0 && s.Port != 0 {
+		return []ServicePort{{Port: s.Port}}
+	}
+	return s.Ports
+}
+
+// GetServiceType ...
+func (s *Service) GetServiceType() string {
+	return s.Type
+}
+
+// GetExternalIPs ...
+func (s *Service) GetExternalIPs() []string {
+	return s.ExternalIPs
+}
+
+// GetExternalName ...
+func (s *Service) GetExternalName() string {
+	return s.ExternalName
+}
+
+// GetLoadBalancerIngress ...
+func (s *Service) GetLoadBalancerIngress() []string {
+	return s.LoadBalancerIngress
+}
+
+// IsExposed tells whether the service is reachable from outside the cluster.
+func (s *Service) IsExposed() bool {
+	switch s.Type {
+	case "NodePort", "LoadBalancer":
+		return true
+	}
+	return len(s.ExternalIPs) > 0 || len(s.LoadBalancerIngress) > 0
+}
+
 // GetLabels ...
 func (s *Service) GetLabels() map[string]string {
 	return s.Labels
@@ -74,6 +145,16 @@ func (s *Service) GetAnnotations() map[string]string {
 	return s.Annotations
 }
 
+// GetOwnerReferences ...
+func (s *Service) GetOwnerReferences() []OwnerReference {
+	return s.OwnerReferences
+}
+
+// GetSelector ...
+func (s *Service) GetSelector() map[string]string {
+	return s.Selector
+}
+
 // GetTimestamp ...
 func (s *Service) GetTimestamp() string {
 	return s.Timestamp
@@ -83,3 +164,16 @@ func (s *Service) GetTimestamp() string {
 func (s *Service) GetObservedGeneration() int64 {
 	return s.ObservedGeneration
 }
+
+// GetCluster ...
+func (s *Service) GetCluster() Cluster {
+	if s.Cluster == nil {
+		return Cluster{}
+	}
+	return *s.Cluster
+}
+
+// SetCluster ...
+func (s *Service) SetCluster(cluster Cluster) {
+	s.Cluster = &cluster
+}
@@ -6,13 +6,25 @@ import (
 
 // StatefulSet ...
 type StatefulSet struct {
-	ID                 string            `json:",omitempty"`
-	Name               string            `json:",omitempty"`
-	Generation         int64             `json:",omitempty"`
-	Namespace          string            `json:",omitempty"`
-	Labels             map[string]string `json:",omitempty"`
-	Annotations        map[string]string `json:",omitempty"`
+	ID              string            `json:",omitempty"`
+	Name            string            `json:",omitempty"`
+	Generation      int64             `json:",omitempty"`
+	Namespace       string            `json:",omitempty"`
+	Labels          map[string]string `json:",omitempty"`
+	Annotations     map[string]string `json:",omitempty"`
+	OwnerReferences []OwnerReference  `json:",omitempty"`
+	PodLabels       map[string]string `json:",omitempty"`
+	// Containers maps the regular containers to their image, it is kept for
+	// the consumers of the catalog predating ContainerSpecs
 	Containers         map[string]string `json:",omitempty"`
+	ContainerSpecs     []Container       `json:",omitempty"`
+	RunningImages      []RunningImage    `json:",omitempty"`
+	Replicas           *Replicas         `json:",omitempty"`
+	Strategy           *Strategy         `json:",omitempty"`
+	Conditions         []Condition       `json:",omitempty"`
+	CurrentRevision    string            `json:",omitempty"`
+	UpdateRevision     string            `json:",omitempty"`
+	Cluster            *Cluster          `json:",omitempty"`
 	Timestamp          string            `json:"Timestamp"`
 	ObservedGeneration int64             `json:",omitempty"`
 }
@@ -57,11 +69,36 @@ func (s *StatefulSet) GetContainers() map[string]string {
 	return s.Containers
 }
 
+// GetContainerSpecs ...
+func (s *StatefulSet) GetContainerSpecs() []Container {
+	return s.ContainerSpecs
+}
+
+// GetRunningImages ...
+func (s *StatefulSet) GetRunningImages() []RunningImage {
+	return s.RunningImages
+}
+
+// SetRunningImages ...
+func (s *StatefulSet) SetRunningImages(runningImages []RunningImage) {
+	s.RunningImages = runningImages
+}
+
 // GetAnnotations ...
 func (s *StatefulSet) GetAnnotations() map[string]string {
 	return s.Annotations
 }
 
+// GetOwnerReferences ...
+func (s *StatefulSet) GetOwnerReferences() []OwnerReference {
+	return s.OwnerReferences
+}
+
+// GetPodLabels returns the labels of the pod template
+func (s *StatefulSet) GetPodLabels() map[string]string {
+	return s.PodLabels
+}
+
 // GetTimestamp ...
 func (s *StatefulSet) GetTimestamp() string {
 	return s.Timestamp
@@ -71,3 +108,58 @@ func (s *StatefulSet) GetTimestamp() string {
 func (s *StatefulSet) GetObservedGeneration() int64 {
 	return s.ObservedGeneration
 }
+
+// GetCluster ...
+func (s *StatefulSet) GetCluster() Cluster {
+	if s.Cluster == nil {
+		return Cluster{}
+	}
+	return *s.Cluster
+}
+
+// SetCluster ...
+func (s *StatefulSet) SetCluster(cluster Cluster) {
+	s.Cluster = &cluster
+}
+
+// GetReplicas ...
+func (s *StatefulSet) GetReplicas() *Replicas {
+	return s.Replicas
+}
+
+// GetStrategy ...
+func (s *StatefulSet) GetStrategy() *Strategy {
+	return s.Strategy
+}
+
+// GetConditions ...
+func (s *StatefulSet) GetConditions() []Condition {
+	return s.Conditions
+}
+
+// GetRolloutStatus reports a rollout as degraded once the replicas to update
+// were updated but some are not ready. Statefulsets have no progress deadline so
+// their rollouts are never reported as stalled.
+func (s *StatefulSet) GetRolloutStatus() RolloutStatus {
+	if s.Replicas == nil {
+		return RolloutStatus{State: RolloutUnknown}
+	}
+
+	// A partitioned rolling update only updates the ordinals above the partition
+	toUpdate := s.Replicas.Desired
+	partitioned := s.Strategy != nil && s.Strategy.Partition != nil && *s.Strategy.Partition > 0
+	if partitioned {
+		toUpdate -= *s.Strategy.Partition
+	}
+
+	if s.ObservedGeneration < s.Generation || s.Replicas.Updated < toUpdate ||
+		(!partitioned && s.UpdateRevision != "" && s.CurrentRevision != s.UpdateRevision) {
+		return RolloutStatus{State: RolloutProgressing}
+	}
+
+	if s.Replicas.Ready < s.Replicas.Desired {
+		return RolloutStatus{State: RolloutDegraded, Reason: "ReplicasNotReady"}
+	}
+
+	return RolloutStatus{State: RolloutComplete}
+}
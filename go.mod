@@ -3,11 +3,15 @@ module github.com/walmartdigital/katalog
 go 1.14
 
 require (
+	github.com/alicebob/miniredis/v2 v2.14.1
 	github.com/avast/retry-go v3.0.0+incompatible
 	github.com/emirpasic/gods v1.12.0
 	github.com/golang/mock v1.2.0
+	github.com/gomodule/redigo v1.8.3
 	github.com/gorilla/mux v1.8.0
 	github.com/imdario/mergo v0.3.11 // indirect
+	github.com/lib/pq v1.8.0
+	github.com/mattn/go-sqlite3 v1.14.4
 	github.com/maxcnunes/httpfake v1.2.1
 	github.com/mitchellh/mapstructure v1.3.3
 	github.com/onsi/ginkgo v1.8.0
@@ -16,9 +20,11 @@ require (
 	github.com/qiniu/checkstyle v0.0.0-20181122073030-e47d31cae315 // indirect
 	github.com/segmentio/kafka-go v0.4.6
 	github.com/sirupsen/logrus v1.7.0
+	go.etcd.io/bbolt v1.3.5
 	golang.org/x/net v0.0.0-20200625001655-4c5254603344
 	k8s.io/api v0.16.14
 	k8s.io/apimachinery v0.16.14
 	k8s.io/client-go v0.16.14
 	k8s.io/utils v0.0.0-20201015054608-420da100c033 // indirect
+	sigs.k8s.io/yaml v1.1.0
 )
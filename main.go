@@ -2,12 +2,17 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
+	"io"
 	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/segmentio/kafka-go"
@@ -18,7 +23,11 @@ import (
 
 	"github.com/avast/retry-go"
 	"github.com/gorilla/mux"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	k8sdriver "github.com/walmartdigital/katalog/collector/k8s-driver"
+	collectorMetrics "github.com/walmartdigital/katalog/collector/metrics"
 	"github.com/walmartdigital/katalog/collector/publishers"
 	webhookServer "github.com/walmartdigital/katalog/server/http"
 	kafkaServer "github.com/walmartdigital/katalog/server/kafka"
@@ -32,14 +41,45 @@ const roleCollector = "collector"
 const roleServer = "server"
 const publisherHTTP = "http"
 const publisherKafka = "kafka"
+const persistenceMemory = "memory"
+const persistenceBolt = "bolt"
+const persistenceSQL = "sql"
+const persistenceRedis = "redis"
 
 var role = flag.String("role", roleCollector, "collector or server")
 var httpURL = flag.String("http-url", "http://127.0.0.1:10000", "http url")
 var kafkaURL = flag.String("kafka-url", "localhost:9092", "kafka url")
 var kafkaTopicPrefix = flag.String("kafka-topic-prefix", "_katalog.artifact", "kafka topic prefix")
 var excludeSystemNamespace = flag.Bool("exclude-system-namespace", false, "exclude all services from kube-system namespace")
+var includeNamespaces = flag.String("include-namespaces", "", "comma separated namespace globs to catalog (all when empty)")
+var excludeNamespaces = flag.String("exclude-namespaces", "", "comma separated namespace globs to leave out of the catalog")
+var namespaceSelector = flag.String("namespace-selector", "", "label selector namespaces must match to be cataloged (e.g. katalog.io/catalog=enabled)")
 var publisher = flag.String("publisher", publisherHTTP, "select where to publish: kafka | http")
 var configfile = flag.Bool("kubeconfig", false, "true if a $HOME/.kube/config file exists")
+var clusterName = flag.String("cluster-name", "", "name of the watched cluster, stamped on every resource")
+var clusterRegion = flag.String("cluster-region", "", "region of the watched cluster")
+var clusterEnvironment = flag.String("cluster-environment", "", "environment of the watched cluster (e.g. production)")
+var redactDropKeys = flag.String("redact-drop-keys", "kubectl.kubernetes.io/last-applied-configuration", "comma separated label and annotation key globs dropped before publishing")
+var redactHashKeys = flag.String("redact-hash-keys", "", "comma separated label and annotation key globs whose values are hashed before publishing")
+var collectorMetricsAddress = flag.String("collector-metrics-address", ":10001", "address where the collector serves its prometheus metrics")
+var resyncPeriod = flag.Duration("resync-period", 0, "how often the collector replays its cache to repair the catalog (0 disables it)")
+var leaderElect = flag.Bool("leader-elect", false, "run collector replicas in high availability, only the lease holder watches the cluster")
+var leaseName = flag.String("leader-election-lease-name", "katalog-collector", "name of the lease used for leader election")
+var leaseNamespace = flag.String("leader-election-namespace", "", "namespace of the lease used for leader election (defaults to POD_NAMESPACE or default)")
+var leaseDuration = flag.Duration("leader-election-lease-duration", 15*time.Second, "how long followers wait before taking over a lease that is not renewed")
+var renewDeadline = flag.Duration("leader-election-renew-deadline", 10*time.Second, "how long the leader retries renewing the lease before giving it up")
+var retryPeriod = flag.Duration("leader-election-retry-period", 2*time.Second, "how often replicas try to acquire or renew the lease")
+var shutdownTimeout = flag.Duration("shutdown-timeout", 15*time.Second, "how long the server waits for requests in progress when stopping")
+var customResourcesConfig = flag.String("custom-resources-config", "", "YAML file listing the custom resources to watch and the fields to extract")
+var imagePolicyConfig = flag.String("image-policy-config", "", "YAML file with the image policy rules checked by the server")
+var endpointsDebounce = flag.Duration("endpoints-debounce", 5*time.Second, "window used to coalesce endpoint changes of a service")
+var podsDebounce = flag.Duration("pods-debounce", 5*time.Second, "window used to coalesce pod changes of a workload")
+var persistenceBackend = flag.String("persistence", persistenceMemory, "select where the server stores the catalog: memory | bolt | sql | redis")
+var boltPath = flag.String("bolt-path", "katalog.db", "file of the bolt database, reopened with its catalog on restart")
+var sqlDriver = flag.String("sql-driver", "sqlite3", "driver of the sql database: sqlite3 | postgres")
+var sqlDSN = flag.String("sql-dsn", "katalog.sqlite", "data source name of the sql database, e.g. postgres://katalog@localhost/katalog")
+var redisAddress = flag.String("redis-address", "localhost:6379", "address of the redis server sharing the catalog between server replicas")
+var redisKeyPrefix = flag.String("redis-key-prefix", "katalog", "prefix of the redis keys holding the catalog")
 
 func main() {
 	err := utils.LogInit(log)
@@ -76,6 +116,115 @@ func main() {
 		kafkaTopicPrefix = &value
 	}
 
+	if value, ok := os.LookupEnv("INCLUDE_NAMESPACES"); ok {
+		includeNamespaces = &value
+	}
+
+	if value, ok := os.LookupEnv("EXCLUDE_NAMESPACES"); ok {
+		excludeNamespaces = &value
+	}
+
+	if value, ok := os.LookupEnv("NAMESPACE_SELECTOR"); ok {
+		namespaceSelector = &value
+	}
+
+	if value, ok := os.LookupEnv("CLUSTER_NAME"); ok {
+		clusterName = &value
+	}
+
+	if value, ok := os.LookupEnv("CLUSTER_REGION"); ok {
+		clusterRegion = &value
+	}
+
+	if value, ok := os.LookupEnv("CLUSTER_ENVIRONMENT"); ok {
+		clusterEnvironment = &value
+	}
+
+	if value, ok := os.LookupEnv("REDACT_DROP_KEYS"); ok {
+		redactDropKeys = &value
+	}
+
+	if value, ok := os.LookupEnv("REDACT_HASH_KEYS"); ok {
+		redactHashKeys = &value
+	}
+
+	if value, ok := os.LookupEnv("COLLECTOR_METRICS_ADDRESS"); ok {
+		collectorMetricsAddress = &value
+	}
+
+	if value, ok := os.LookupEnv("RESYNC_PERIOD"); ok {
+		resyncPeriod = mustParseDuration(value)
+	}
+
+	if value, ok := os.LookupEnv("CUSTOM_RESOURCES_CONFIG"); ok {
+		customResourcesConfig = &value
+	}
+
+	if value, ok := os.LookupEnv("IMAGE_POLICY_CONFIG"); ok {
+		imagePolicyConfig = &value
+	}
+
+	if value, ok := os.LookupEnv("LEADER_ELECT"); ok {
+		elect := value == "true"
+		leaderElect = &elect
+	}
+
+	if value, ok := os.LookupEnv("LEADER_ELECTION_LEASE_NAME"); ok {
+		leaseName = &value
+	}
+
+	if value, ok := os.LookupEnv("LEADER_ELECTION_NAMESPACE"); ok {
+		leaseNamespace = &value
+	}
+
+	if value, ok := os.LookupEnv("LEADER_ELECTION_LEASE_DURATION"); ok {
+		leaseDuration = mustParseDuration(value)
+	}
+
+	if value, ok := os.LookupEnv("LEADER_ELECTION_RENEW_DEADLINE"); ok {
+		renewDeadline = mustParseDuration(value)
+	}
+
+	if value, ok := os.LookupEnv("LEADER_ELECTION_RETRY_PERIOD"); ok {
+		retryPeriod = mustParseDuration(value)
+	}
+
+	if value, ok := os.LookupEnv("SHUTDOWN_TIMEOUT"); ok {
+		shutdownTimeout = mustParseDuration(value)
+	}
+
+	if value, ok := os.LookupEnv("ENDPOINTS_DEBOUNCE"); ok {
+		endpointsDebounce = mustParseDuration(value)
+	}
+
+	if value, ok := os.LookupEnv("PODS_DEBOUNCE"); ok {
+		podsDebounce = mustParseDuration(value)
+	}
+
+	if value, ok := os.LookupEnv("PERSISTENCE"); ok {
+		persistenceBackend = &value
+	}
+
+	if value, ok := os.LookupEnv("BOLT_PATH"); ok {
+		boltPath = &value
+	}
+
+	if value, ok := os.LookupEnv("SQL_DRIVER"); ok {
+		sqlDriver = &value
+	}
+
+	if value, ok := os.LookupEnv("SQL_DSN"); ok {
+		sqlDSN = &value
+	}
+
+	if value, ok := os.LookupEnv("REDIS_ADDRESS"); ok {
+		redisAddress = &value
+	}
+
+	if value, ok := os.LookupEnv("REDIS_KEY_PREFIX"); ok {
+		redisKeyPrefix = &value
+	}
+
 	if *configfile {
 		kubeconfig = filepath.Join(
 			os.Getenv("HOME"), ".kube", "config",
@@ -84,87 +233,293 @@ func main() {
 		kubeconfig = ""
 	}
 
+	ctx := buildRootContext()
+
 	switch *role {
 	case roleCollector:
-		mainCollector(kubeconfig)
+		mainCollector(ctx, kubeconfig)
 	case roleServer:
 		var wg sync.WaitGroup
+		persistenceFactory := &PersistenceFactory{}
+		service := buildService(ResourceRepositoryFactory{persistenceFactory: persistenceFactory})
 		switch *publisher {
 		case publisherHTTP:
 			wg.Add(1)
-			go mainServer(&wg, true)
+			go mainServer(ctx, &wg, service, true)
 		case publisherKafka:
 			wg.Add(2)
-			go mainServer(&wg, false)
-			go mainConsumer(&wg, true)
+			go mainServer(ctx, &wg, service, false)
+			go mainConsumer(ctx, &wg, service, true)
 		default:
 			wg.Add(1)
-			go mainServer(&wg, true)
+			go mainServer(ctx, &wg, service, true)
 		}
 		wg.Wait()
+		persistenceFactory.Close()
 	default:
 		panic(errors.New("role should be server or collector"))
 	}
 }
 
-func mainCollector(kubeconfig string) {
+// buildRootContext returns a context cancelled on SIGTERM or SIGINT. A second
+// signal exits right away.
+func buildRootContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	go func() {
+		received := <-signals
+		log.Infof("received %s, shutting down...", received)
+		cancel()
+		<-signals
+		log.Fatal("received a second signal, exiting")
+	}()
+	return ctx
+}
+
+func mainCollector(ctx context.Context, kubeconfig string) {
 	log.Info("collector starting...")
-	serviceEvents := make(chan interface{})
-	deploymentEvents := make(chan interface{})
-	statefulsetEvents := make(chan interface{})
-	k8sDriver := k8sdriver.BuildDriver(kubeconfig, *excludeSystemNamespace)
-	publisher := resolvePublisher()
-	defer closeProbes()
-	go k8sDriver.StartWatchingResources(serviceEvents, domain.Resource{K8sResource: &domain.Service{}})
-	go k8sDriver.StartWatchingResources(deploymentEvents, domain.Resource{K8sResource: &domain.Deployment{}})
-	go k8sDriver.StartWatchingResources(statefulsetEvents, domain.Resource{K8sResource: &domain.StatefulSet{}})
-	for {
-		select {
-		case event := <-serviceEvents:
-			err := publisher.Publish(event)
+	events := make(chan interface{})
+	customResources := resolveCustomResources()
+	metrics := resolveCollectorMetrics()
+	k8sDriver := k8sdriver.BuildDriver(kubeconfig, resolveCluster(), *resyncPeriod, resolveNamespaceFilter(), resolveRedactionPolicy(), metrics)
+	elector := resolveLeaderElector(k8sDriver)
+	serveCollectorEndpoints(elector)
+	publisher := resolvePublisher(ctx)
+	watch := func(ctx context.Context) {
+		k8sDriver.StartWatchingNamespaces(ctx)
+		k8sDriver.StartWatchingPods(ctx, *podsDebounce)
+		for _, kind := range k8sdriver.WatchableKinds() {
+			k8sDriver.StartWatchingResources(ctx, events, domain.Resource{K8sResource: kind.New()})
+		}
+		k8sDriver.StartWatchingEndpoints(ctx, events, *endpointsDebounce)
+		for _, config := range customResources {
+			err := k8sDriver.StartWatchingCustomResources(ctx, events, config)
 			if err != nil {
-				log.Error(err)
+				log.Fatal(err)
+			}
+		}
+	}
+	electorDone := make(chan struct{})
+	if elector == nil {
+		watch(ctx)
+		close(electorDone)
+	} else {
+		go func() {
+			defer close(electorDone)
+			err := elector.Run(ctx, watch)
+			if err != nil {
+				log.Fatal(err)
 			}
-		case event := <-deploymentEvents:
+		}()
+	}
+	// Events are sent synchronously, once every watch stopped the events they
+	// handled have all been received by the loop below
+	watchesStopped := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		k8sDriver.Wait()
+		close(watchesStopped)
+	}()
+	for {
+		select {
+		case event := <-events:
 			err := publisher.Publish(event)
 			if err != nil {
 				log.Error(err)
+				k8sDriver.ForgetPublished(event.(domain.Operation))
 			}
-		case event := <-statefulsetEvents:
-			err := publisher.Publish(event)
+		case <-watchesStopped:
+			log.Info("watches stopped, closing publisher...")
+			err := publisher.Close()
 			if err != nil {
 				log.Error(err)
 			}
+			<-electorDone
+			log.Info("collector stopped")
+			return
+		}
+	}
+}
+
+func resolveNamespaceFilter() *k8sdriver.NamespaceFilter {
+	exclude := splitList(*excludeNamespaces)
+	if *excludeSystemNamespace {
+		exclude = append(exclude, "kube-system")
+	}
+
+	filter, err := k8sdriver.BuildNamespaceFilter(splitList(*includeNamespaces), exclude, *namespaceSelector)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return filter
+}
+
+func resolveCluster() domain.Cluster {
+	if *clusterName == "" && (*clusterRegion != "" || *clusterEnvironment != "") {
+		log.Fatal("a cluster region or environment requires a cluster name")
+	}
+
+	return domain.Cluster{
+		Name:        *clusterName,
+		Region:      *clusterRegion,
+		Environment: *clusterEnvironment,
+	}
+}
+
+func resolveRedactionPolicy() *k8sdriver.RedactionPolicy {
+	policy, err := k8sdriver.BuildRedactionPolicy(splitList(*redactDropKeys), splitList(*redactHashKeys))
+	if err != nil {
+		log.Fatal(err)
+	}
+	return policy
+}
+
+func resolveCustomResources() []k8sdriver.CustomResourceConfig {
+	if *customResourcesConfig == "" {
+		return nil
+	}
+
+	configs, err := k8sdriver.LoadCustomResourceConfigs(*customResourcesConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return configs
+}
+
+func resolveImagePolicy() *server.ImagePolicy {
+	if *imagePolicyConfig == "" {
+		return nil
+	}
+
+	policy, err := server.LoadImagePolicy(*imagePolicyConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return policy
+}
+
+func resolveCollectorMetrics() k8sdriver.Metrics {
+	metrics := collectorMetrics.PrometheusMetrics{}
+	metrics.InitMetrics()
+	return metrics
+}
+
+func resolveLeaderElector(k8sDriver *k8sdriver.Driver) *k8sdriver.LeaderElector {
+	if !*leaderElect {
+		return nil
+	}
+
+	identity := os.Getenv("POD_NAME")
+	if identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			log.Fatal(err)
 		}
+		identity = hostname
+	}
+
+	namespace := *leaseNamespace
+	if namespace == "" {
+		namespace = os.Getenv("POD_NAMESPACE")
 	}
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	return k8sDriver.BuildLeaderElector(k8sdriver.LeaderElectionConfig{
+		LeaseName:      *leaseName,
+		LeaseNamespace: namespace,
+		Identity:       identity,
+		LeaseDuration:  *leaseDuration,
+		RenewDeadline:  *renewDeadline,
+		RetryPeriod:    *retryPeriod,
+	})
+}
+
+// collectorHealth ...
+type collectorHealth struct {
+	LeaderElection bool
+	Leader         bool
+	Identity       string `json:",omitempty"`
+	LeaderIdentity string `json:",omitempty"`
 }
 
-var ticker *time.Ticker
-var done chan bool
+func serveCollectorEndpoints(elector *k8sdriver.LeaderElector) {
+	router := mux.NewRouter()
+	router.HandleFunc("/metrics", promhttp.Handler().ServeHTTP).Methods("GET")
+	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		health := collectorHealth{Leader: true}
+		if elector != nil {
+			health = collectorHealth{
+				LeaderElection: true,
+				Leader:         elector.IsLeader(),
+				Identity:       elector.GetIdentity(),
+				LeaderIdentity: elector.GetLeader(),
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(health)
+		if err != nil {
+			log.Error(err)
+		}
+	}).Methods("GET")
 
-func check(checkable server.Checkable) {
-	// Liveness probe
-	done = make(chan bool)
 	go func() {
-		for now := range time.Tick(30 * time.Second) {
-			if checkable.Check() {
-				log.Debug("(LIVE) Health check at " + now.Local().String())
-				_, errOpen := os.OpenFile("/tmp/imalive", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
-				if errOpen != nil {
-					log.Error("Error opening health check file", errOpen)
-				}
+		log.Fatal(http.ListenAndServe(*collectorMetricsAddress, router))
+	}()
+}
 
-				log.Debug("(TOUCH) Health check at " + now.Local().String())
-				currentTime := time.Now().Local()
-				errTouching := os.Chtimes("/tmp/imalive", currentTime, currentTime)
-				if errTouching != nil {
-					log.Fatal(errTouching.Error())
-				}
-			} else {
-				log.Debug("(DEAD) Health check at " + now.Local().String())
-				errRemove := os.Remove("/tmp/imalive")
-				if errRemove != nil {
-					log.Fatal("Error removing health check file", errRemove)
+func mustParseDuration(value string) *time.Duration {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return &duration
+}
+
+func splitList(value string) []string {
+	output := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			output = append(output, item)
+		}
+	}
+	return output
+}
+
+// check touches the liveness probe file every 30 seconds while checkable is
+// healthy, until ctx is done
+func check(ctx context.Context, checkable server.Checkable) {
+	go func() {
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				log.Info("Closing health checks")
+				return
+			case now := <-ticker.C:
+				if checkable.Check() {
+					log.Debug("(LIVE) Health check at " + now.Local().String())
+					_, errOpen := os.OpenFile("/tmp/imalive", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+					if errOpen != nil {
+						log.Error("Error opening health check file", errOpen)
+					}
+
+					log.Debug("(TOUCH) Health check at " + now.Local().String())
+					currentTime := time.Now().Local()
+					errTouching := os.Chtimes("/tmp/imalive", currentTime, currentTime)
+					if errTouching != nil {
+						log.Fatal(errTouching.Error())
+					}
+				} else {
+					log.Debug("(DEAD) Health check at " + now.Local().String())
+					errRemove := os.Remove("/tmp/imalive")
+					if errRemove != nil {
+						log.Fatal("Error removing health check file", errRemove)
+					}
 				}
 			}
 		}
@@ -183,10 +538,11 @@ func (f KafkaWriterFactory) Create(kafkaURL string, topic string) publishers.Wri
 	})
 }
 
-func resolvePublisher() publishers.Publisher {
+func resolvePublisher(ctx context.Context) publishers.Publisher {
 	var current publishers.Publisher
 	switch *publisher {
 	case publisherKafka:
+		// Not bound to ctx so the events drained on shutdown are still written
 		current = publishers.BuildKafkaPublisher(context.Background(), *kafkaURL, *kafkaTopicPrefix, KafkaWriterFactory{})
 	case publisherHTTP:
 		current = publishers.BuildHTTPPublisher(*httpURL, retry.Do)
@@ -194,32 +550,43 @@ func resolvePublisher() publishers.Publisher {
 		panic(errors.New("A publusher must be selected"))
 	}
 
-	check(current)
+	check(ctx, current)
 
 	return current
 }
 
-func closeProbes() {
-	log.Info("Closing health checks")
-	ticker.Stop()
-	done <- true
+// buildService builds the service storing the catalog in a repository of
+// repositoryFactory, shared by the http server and the kafka consumers
+func buildService(repositoryFactory repositories.RepositoryFactory) *server.Service {
+	service := server.MakeService(repositoryFactory.Create(), PrometheusMetricsFactory{})
+	service.SetImagePolicy(resolveImagePolicy())
+	return &service
 }
 
-func mainServer(wg *sync.WaitGroup, doCheck bool) {
+func mainServer(ctx context.Context, wg *sync.WaitGroup, service *server.Service, doCheck bool) {
 	defer wg.Done()
 	log.Info("http (webhook) server starting...")
-	memory := new(sync.Map)
-	persistence := persistence.BuildMemoryPersistence(memory)
-	resourceRepository := repositories.CreateResourceRepository(persistence)
 	router := mux.NewRouter().StrictSlash(true)
 	routerWrapper := &routerWrapper{router: router}
 	httpServer := &http.Server{Addr: ":10000", Handler: router}
-	webhookServer := webhookServer.CreateServer(httpServer, resourceRepository, routerWrapper, PrometheusMetricsFactory{})
+	webhookServer := webhookServer.CreateServerForService(httpServer, service, routerWrapper)
 	if doCheck {
-		check(webhookServer)
+		check(ctx, webhookServer)
 	}
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+		defer cancel()
+		err := webhookServer.Shutdown(shutdownCtx)
+		if err != nil {
+			log.Error(err)
+		}
+	}()
 	webhookServer.Run()
-	log.Info("http (webhook) server started...")
+	<-stopped
+	log.Info("http (webhook) server stopped")
 }
 
 // KafkaReaderFactory ...
@@ -243,7 +610,7 @@ func (f KafkaReaderFactory) Create(kafkaURL string, topic string) kafkaServer.Re
 
 // ResourceRepositoryFactory ...
 type ResourceRepositoryFactory struct {
-	persistenceFactory MemoryPersistenceFactory
+	persistenceFactory persistence.Factory
 }
 
 // Create ...
@@ -261,23 +628,80 @@ func (f MemoryPersistenceFactory) Create() persistence.Persistence {
 	return persistence.BuildMemoryPersistence(memory)
 }
 
-func mainConsumer(wg *sync.WaitGroup, doCheck bool) {
+// PersistenceFactory opens the persistence selected by the persistence flag,
+// keeping track of it to close it on exit
+type PersistenceFactory struct {
+	opened []persistence.Persistence
+}
+
+// Create ...
+func (f *PersistenceFactory) Create() persistence.Persistence {
+	var current persistence.Persistence
+	switch *persistenceBackend {
+	case persistenceMemory:
+		current = MemoryPersistenceFactory{}.Create()
+	case persistenceBolt, persistenceSQL, persistenceRedis:
+		current = openPersistence()
+	default:
+		panic(errors.New("persistence should be memory, bolt, sql or redis"))
+	}
+	f.opened = append(f.opened, current)
+	return current
+}
+
+// Close closes the databases opened
+func (f *PersistenceFactory) Close() {
+	for _, opened := range f.opened {
+		closer, ok := opened.(io.Closer)
+		if !ok {
+			continue
+		}
+		if err := closer.Close(); err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+func openPersistence() persistence.Persistence {
+	switch *persistenceBackend {
+	case persistenceBolt:
+		store, err := persistence.BuildBoltPersistence(*boltPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Info("bolt database " + *boltPath + " opened")
+		return store
+	case persistenceRedis:
+		store, err := persistence.BuildRedisPersistence(*redisAddress, *redisKeyPrefix)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Info("redis " + *redisAddress + " connected")
+		return store
+	}
+
+	store, err := persistence.BuildSQLPersistence(*sqlDriver, *sqlDSN)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Info(*sqlDriver + " database opened")
+	return store
+}
+
+func mainConsumer(ctx context.Context, wg *sync.WaitGroup, service *server.Service, doCheck bool) {
 	consumerWg := new(sync.WaitGroup)
 
 	defer wg.Done()
 
 	log.Info("kafka consumer starting...")
-	memFactory := MemoryPersistenceFactory{}
-	service := server.MakeService(ResourceRepositoryFactory{persistenceFactory: memFactory}.Create(), PrometheusMetricsFactory{})
-
-	created := kafkaServer.CreateConsumer(context.Background(), consumerWg, *kafkaURL, *kafkaTopicPrefix, "created", KafkaReaderFactory{}, &service)
-	updated := kafkaServer.CreateConsumer(context.Background(), consumerWg, *kafkaURL, *kafkaTopicPrefix, "updated", KafkaReaderFactory{}, &service)
-	deleted := kafkaServer.CreateConsumer(context.Background(), consumerWg, *kafkaURL, *kafkaTopicPrefix, "deleted", KafkaReaderFactory{}, &service)
+	created := kafkaServer.CreateConsumer(ctx, consumerWg, *kafkaURL, *kafkaTopicPrefix, "created", KafkaReaderFactory{}, service)
+	updated := kafkaServer.CreateConsumer(ctx, consumerWg, *kafkaURL, *kafkaTopicPrefix, "updated", KafkaReaderFactory{}, service)
+	deleted := kafkaServer.CreateConsumer(ctx, consumerWg, *kafkaURL, *kafkaTopicPrefix, "deleted", KafkaReaderFactory{}, service)
 
 	if doCheck {
-		check(created)
-		check(updated)
-		check(deleted)
+		check(ctx, created)
+		check(ctx, updated)
+		check(ctx, deleted)
 	}
 
 	consumerWg.Add(3)
@@ -286,6 +710,7 @@ func mainConsumer(wg *sync.WaitGroup, doCheck bool) {
 	go deleted.Run()
 	log.Info("kafka consumers started...")
 	consumerWg.Wait()
+	log.Info("kafka consumers stopped")
 }
 
 type routerWrapper struct {
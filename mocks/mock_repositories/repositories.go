@@ -9,6 +9,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	domain "github.com/walmartdigital/katalog/domain"
+	persistence "github.com/walmartdigital/katalog/server/persistence"
 	repositories "github.com/walmartdigital/katalog/server/repositories"
 )
 
@@ -93,6 +94,21 @@ func (mr *MockRepositoryMockRecorder) GetAllResources() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllResources", reflect.TypeOf((*MockRepository)(nil).GetAllResources))
 }
 
+// GetResourcesBy mocks base method
+func (m *MockRepository) GetResourcesBy(filter persistence.Filter) ([]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourcesBy", filter)
+	ret0, _ := ret[0].([]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourcesBy indicates an expected call of GetResourcesBy
+func (mr *MockRepositoryMockRecorder) GetResourcesBy(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourcesBy", reflect.TypeOf((*MockRepository)(nil).GetResourcesBy), filter)
+}
+
 // GetResource mocks base method
 func (m *MockRepository) GetResource(id string) (interface{}, error) {
 	m.ctrl.T.Helper()
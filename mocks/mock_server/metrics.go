@@ -62,6 +62,40 @@ func (mr *MockMetricsMockRecorder) IncrementCounter(arg0 interface{}, arg1 ...in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementCounter", reflect.TypeOf((*MockMetrics)(nil).IncrementCounter), varargs...)
 }
 
+// SetGauge mocks base method
+func (m *MockMetrics) SetGauge(arg0 string, arg1 float64, arg2 ...string) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "SetGauge", varargs...)
+}
+
+// SetGauge indicates an expected call of SetGauge
+func (mr *MockMetricsMockRecorder) SetGauge(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGauge", reflect.TypeOf((*MockMetrics)(nil).SetGauge), varargs...)
+}
+
+// DeleteGauge mocks base method
+func (m *MockMetrics) DeleteGauge(arg0 string, arg1 ...string) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "DeleteGauge", varargs...)
+}
+
+// DeleteGauge indicates an expected call of DeleteGauge
+func (mr *MockMetricsMockRecorder) DeleteGauge(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGauge", reflect.TypeOf((*MockMetrics)(nil).DeleteGauge), varargs...)
+}
+
 // DestroyMetrics mocks base method
 func (m *MockMetrics) DestroyMetrics() {
 	m.ctrl.T.Helper()
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	"github.com/walmartdigital/katalog/domain"
+)
+
+// ServiceBackends sums up the instances behind a service, how many are ready
+// to receive traffic and the zones they run in
+type ServiceBackends struct {
+	ID        string
+	Name      string
+	Namespace string
+	Cluster   string `json:",omitempty"`
+	Instances int
+	Ready     int
+	Zones     []string
+}
+
+// getServiceBackends sums up the backends of every service, restricted by
+// cluster and namespace when they are not empty
+func (s *Server) getServiceBackends(cluster string, namespace string) ([]ServiceBackends, error) {
+	resources, err := s.resourcesRepository.GetAllResources()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]ServiceBackends, 0)
+	for _, r := range resources {
+		res := r.(domain.Resource)
+		service, ok := res.K8sResource.(*domain.Service)
+		if !ok || (cluster != "" && res.GetCluster().Name != cluster) || (namespace != "" && res.GetNamespace() != namespace) {
+			continue
+		}
+
+		zones := service.GetInstanceZones()
+		sort.Strings(zones)
+		list = append(list, ServiceBackends{
+			ID:        service.GetID(),
+			Name:      service.GetName(),
+			Namespace: service.GetNamespace(),
+			Cluster:   service.GetCluster().Name,
+			Instances: len(service.Instances),
+			Ready:     service.CountReadyInstances(),
+			Zones:     zones,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Namespace != list[j].Namespace {
+			return list[i].Namespace < list[j].Namespace
+		}
+		return list[i].Name < list[j].Name
+	})
+
+	return list, nil
+}
+
+func (s *Server) getAllServiceBackends(w http.ResponseWriter, r *http.Request) {
+	backends, err := s.getServiceBackends(queryParam(r, "cluster"), queryParam(r, "namespace"))
+	if err != nil {
+		fmt.Fprint(w, "Resource not found")
+		log.Error("Resource not found")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	errEncoding := json.NewEncoder(w).Encode(backends)
+	if errEncoding != nil {
+		log.WithFields(logrus.Fields{
+			"msg": errEncoding.Error(),
+		}).Error("Getting service backends")
+	}
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	"github.com/walmartdigital/katalog/domain"
+)
+
+// ClusterSummary ...
+type ClusterSummary struct {
+	Name        string
+	Region      string `json:",omitempty"`
+	Environment string `json:",omitempty"`
+	Count       int
+	Resources   map[string]int
+	LastEvent   string
+}
+
+// getClusterSummaries groups the cataloged resources by cluster. The last
+// event is the newest collector timestamp among the cluster resources.
+func (s *Server) getClusterSummaries() ([]ClusterSummary, error) {
+	resources, err := s.resourcesRepository.GetAllResources()
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make(map[string]*ClusterSummary)
+	for _, r := range resources {
+		res := r.(domain.Resource)
+		cluster := res.GetCluster()
+		if cluster.Name == "" {
+			continue
+		}
+
+		summary, ok := summaries[cluster.Name]
+		if !ok {
+			summary = &ClusterSummary{
+				Name:        cluster.Name,
+				Region:      cluster.Region,
+				Environment: cluster.Environment,
+				Resources:   make(map[string]int),
+			}
+			summaries[cluster.Name] = summary
+		}
+
+		summary.Count++
+		summary.Resources[res.GetType().Elem().Name()]++
+		if res.GetTimestamp() > summary.LastEvent {
+			summary.LastEvent = res.GetTimestamp()
+		}
+	}
+
+	list := make([]ClusterSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		list = append(list, *summary)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list, nil
+}
+
+func (s *Server) getAllClusters(w http.ResponseWriter, r *http.Request) {
+	clusters, err := s.getClusterSummaries()
+	if err != nil {
+		fmt.Fprint(w, "Resource not found")
+		log.Error("Resource not found")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	errEncoding := json.NewEncoder(w).Encode(clusters)
+	if errEncoding != nil {
+		log.WithFields(logrus.Fields{
+			"msg": errEncoding.Error(),
+		}).Error("Getting all clusters")
+	}
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	"github.com/walmartdigital/katalog/domain"
+)
+
+// DriftedWorkload ...
+type DriftedWorkload struct {
+	Kind      string
+	ID        string
+	Name      string
+	Namespace string
+	Cluster   string `json:",omitempty"`
+	Drifts    []domain.ImageDrift
+}
+
+// getDriftedWorkloads lists the workloads whose pods run mixed digests of a
+// container or a digest other than the one pinned by the template, restricted
+// to one cluster when cluster is not empty
+func (s *Server) getDriftedWorkloads(cluster string) ([]DriftedWorkload, error) {
+	resources, err := s.resourcesRepository.GetAllResources()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]DriftedWorkload, 0)
+	for _, r := range resources {
+		res := r.(domain.Resource)
+		workload, ok := res.K8sResource.(domain.PodRunner)
+		if !ok || (cluster != "" && res.GetCluster().Name != cluster) {
+			continue
+		}
+
+		drifts := domain.GetImageDrifts(workload)
+		if len(drifts) == 0 {
+			continue
+		}
+
+		list = append(list, DriftedWorkload{
+			Kind:      res.GetType().Elem().Name(),
+			ID:        res.GetID(),
+			Name:      res.GetName(),
+			Namespace: res.GetNamespace(),
+			Cluster:   res.GetCluster().Name,
+			Drifts:    drifts,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Namespace != list[j].Namespace {
+			return list[i].Namespace < list[j].Namespace
+		}
+		return list[i].Name < list[j].Name
+	})
+
+	return list, nil
+}
+
+func (s *Server) getAllDriftedWorkloads(w http.ResponseWriter, r *http.Request) {
+	workloads, err := s.getDriftedWorkloads(queryParam(r, "cluster"))
+	if err != nil {
+		fmt.Fprint(w, "Resource not found")
+		log.Error("Resource not found")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	errEncoding := json.NewEncoder(w).Encode(workloads)
+	if errEncoding != nil {
+		log.WithFields(logrus.Fields{
+			"msg": errEncoding.Error(),
+		}).Error("Getting drifted workloads")
+	}
+}
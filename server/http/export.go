@@ -0,0 +1,289 @@
+package http
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/walmartdigital/katalog/domain"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Export formats of the catalog graph
+const (
+	FormatDOT     = "dot"
+	FormatGraphML = "graphml"
+	FormatJSON    = "json"
+)
+
+const defaultTeamLabel = "team"
+
+// ExportNode is a resource of an exported graph, identified by its key
+type ExportNode struct {
+	ID        string   `json:"id"`
+	Kind      string   `json:"kind"`
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace,omitempty"`
+	Cluster   string   `json:"cluster,omitempty"`
+	Images    []string `json:"images,omitempty"`
+	Team      string   `json:"team,omitempty"`
+}
+
+// ExportEdge links two nodes of an exported graph, e.g. an owner to its child
+type ExportEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Type   string `json:"type"`
+}
+
+// ExportGraph is the JSON nodes and edges format of the exported graph
+type ExportGraph struct {
+	Nodes []ExportNode `json:"nodes"`
+	Edges []ExportEdge `json:"edges"`
+}
+
+// graphScope restricts the exported subgraph to a cluster, a namespace and
+// the resources matching a label selector, when they are set
+type graphScope struct {
+	cluster   string
+	namespace string
+	selector  labels.Selector
+}
+
+func (s graphScope) includes(res domain.Resource) bool {
+	return (s.cluster == "" || res.GetCluster().Name == s.cluster) &&
+		(s.namespace == "" || res.GetNamespace() == s.namespace) &&
+		s.selector.Matches(labels.Set(res.GetLabels()))
+}
+
+// export returns the subgraph of the resources in scope, with the edges
+// between them, sorted by key
+func (g *catalogGraph) export(scope graphScope, teamLabel string) ExportGraph {
+	graph := ExportGraph{Nodes: make([]ExportNode, 0), Edges: make([]ExportEdge, 0)}
+
+	included := make(map[string]bool)
+	for key, res := range g.resources {
+		if scope.includes(res) {
+			included[key] = true
+		}
+	}
+
+	keys := make([]string, 0, len(included))
+	for key := range included {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		res := g.resources[key]
+		node := nodeOf(res)
+		exported := ExportNode{
+			ID:        key,
+			Kind:      node.Kind,
+			Name:      node.Name,
+			Namespace: node.Namespace,
+			Cluster:   node.Cluster,
+			Team:      res.GetLabels()[teamLabel],
+		}
+		if workload, ok := res.K8sResource.(domain.Workload); ok {
+			exported.Images = imagesOf(workload)
+		}
+		graph.Nodes = append(graph.Nodes, exported)
+
+		for _, edges := range []struct {
+			links    map[string][]string
+			edgeType string
+		}{{g.children, EdgeOwns}, {g.selects, EdgeSelects}} {
+			targets := append([]string{}, edges.links[key]...)
+			sort.Strings(targets)
+			for _, target := range targets {
+				if included[target] {
+					graph.Edges = append(graph.Edges, ExportEdge{Source: key, Target: target, Type: edges.edgeType})
+				}
+			}
+		}
+	}
+
+	return graph
+}
+
+// imagesOf returns the distinct images of the workload, sorted
+func imagesOf(workload domain.Workload) []string {
+	images := make([]string, 0)
+	for _, image := range domain.ImagesOf(workload) {
+		if !containsString(images, image) {
+			images = append(images, image)
+		}
+	}
+	sort.Strings(images)
+	return images
+}
+
+func writeDOT(w io.Writer, graph ExportGraph) error {
+	quote := func(value string) string {
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+	}
+
+	lines := []string{"digraph katalog {"}
+	for _, node := range graph.Nodes {
+		attributes := []string{
+			"label=" + quote(node.Kind+"\n"+node.Namespace+"/"+node.Name),
+			"kind=" + quote(node.Kind),
+			"namespace=" + quote(node.Namespace),
+		}
+		if node.Cluster != "" {
+			attributes = append(attributes, "cluster="+quote(node.Cluster))
+		}
+		if len(node.Images) > 0 {
+			attributes = append(attributes, "images="+quote(strings.Join(node.Images, ",")))
+		}
+		if node.Team != "" {
+			attributes = append(attributes, "team="+quote(node.Team))
+		}
+		lines = append(lines, fmt.Sprintf("  %s [%s];", quote(node.ID), strings.Join(attributes, ", ")))
+	}
+	for _, edge := range graph.Edges {
+		lines = append(lines, fmt.Sprintf("  %s -> %s [type=%s];", quote(edge.Source), quote(edge.Target), quote(edge.Type)))
+	}
+	lines = append(lines, "}")
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+func writeGraphML(w io.Writer, graph ExportGraph) error {
+	document := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Graph: graphMLGraph{ID: "katalog", EdgeDefault: "directed"},
+	}
+	for _, name := range []string{"kind", "name", "namespace", "cluster", "images", "team"} {
+		document.Keys = append(document.Keys, graphMLKey{ID: name, For: "node", AttrName: name, AttrType: "string"})
+	}
+	document.Keys = append(document.Keys, graphMLKey{ID: "type", For: "edge", AttrName: "type", AttrType: "string"})
+
+	for _, node := range graph.Nodes {
+		data := []graphMLData{{"kind", node.Kind}, {"name", node.Name}, {"namespace", node.Namespace}}
+		if node.Cluster != "" {
+			data = append(data, graphMLData{"cluster", node.Cluster})
+		}
+		if len(node.Images) > 0 {
+			data = append(data, graphMLData{"images", strings.Join(node.Images, ",")})
+		}
+		if node.Team != "" {
+			data = append(data, graphMLData{"team", node.Team})
+		}
+		document.Graph.Nodes = append(document.Graph.Nodes, graphMLNode{ID: node.ID, Data: data})
+	}
+	for _, edge := range graph.Edges {
+		document.Graph.Edges = append(document.Graph.Edges, graphMLEdge{
+			Source: edge.Source,
+			Target: edge.Target,
+			Data:   []graphMLData{{"type", edge.Type}},
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return encoder.Encode(document)
+}
+
+// exportGraph renders the subgraph scoped by the cluster, namespace and
+// selector query parameters in the requested format, JSON by default
+func (s *Server) exportGraph(w http.ResponseWriter, r *http.Request) {
+	selector, err := labels.Parse(queryParam(r, "selector"))
+	if err != nil {
+		writeQueryError(w, invalidParameterError{"selector", err})
+		return
+	}
+
+	format := queryParam(r, "format")
+	if format == "" {
+		format = FormatJSON
+	}
+	if format != FormatDOT && format != FormatGraphML && format != FormatJSON {
+		writeQueryError(w, invalidParameterError{"format", fmt.Errorf("unknown format %s, expected %s, %s or %s", format, FormatDOT, FormatGraphML, FormatJSON)})
+		return
+	}
+
+	teamLabel := queryParam(r, "teamLabel")
+	if teamLabel == "" {
+		teamLabel = defaultTeamLabel
+	}
+
+	graph, err := s.getCatalogGraph()
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"msg": err.Error(),
+		}).Error("Exporting graph")
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, "Resource not found")
+		return
+	}
+	exported := graph.export(graphScope{
+		cluster:   queryParam(r, "cluster"),
+		namespace: queryParam(r, "namespace"),
+		selector:  selector,
+	}, teamLabel)
+
+	switch format {
+	case FormatDOT:
+		w.Header().Set("Content-Type", "text/vnd.graphviz")
+		err = writeDOT(w, exported)
+	case FormatGraphML:
+		w.Header().Set("Content-Type", "application/graphml+xml")
+		err = writeGraphML(w, exported)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(exported)
+	}
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"msg": err.Error(),
+		}).Errorf("Exporting graph as %s", format)
+	}
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	"github.com/walmartdigital/katalog/domain"
+)
+
+// ExposedService is a service reachable from outside the cluster with the
+// addresses and ports it is reachable at
+type ExposedService struct {
+	ID                  string
+	Name                string
+	Namespace           string
+	Cluster             string `json:",omitempty"`
+	Type                string
+	Ports               []domain.ServicePort
+	ExternalIPs         []string `json:",omitempty"`
+	LoadBalancerIngress []string `json:",omitempty"`
+}
+
+// getExposedServices lists the node port and load balancer services and the
+// services with external IPs, restricted to one cluster when cluster is not
+// empty
+func (s *Server) getExposedServices(cluster string) ([]ExposedService, error) {
+	resources, err := s.resourcesRepository.GetAllResources()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]ExposedService, 0)
+	for _, r := range resources {
+		res := r.(domain.Resource)
+		service, ok := res.K8sResource.(*domain.Service)
+		if !ok || !service.IsExposed() || (cluster != "" && res.GetCluster().Name != cluster) {
+			continue
+		}
+
+		list = append(list, ExposedService{
+			ID:                  service.GetID(),
+			Name:                service.GetName(),
+			Namespace:           service.GetNamespace(),
+			Cluster:             service.GetCluster().Name,
+			Type:                service.GetServiceType(),
+			Ports:               service.GetPorts(),
+			ExternalIPs:         service.GetExternalIPs(),
+			LoadBalancerIngress: service.GetLoadBalancerIngress(),
+		})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Namespace != list[j].Namespace {
+			return list[i].Namespace < list[j].Namespace
+		}
+		return list[i].Name < list[j].Name
+	})
+
+	return list, nil
+}
+
+func (s *Server) getAllExposedServices(w http.ResponseWriter, r *http.Request) {
+	services, err := s.getExposedServices(queryParam(r, "cluster"))
+	if err != nil {
+		fmt.Fprint(w, "Resource not found")
+		log.Error("Resource not found")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	errEncoding := json.NewEncoder(w).Encode(services)
+	if errEncoding != nil {
+		log.WithFields(logrus.Fields{
+			"msg": errEncoding.Error(),
+		}).Error("Getting exposed services")
+	}
+}
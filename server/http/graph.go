@@ -0,0 +1,254 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	"github.com/walmartdigital/katalog/domain"
+)
+
+// Edge types of the catalog graph
+const (
+	EdgeOwns    = "owns"
+	EdgeSelects = "selects"
+)
+
+// GraphNode ...
+type GraphNode struct {
+	Kind      string
+	ID        string
+	Name      string
+	Namespace string `json:",omitempty"`
+	Cluster   string `json:",omitempty"`
+}
+
+// Topology is a resource with its owners upstream, its children downstream,
+// closest first, and the workloads it selects or the services selecting it
+type Topology struct {
+	Resource   GraphNode
+	Owners     []GraphNode
+	Children   []GraphNode
+	Selects    []GraphNode
+	SelectedBy []GraphNode
+}
+
+// Orphans are the services selecting no workload and the long running
+// workloads no service selects
+type Orphans struct {
+	Services  []GraphNode
+	Workloads []GraphNode
+}
+
+// orphanKinds are the workloads expected to be selected by a service, batch
+// workloads rarely are
+var orphanKinds = map[string]bool{"Deployment": true, "StatefulSet": true, "DaemonSet": true}
+
+// catalogGraph links the cataloged resources by key, owners to their
+// children through owner references and services to the workloads whose pod
+// template they select
+type catalogGraph struct {
+	resources  map[string]domain.Resource
+	children   map[string][]string
+	owners     map[string][]string
+	selects    map[string][]string
+	selectedBy map[string][]string
+}
+
+func buildCatalogGraph(resources []interface{}) *catalogGraph {
+	graph := &catalogGraph{
+		resources:  make(map[string]domain.Resource),
+		children:   make(map[string][]string),
+		owners:     make(map[string][]string),
+		selects:    make(map[string][]string),
+		selectedBy: make(map[string][]string),
+	}
+
+	for _, r := range resources {
+		res := r.(domain.Resource)
+		graph.resources[res.GetKey()] = res
+	}
+
+	for key, res := range graph.resources {
+		if owned, ok := res.K8sResource.(domain.Owned); ok {
+			for _, reference := range owned.GetOwnerReferences() {
+				owner := domain.BuildResourceKey(res.GetCluster().Name, reference.UID)
+				if _, ok := graph.resources[owner]; ok {
+					graph.children[owner] = append(graph.children[owner], key)
+					graph.owners[key] = append(graph.owners[key], owner)
+				}
+			}
+		}
+
+		selecting, ok := res.K8sResource.(domain.Selecting)
+		if !ok {
+			continue
+		}
+		for workloadKey, workload := range graph.resources {
+			podTemplated, ok := workload.K8sResource.(domain.Workload)
+			if !ok || workload.GetNamespace() != res.GetNamespace() || workload.GetCluster().Name != res.GetCluster().Name {
+				continue
+			}
+			if domain.Selects(selecting.GetSelector(), podTemplated.GetPodLabels()) {
+				graph.selects[key] = append(graph.selects[key], workloadKey)
+				graph.selectedBy[workloadKey] = append(graph.selectedBy[workloadKey], key)
+			}
+		}
+	}
+
+	return graph
+}
+
+// walk returns the resources reachable from key through the links, closest
+// first
+func (g *catalogGraph) walk(key string, links map[string][]string) []GraphNode {
+	visited := map[string]bool{key: true}
+	queue := []string{key}
+	nodes := make([]GraphNode, 0)
+	for len(queue) > 0 {
+		next := g.sortedKeys(links[queue[0]])
+		queue = queue[1:]
+		for _, linked := range next {
+			if visited[linked] {
+				continue
+			}
+			visited[linked] = true
+			queue = append(queue, linked)
+			nodes = append(nodes, g.node(linked))
+		}
+	}
+	return nodes
+}
+
+func (g *catalogGraph) nodes(keys []string) []GraphNode {
+	nodes := make([]GraphNode, 0, len(keys))
+	for _, key := range g.sortedKeys(keys) {
+		nodes = append(nodes, g.node(key))
+	}
+	return nodes
+}
+
+// sortedKeys sorts keys by namespace, name and kind of their resource
+func (g *catalogGraph) sortedKeys(keys []string) []string {
+	sorted := append([]string{}, keys...)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := g.node(sorted[i]), g.node(sorted[j])
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Kind < b.Kind
+	})
+	return sorted
+}
+
+func (g *catalogGraph) node(key string) GraphNode {
+	return nodeOf(g.resources[key])
+}
+
+// nodeOf names custom resources by their own kind, e.g. Rollout
+func nodeOf(res domain.Resource) GraphNode {
+	kind := res.GetType().Elem().Name()
+	if customResource, ok := res.K8sResource.(*domain.CustomResource); ok {
+		kind = customResource.GetKind()
+	}
+	return GraphNode{
+		Kind:      kind,
+		ID:        res.GetID(),
+		Name:      res.GetName(),
+		Namespace: res.GetNamespace(),
+		Cluster:   res.GetCluster().Name,
+	}
+}
+
+func (g *catalogGraph) topology(key string) Topology {
+	return Topology{
+		Resource:   g.node(key),
+		Owners:     g.walk(key, g.owners),
+		Children:   g.walk(key, g.children),
+		Selects:    g.nodes(g.selects[key]),
+		SelectedBy: g.nodes(g.selectedBy[key]),
+	}
+}
+
+// orphans lists the orphans, restricted by cluster and namespace when they
+// are not empty
+func (g *catalogGraph) orphans(cluster string, namespace string) Orphans {
+	var services, workloads []string
+	for key, res := range g.resources {
+		if (cluster != "" && res.GetCluster().Name != cluster) || (namespace != "" && res.GetNamespace() != namespace) {
+			continue
+		}
+
+		if selecting, ok := res.K8sResource.(domain.Selecting); ok && len(selecting.GetSelector()) > 0 && len(g.selects[key]) == 0 {
+			services = append(services, key)
+		}
+		if orphanKinds[res.GetType().Elem().Name()] && len(g.selectedBy[key]) == 0 {
+			workloads = append(workloads, key)
+		}
+	}
+
+	return Orphans{Services: g.nodes(services), Workloads: g.nodes(workloads)}
+}
+
+func (s *Server) getCatalogGraph() (*catalogGraph, error) {
+	resources, err := s.resourcesRepository.GetAllResources()
+	if err != nil {
+		return nil, err
+	}
+	return buildCatalogGraph(resources), nil
+}
+
+func (s *Server) getTopology(w http.ResponseWriter, r *http.Request) {
+	vars := routeVars(r)
+	kind, ok := domain.KindBySegment(vars["kind"])
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, "Kind %s not found", vars["kind"])
+		return
+	}
+
+	graph, err := s.getCatalogGraph()
+	if err != nil {
+		fmt.Fprint(w, "Resource not found")
+		log.Error("Resource not found")
+		return
+	}
+
+	key := domain.BuildResourceKey(queryParam(r, "cluster"), vars["id"])
+	res, ok := graph.resources[key]
+	if !ok || res.GetType() != kind.Type() {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, "%s %s not found", kind.Label, vars["id"])
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	errEncoding := json.NewEncoder(w).Encode(graph.topology(key))
+	if errEncoding != nil {
+		log.WithFields(logrus.Fields{
+			"msg": errEncoding.Error(),
+		}).Error("Getting topology")
+	}
+}
+
+func (s *Server) getOrphans(w http.ResponseWriter, r *http.Request) {
+	graph, err := s.getCatalogGraph()
+	if err != nil {
+		fmt.Fprint(w, "Resource not found")
+		log.Error("Resource not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	errEncoding := json.NewEncoder(w).Encode(graph.orphans(queryParam(r, "cluster"), queryParam(r, "namespace")))
+	if errEncoding != nil {
+		log.WithFields(logrus.Fields{
+			"msg": errEncoding.Error(),
+		}).Error("Getting orphans")
+	}
+}
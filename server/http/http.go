@@ -1,10 +1,12 @@
 package http
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
+	"github.com/walmartdigital/katalog/domain"
 	"github.com/walmartdigital/katalog/server"
 	"github.com/walmartdigital/katalog/server/repositories"
 	"github.com/walmartdigital/katalog/utils"
@@ -22,6 +24,7 @@ func init() {
 // WebhookServer ...
 type WebhookServer interface {
 	ListenAndServe() error
+	Shutdown(ctx context.Context) error
 }
 
 // Server ...
@@ -29,7 +32,7 @@ type Server struct {
 	httpServer          WebhookServer
 	resourcesRepository repositories.Repository
 	router              Router
-	service             server.Service
+	service             *server.Service
 }
 
 // Check ...
@@ -49,15 +52,19 @@ type Route interface {
 
 // CreateServer ...
 func CreateServer(webhook WebhookServer, repository repositories.Repository, router Router, mfactory server.MetricsFactory) *Server {
-	current := &Server{
+	service := server.MakeService(repository, mfactory)
+	return CreateServerForService(webhook, &service, router)
+}
+
+// CreateServerForService creates a server reading and writing the catalog
+// through service, shared with the kafka consumers so both serve one catalog
+func CreateServerForService(webhook WebhookServer, service *server.Service, router Router) *Server {
+	return &Server{
 		httpServer:          webhook,
-		resourcesRepository: repository,
+		resourcesRepository: service.GetRepository(),
 		router:              router,
+		service:             service,
 	}
-
-	current.service = server.MakeService(current.resourcesRepository, mfactory)
-
-	return current
 }
 
 // Run ...
@@ -67,24 +74,34 @@ func (s *Server) Run() {
 
 func (s *Server) handleRequests() {
 	s.router.HandleFunc("/metrics", promhttp.Handler().ServeHTTP).Methods("GET")
-	s.router.HandleFunc("/services", s.getAllServices).Methods("GET")
-	s.router.HandleFunc("/services/_count", s.countServices).Methods("GET")
-	s.router.HandleFunc("/services/{id}", s.CreateService).Methods("POST")
-	s.router.HandleFunc("/services/{id}", s.UpdateService).Methods("PUT")
-	s.router.HandleFunc("/services/{id}", s.DeleteService).Methods("DELETE")
-	s.router.HandleFunc("/deployments", s.getAllDeployments).Methods("GET")
-	s.router.HandleFunc("/deployments/_count", s.countDeployments).Methods("GET")
-	s.router.HandleFunc("/deployments/{id}", s.CreateDeployment).Methods("POST")
-	s.router.HandleFunc("/deployments/{id}", s.UpdateDeployment).Methods("PUT")
-	s.router.HandleFunc("/deployments/{id}", s.DeleteDeployment).Methods("DELETE")
-	s.router.HandleFunc("/statefulsets", s.getAllStatefulSets).Methods("GET")
-	s.router.HandleFunc("/statefulsets/_count", s.countStatefulSets).Methods("GET")
-	s.router.HandleFunc("/statefulsets/{id}", s.CreateStatefulSet).Methods("POST")
-	s.router.HandleFunc("/statefulsets/{id}", s.UpdateStatefulSet).Methods("PUT")
-	s.router.HandleFunc("/statefulsets/{id}", s.DeleteStatefulSet).Methods("DELETE")
+	s.router.HandleFunc("/clusters", s.getAllClusters).Methods("GET")
+	s.router.HandleFunc("/services/_exposed", s.getAllExposedServices).Methods("GET")
+	s.router.HandleFunc("/services/_backends", s.getAllServiceBackends).Methods("GET")
+	s.router.HandleFunc("/workloads/_degraded", s.getAllDegradedWorkloads).Methods("GET")
+	s.router.HandleFunc("/workloads/_drifted", s.getAllDriftedWorkloads).Methods("GET")
+	s.router.HandleFunc("/images", s.getAllImages).Methods("GET")
+	s.router.HandleFunc("/images/_registries", s.getAllRegistries).Methods("GET")
+	s.router.HandleFunc("/violations", s.getAllViolations).Methods("GET")
+	s.router.HandleFunc("/graph/_orphans", s.getOrphans).Methods("GET")
+	s.router.HandleFunc("/graph/{kind}/{id}", s.getTopology).Methods("GET")
+	s.router.HandleFunc("/export/graph", s.exportGraph).Methods("GET")
+	for _, kind := range domain.Kinds() {
+		path := kind.Path()
+		s.router.HandleFunc(path, s.listResources(kind)).Methods("GET")
+		s.router.HandleFunc(path+"/_count", s.countResources(kind)).Methods("GET")
+		s.router.HandleFunc(path+"/{id}", s.createResource(kind)).Methods("POST")
+		s.router.HandleFunc(path+"/{id}", s.updateResource(kind)).Methods("PUT")
+		s.router.HandleFunc(path+"/{id}", s.deleteResource(kind)).Methods("DELETE")
+	}
 
 	err := s.httpServer.ListenAndServe()
-	if err != nil {
+	if err != nil && err != http.ErrServerClosed {
 		log.Fatal(err)
 	}
 }
+
+// Shutdown stops accepting requests and waits for the ones in progress until
+// ctx is done
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
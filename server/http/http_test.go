@@ -2,12 +2,15 @@ package http_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
 	"reflect"
 	"testing"
 
@@ -19,13 +22,16 @@ import (
 
 	"github.com/walmartdigital/katalog/domain"
 	"github.com/walmartdigital/katalog/mocks/mock_server"
+	"github.com/walmartdigital/katalog/server"
 	webhookServer "github.com/walmartdigital/katalog/server/http"
+	katalogPersistence "github.com/walmartdigital/katalog/server/persistence"
 	"github.com/walmartdigital/katalog/utils"
 )
 
 type fakeRepository struct {
 	persistence map[string]interface{}
 	fail        bool
+	filters     []katalogPersistence.Filter
 }
 
 func (r *fakeRepository) CreateResource(obj interface{}) error {
@@ -34,14 +40,14 @@ func (r *fakeRepository) CreateResource(obj interface{}) error {
 	if resource.GetID() == "" {
 		return errors.New("")
 	}
-	r.persistence[resource.GetID()] = resource
+	r.persistence[resource.GetKey()] = resource
 
 	return nil
 }
 
 func (r *fakeRepository) UpdateResource(resource interface{}) (*domain.Resource, error) {
 	res := resource.(domain.Resource)
-	savedResource, ok := r.persistence[res.GetID()]
+	savedResource, ok := r.persistence[res.GetKey()]
 	if !ok {
 		return nil, errors.New("ID does not exist")
 	}
@@ -51,7 +57,7 @@ func (r *fakeRepository) UpdateResource(resource interface{}) (*domain.Resource,
 	sr := savedResource.(domain.Resource)
 	if &sr != nil {
 		if sr.GetGeneration() < res.GetGeneration() {
-			r.persistence[res.GetID()] = res
+			r.persistence[res.GetKey()] = res
 			return &res, nil
 		}
 	}
@@ -81,6 +87,21 @@ func (r *fakeRepository) GetAllResources() ([]interface{}, error) {
 	return resources.Values(), nil
 }
 
+func (r *fakeRepository) GetResourcesBy(filter katalogPersistence.Filter) ([]interface{}, error) {
+	r.filters = append(r.filters, filter)
+	resources, err := r.GetAllResources()
+	if err != nil {
+		return nil, err
+	}
+	list := arraylist.New()
+	for _, resource := range resources {
+		if res, ok := resource.(domain.Resource); ok && filter.Matches(res) {
+			list.Add(resource)
+		}
+	}
+	return list.Values(), nil
+}
+
 func (r *fakeRepository) GetResource(id string) (interface{}, error) {
 	resource, ok := r.persistence[id]
 	if !ok {
@@ -113,8 +134,22 @@ func (s *fakeHTTPServer) ListenAndServe() error {
 	return nil
 }
 
+func (s *fakeHTTPServer) Shutdown(ctx context.Context) error {
+	return nil
+}
+
 var ctrl *gomock.Controller
 
+// serveWithImagePolicy registers the routes again for a service checking the
+// images against the policy, the way main configures it
+func serveWithImagePolicy(policy *server.ImagePolicy, repository *fakeRepository, router *fakeRouter, httpServer *fakeHTTPServer, metrics server.Metrics) {
+	fakeMetricsFactory := mock_server.NewMockMetricsFactory(ctrl)
+	fakeMetricsFactory.EXPECT().Create().Return(metrics).Times(1)
+	service := server.MakeService(repository, fakeMetricsFactory)
+	service.SetImagePolicy(policy)
+	webhookServer.CreateServerForService(httpServer, &service, router).Run()
+}
+
 func TestAll(t *testing.T) {
 	ctrl = gomock.NewController(t)
 	defer ctrl.Finish()
@@ -130,6 +165,7 @@ var _ = Describe("run server", func() {
 		router        fakeRouter
 		httpServer    fakeHTTPServer
 		katalogServer *webhookServer.Server
+		fakeMetrics   *mock_server.MockMetrics
 	)
 
 	BeforeEach(func() {
@@ -138,7 +174,7 @@ var _ = Describe("run server", func() {
 		router = fakeRouter{}
 		httpServer = fakeHTTPServer{}
 		fakeMetricsFactory := mock_server.NewMockMetricsFactory(ctrl)
-		fakeMetrics := mock_server.NewMockMetrics(ctrl)
+		fakeMetrics = mock_server.NewMockMetrics(ctrl)
 		fakeMetrics.EXPECT().IncrementCounter(gomock.Any(), gomock.Any()).AnyTimes()
 		fakeMetricsFactory.EXPECT().Create().Return(
 			fakeMetrics,
@@ -166,6 +202,21 @@ var _ = Describe("run server", func() {
 		Expect(srv).To(Equal(*output))
 	})
 
+	It("should create a service sent by a collector that only knows the single port", func() {
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		body := bytes.NewBufferString(`{"ID": "` + id + `", "Name": "legacy", "Port": 8080, "Address": "10.0.0.1"}`)
+		path := "/services/{id}"
+		req, _ := http.NewRequest(http.MethodPost, "", body)
+		rec := httptest.NewRecorder()
+
+		routes[path+"@POST"](rec, req)
+
+		resource := repository.persistence[id].(domain.Resource)
+		output := resource.GetK8sResource().(*domain.Service)
+		Expect(output.GetPort()).To(Equal(8080))
+		Expect(output.GetPorts()).To(Equal([]domain.ServicePort{{Port: 8080}}))
+	})
+
 	It("should update a service", func() {
 		id := "22d080de-4138-446f-acd4-d4c13fe77912"
 		service := domain.Service{ID: id, Port: 8888, Generation: 1}
@@ -323,6 +374,769 @@ var _ = Describe("run server", func() {
 		Expect(string(b)).To(Equal("Resource not found"))
 	})
 
+	It("should store services without metrics and delete a missing one", func() {
+		fakeMetricsFactory := mock_server.NewMockMetricsFactory(ctrl)
+		fakeMetricsFactory.EXPECT().Create().Return(mock_server.NewMockMetrics(ctrl)).Times(1)
+		service := server.MakeService(&repository, fakeMetricsFactory)
+		resource := domain.Resource{K8sResource: &domain.Service{ID: "22d080de-4138-446f-acd4-d4c13fe77912", Name: "catalog"}}
+		kind, _ := domain.KindOf(resource.K8sResource)
+
+		Expect(service.CreateResource(kind, resource)).To(Succeed())
+		Expect(service.UpdateResource(kind, resource)).To(Succeed())
+		Expect(service.DeleteResource(kind, "22d080de-4138-446f-acd4-d4c13fe77912")).To(Succeed())
+		Expect(service.DeleteResource(kind, "22d080de-4138-446f-acd4-d4c13fe77912")).To(Succeed())
+		Expect(repository.persistence["22d080de-4138-446f-acd4-d4c13fe77912"]).To(BeNil())
+	})
+
+	It("should serve the resources stored through the service it shares", func() {
+		fakeMetricsFactory := mock_server.NewMockMetricsFactory(ctrl)
+		fakeMetricsFactory.EXPECT().Create().Return(fakeMetrics).Times(1)
+		service := server.MakeService(&repository, fakeMetricsFactory)
+		webhookServer.CreateServerForService(&httpServer, &service, &router).Run()
+		deployment := domain.Resource{K8sResource: &domain.Deployment{ID: "22d080de-4138-446f-acd4-d4c13fe77912", Name: "catalog"}}
+		kind, _ := domain.KindOf(deployment.K8sResource)
+
+		Expect(service.CreateResource(kind, deployment)).To(Succeed())
+
+		rec := httptest.NewRecorder()
+		routes["/deployments"](rec, nil)
+		b, _ := ioutil.ReadAll(rec.Body)
+		resources, err := utils.DeserializeResourceArray(b, reflect.TypeOf(domain.Deployment{}))
+		Expect(err).To(BeNil())
+		Expect(resources).To(HaveLen(1))
+		Expect(resources[0].GetName()).To(Equal("catalog"))
+	})
+
+	It("should list and count only the services of the requested cluster", func() {
+		east := domain.Cluster{Name: "east"}
+		west := domain.Cluster{Name: "west"}
+		repository.persistence["east/22d080de-4138-446f-acd4-d4c13fe77912"] = domain.Resource{
+			K8sResource: &domain.Service{ID: "22d080de-4138-446f-acd4-d4c13fe77912", Cluster: &east, Instances: []domain.Instance{}},
+		}
+		repository.persistence["west/22d080de-4138-446f-acd4-d4c13fe77912"] = domain.Resource{
+			K8sResource: &domain.Service{ID: "22d080de-4138-446f-acd4-d4c13fe77912", Cluster: &west, Instances: []domain.Instance{}},
+		}
+		repository.persistence["west/22d080de-ffff-446f-acd4-d4c13fe77912"] = domain.Resource{
+			K8sResource: &domain.Service{ID: "22d080de-ffff-446f-acd4-d4c13fe77912", Cluster: &west, Instances: []domain.Instance{}},
+		}
+
+		req, _ := http.NewRequest(http.MethodGet, "/services?cluster=west", nil)
+		rec := httptest.NewRecorder()
+		routes["/services"](rec, req)
+
+		b, _ := ioutil.ReadAll(rec.Body)
+		resources, _ := utils.DeserializeResourceArray(b, reflect.TypeOf(domain.Service{}))
+		Expect(resources).To(HaveLen(2))
+		for _, r := range resources {
+			Expect(r.GetCluster().Name).To(Equal("west"))
+		}
+
+		req, _ = http.NewRequest(http.MethodGet, "/services/_count?cluster=east", nil)
+		rec = httptest.NewRecorder()
+		routes["/services/_count"](rec, req)
+
+		b, _ = ioutil.ReadAll(rec.Body)
+		var m struct{ Count int }
+		json.Unmarshal(b, &m)
+		Expect(m.Count).To(Equal(1))
+	})
+
+	It("should delete a service of the given cluster only", func() {
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		repository.persistence["east/"+id] = domain.Resource{K8sResource: &domain.Service{ID: id, Cluster: &domain.Cluster{Name: "east"}}}
+		repository.persistence["west/"+id] = domain.Resource{K8sResource: &domain.Service{ID: id, Cluster: &domain.Cluster{Name: "west"}}}
+		req, _ := http.NewRequest(http.MethodDelete, "/services/"+id+"?cluster=east", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		rec := httptest.NewRecorder()
+
+		routes["/services/{id}@DELETE"](rec, req)
+
+		Expect(repository.persistence["east/"+id]).To(BeNil())
+		Expect(repository.persistence["west/"+id]).NotTo(BeNil())
+	})
+
+	It("should list known clusters with their resource counts and last event", func() {
+		east := domain.Cluster{Name: "east", Region: "us-east", Environment: "production"}
+		repository.persistence["east/1"] = domain.Resource{K8sResource: &domain.Service{ID: "1", Cluster: &east, Timestamp: "2020-10-01 10:00:00"}}
+		repository.persistence["east/2"] = domain.Resource{K8sResource: &domain.Deployment{ID: "2", Cluster: &east, Timestamp: "2020-10-01 11:00:00"}}
+		repository.persistence["east/3"] = domain.Resource{K8sResource: &domain.Deployment{ID: "3", Cluster: &east, Timestamp: "2020-10-01 09:00:00"}}
+		repository.persistence["4"] = domain.Resource{K8sResource: &domain.Deployment{ID: "4"}}
+		rec := httptest.NewRecorder()
+
+		routes["/clusters"](rec, nil)
+
+		var clusters []webhookServer.ClusterSummary
+		json.NewDecoder(rec.Body).Decode(&clusters)
+		Expect(clusters).To(Equal([]webhookServer.ClusterSummary{{
+			Name:        "east",
+			Region:      "us-east",
+			Environment: "production",
+			Count:       3,
+			Resources:   map[string]int{"Service": 1, "Deployment": 2},
+			LastEvent:   "2020-10-01 11:00:00",
+		}}))
+	})
+
+	It("should create a custom resource and list it under its group and kind", func() {
+		customResource := domain.CustomResource{
+			ID:       "8d3d9c1a-3e2b-4a8f-9c43-5f1d7a2b6e01",
+			Name:     "checkout",
+			Group:    "argoproj.io",
+			Version:  "v1alpha1",
+			Kind:     "Rollout",
+			Resource: "rollouts",
+			Fields:   map[string]interface{}{"replicas": float64(4)},
+		}
+		body, _ := json.Marshal(customResource)
+		req, _ := http.NewRequest(http.MethodPost, "/resources/argoproj.io/Rollout/"+customResource.ID, bytes.NewReader(body))
+		routes["/resources/{group}/{kind}/{id}@POST"](httptest.NewRecorder(), req)
+		repository.persistence["other"] = domain.Resource{K8sResource: &domain.CustomResource{ID: "other", Group: "serving.knative.dev", Kind: "Service", Resource: "services"}}
+
+		Expect(repository.persistence).To(HaveKey(customResource.ID))
+
+		req, _ = http.NewRequest(http.MethodGet, "/resources/argoproj.io/rollouts", nil)
+		req = mux.SetURLVars(req, map[string]string{"group": "argoproj.io", "kind": "rollouts"})
+		rec := httptest.NewRecorder()
+		routes["/resources/{group}/{kind}"](rec, req)
+
+		var listed []struct{ K8sResource domain.CustomResource }
+		json.NewDecoder(rec.Body).Decode(&listed)
+		Expect(listed).To(HaveLen(1))
+		Expect(listed[0].K8sResource).To(Equal(customResource))
+
+		req, _ = http.NewRequest(http.MethodGet, "/resources/serving.knative.dev/service/_count", nil)
+		req = mux.SetURLVars(req, map[string]string{"group": "serving.knative.dev", "kind": "service"})
+		rec = httptest.NewRecorder()
+		routes["/resources/{group}/{kind}/_count"](rec, req)
+
+		var m struct{ Count int }
+		json.NewDecoder(rec.Body).Decode(&m)
+		Expect(m.Count).To(Equal(1))
+	})
+
+	It("should delete a custom resource", func() {
+		id := "8d3d9c1a-3e2b-4a8f-9c43-5f1d7a2b6e01"
+		repository.persistence[id] = domain.Resource{K8sResource: &domain.CustomResource{ID: id, Group: "argoproj.io", Kind: "Rollout"}}
+		req, _ := http.NewRequest(http.MethodDelete, "/resources/argoproj.io/Rollout/"+id, nil)
+		req = mux.SetURLVars(req, map[string]string{"group": "argoproj.io", "kind": "Rollout", "id": id})
+
+		routes["/resources/{group}/{kind}/{id}@DELETE"](httptest.NewRecorder(), req)
+
+		Expect(repository.persistence[id]).To(BeNil())
+	})
+
+	It("should sum up the ready instances and zones behind every service", func() {
+		repository.persistence["1"] = domain.Resource{K8sResource: &domain.Service{ID: "1", Name: "web", Namespace: "shop", Instances: []domain.Instance{
+			{Address: "10.0.0.1", Zone: "zone-b"},
+			{Address: "10.0.0.2", Zone: "zone-a"},
+			{Address: "10.0.0.3", Zone: "zone-a", NotReady: true},
+		}}}
+		repository.persistence["2"] = domain.Resource{K8sResource: &domain.Service{ID: "2", Name: "db", Namespace: "data", Instances: []domain.Instance{}}}
+		req, _ := http.NewRequest(http.MethodGet, "/services/_backends?namespace=shop", nil)
+		rec := httptest.NewRecorder()
+
+		routes["/services/_backends"](rec, req)
+
+		var backends []webhookServer.ServiceBackends
+		json.NewDecoder(rec.Body).Decode(&backends)
+		Expect(backends).To(Equal([]webhookServer.ServiceBackends{
+			{ID: "1", Name: "web", Namespace: "shop", Instances: 3, Ready: 2, Zones: []string{"zone-a", "zone-b"}},
+		}))
+	})
+
+	It("should list the services exposed outside the cluster", func() {
+		repository.persistence["1"] = domain.Resource{K8sResource: &domain.Service{ID: "1", Name: "web", Namespace: "shop", Type: "LoadBalancer",
+			Ports: []domain.ServicePort{{Name: "http", Protocol: "TCP", Port: 80, TargetPort: "8080", NodePort: 30080}}, LoadBalancerIngress: []string{"203.0.113.10"}}}
+		repository.persistence["2"] = domain.Resource{K8sResource: &domain.Service{ID: "2", Name: "legacy", Namespace: "shop", Port: 8080, ExternalIPs: []string{"198.51.100.7"}}}
+		repository.persistence["3"] = domain.Resource{K8sResource: &domain.Service{ID: "3", Name: "db", Namespace: "data", Type: "ClusterIP", Port: 5432}}
+		rec := httptest.NewRecorder()
+
+		routes["/services/_exposed"](rec, nil)
+
+		var services []webhookServer.ExposedService
+		json.NewDecoder(rec.Body).Decode(&services)
+		Expect(services).To(Equal([]webhookServer.ExposedService{
+			{ID: "2", Name: "legacy", Namespace: "shop", Ports: []domain.ServicePort{{Port: 8080}}, ExternalIPs: []string{"198.51.100.7"}},
+			{ID: "1", Name: "web", Namespace: "shop", Type: "LoadBalancer",
+				Ports: []domain.ServicePort{{Name: "http", Protocol: "TCP", Port: 80, TargetPort: "8080", NodePort: 30080}}, LoadBalancerIngress: []string{"203.0.113.10"}},
+		}))
+	})
+
+	It("should list the workloads whose rollout is degraded or stalled", func() {
+		east := domain.Cluster{Name: "east"}
+		repository.persistence["east/1"] = domain.Resource{K8sResource: &domain.Deployment{
+			ID: "1", Name: "api", Namespace: "shop", Cluster: &east,
+			Replicas: &domain.Replicas{Desired: 3, Ready: 3, Available: 3, Updated: 3},
+		}}
+		repository.persistence["east/2"] = domain.Resource{K8sResource: &domain.Deployment{
+			ID: "2", Name: "checkout", Namespace: "shop", Cluster: &east,
+			Replicas: &domain.Replicas{Desired: 3, Ready: 1, Available: 1, Updated: 1, Unavailable: 2},
+			Conditions: []domain.Condition{{
+				Type: domain.ConditionProgressing, Status: "False", Reason: "ProgressDeadlineExceeded",
+			}},
+		}}
+		repository.persistence["east/3"] = domain.Resource{K8sResource: &domain.Deployment{
+			ID: "3", Name: "cart", Namespace: "shop", Cluster: &east,
+			Replicas: &domain.Replicas{Desired: 2, Ready: 1, Available: 1, Updated: 2, Unavailable: 1},
+		}}
+		repository.persistence["east/4"] = domain.Resource{K8sResource: &domain.StatefulSet{
+			ID: "4", Name: "db", Namespace: "data", Cluster: &east,
+			Replicas: &domain.Replicas{Desired: 3, Ready: 2, Available: 2, Updated: 3},
+		}}
+		repository.persistence["east/5"] = domain.Resource{K8sResource: &domain.StatefulSet{
+			ID: "5", Name: "cache", Namespace: "data", Generation: 2, ObservedGeneration: 2, Cluster: &east,
+			Replicas: &domain.Replicas{Desired: 3, Ready: 1, Available: 1, Updated: 1},
+		}}
+		repository.persistence["east/6"] = domain.Resource{K8sResource: &domain.Deployment{ID: "6", Name: "legacy", Cluster: &east}}
+		rec := httptest.NewRecorder()
+
+		routes["/workloads/_degraded"](rec, nil)
+
+		var workloads []webhookServer.DegradedWorkload
+		json.NewDecoder(rec.Body).Decode(&workloads)
+		Expect(workloads).To(HaveLen(3))
+		Expect(workloads[0].Name).To(Equal("db"))
+		Expect(workloads[0].Kind).To(Equal("StatefulSet"))
+		Expect(workloads[0].Status).To(Equal(domain.RolloutStatus{State: domain.RolloutDegraded, Reason: "ReplicasNotReady"}))
+		Expect(workloads[1].Name).To(Equal("cart"))
+		Expect(workloads[1].Status.State).To(Equal(domain.RolloutDegraded))
+		Expect(workloads[2].Name).To(Equal("checkout"))
+		Expect(workloads[2].Status.State).To(Equal(domain.RolloutStalled))
+		Expect(workloads[2].Replicas.Unavailable).To(Equal(int32(2)))
+	})
+
+	It("should list the workloads running mixed digests or a digest other than the template", func() {
+		repository.persistence["1"] = domain.Resource{K8sResource: &domain.Deployment{
+			ID: "1", Name: "api", Namespace: "shop",
+			ContainerSpecs: []domain.Container{{Name: "api", Image: "registry.example.com/api:2.3"}},
+			RunningImages: []domain.RunningImage{
+				{Container: "api", Digest: "sha256:aaa", Pods: 2},
+				{Container: "api", Digest: "sha256:bbb", Pods: 1},
+			},
+		}}
+		repository.persistence["2"] = domain.Resource{K8sResource: &domain.DaemonSet{
+			ID: "2", Name: "agent", Namespace: "ops",
+			ContainerSpecs: []domain.Container{{Name: "agent", Image: "registry.example.com/agent@sha256:ccc"}},
+			RunningImages:  []domain.RunningImage{{Container: "agent", Digest: "sha256:ddd", Pods: 4}},
+		}}
+		repository.persistence["3"] = domain.Resource{K8sResource: &domain.Deployment{
+			ID: "3", Name: "cart", Namespace: "shop",
+			ContainerSpecs: []domain.Container{{Name: "cart", Image: "registry.example.com/cart@sha256:eee"}},
+			RunningImages:  []domain.RunningImage{{Container: "cart", Digest: "sha256:eee", Pods: 2}},
+		}}
+		rec := httptest.NewRecorder()
+
+		routes["/workloads/_drifted"](rec, nil)
+
+		var workloads []webhookServer.DriftedWorkload
+		json.NewDecoder(rec.Body).Decode(&workloads)
+		Expect(workloads).To(HaveLen(2))
+		Expect(workloads[0].Name).To(Equal("agent"))
+		Expect(workloads[0].Drifts).To(Equal([]domain.ImageDrift{{
+			Container: "agent",
+			Image:     "registry.example.com/agent@sha256:ccc",
+			Digests:   []domain.RunningImage{{Container: "agent", Digest: "sha256:ddd", Pods: 4}},
+			Diverged:  true,
+		}}))
+		Expect(workloads[1].Name).To(Equal("api"))
+		Expect(workloads[1].Drifts[0].Mixed).To(BeTrue())
+		Expect(workloads[1].Drifts[0].Diverged).To(BeFalse())
+	})
+
+	It("should list the distinct images with the workloads and namespaces running them", func() {
+		repository.persistence["1"] = domain.Resource{K8sResource: &domain.Deployment{
+			ID: "1", Name: "api", Namespace: "shop",
+			ContainerSpecs: []domain.Container{
+				{Name: "api", Image: "registry.example.com:5000/shop/api:2.3"},
+				{Name: "migrations", Image: "registry.example.com:5000/shop/api:2.3", Init: true},
+				{Name: "proxy", Image: "nginx"},
+			},
+		}}
+		repository.persistence["2"] = domain.Resource{K8sResource: &domain.CronJob{
+			ID: "2", Name: "report", Namespace: "finance",
+			Containers: map[string]string{"report": "docker.io/library/nginx:latest"},
+		}}
+		repository.persistence["3"] = domain.Resource{K8sResource: &domain.DaemonSet{
+			ID: "3", Name: "agent", Namespace: "ops",
+			ContainerSpecs: []domain.Container{{Name: "agent", Image: "quay.io/ops/agent@sha256:ccc"}},
+		}}
+		repository.persistence["4"] = domain.Resource{K8sResource: &domain.Service{ID: "4", Name: "api"}}
+		rec := httptest.NewRecorder()
+
+		routes["/images"](rec, nil)
+
+		var images []webhookServer.ImageUsage
+		json.NewDecoder(rec.Body).Decode(&images)
+		Expect(images).To(HaveLen(3))
+		Expect(images[0].Image).To(Equal("docker.io/library/nginx:latest"))
+		Expect(images[0].ImageReference).To(Equal(domain.ImageReference{Registry: "docker.io", Repository: "library/nginx", Tag: "latest"}))
+		Expect(images[0].Namespaces).To(Equal([]string{"finance", "shop"}))
+		Expect(images[1].ImageReference).To(Equal(domain.ImageReference{Registry: "quay.io", Repository: "ops/agent", Digest: "sha256:ccc"}))
+		Expect(images[2].ImageReference).To(Equal(domain.ImageReference{Registry: "registry.example.com:5000", Repository: "shop/api", Tag: "2.3"}))
+		Expect(images[2].Workloads).To(Equal([]webhookServer.ImageWorkload{
+			{Kind: "Deployment", ID: "1", Name: "api", Namespace: "shop", Container: "api"},
+			{Kind: "Deployment", ID: "1", Name: "api", Namespace: "shop", Container: "migrations"},
+		}))
+	})
+
+	It("should find who runs a repository at any tag", func() {
+		repository.persistence["1"] = domain.Resource{K8sResource: &domain.Deployment{
+			ID: "1", Name: "web", Namespace: "shop",
+			ContainerSpecs: []domain.Container{{Name: "web", Image: "nginx:1.19"}},
+		}}
+		repository.persistence["2"] = domain.Resource{K8sResource: &domain.Deployment{
+			ID: "2", Name: "edge", Namespace: "ops",
+			ContainerSpecs: []domain.Container{{Name: "edge", Image: "nginx@sha256:aaa"}},
+		}}
+		repository.persistence["3"] = domain.Resource{K8sResource: &domain.Deployment{
+			ID: "3", Name: "mirror", Namespace: "ops",
+			ContainerSpecs: []domain.Container{{Name: "mirror", Image: "mirror.example.com/library/nginx:1.19"}},
+		}}
+		repository.persistence["4"] = domain.Resource{K8sResource: &domain.Deployment{
+			ID: "4", Name: "other", Namespace: "ops",
+			ContainerSpecs: []domain.Container{{Name: "other", Image: "nginx-exporter:1.0"}},
+		}}
+
+		req, _ := http.NewRequest(http.MethodGet, "/images?repository=nginx", nil)
+		rec := httptest.NewRecorder()
+		routes["/images"](rec, req)
+
+		var images []webhookServer.ImageUsage
+		json.NewDecoder(rec.Body).Decode(&images)
+		Expect(images).To(HaveLen(3))
+
+		req, _ = http.NewRequest(http.MethodGet, "/images?repository=docker.io/library/nginx", nil)
+		rec = httptest.NewRecorder()
+		routes["/images"](rec, req)
+
+		json.NewDecoder(rec.Body).Decode(&images)
+		Expect(images).To(HaveLen(2))
+		Expect(images[0].Image).To(Equal("docker.io/library/nginx:1.19"))
+		Expect(images[0].Workloads[0].Name).To(Equal("web"))
+		Expect(images[1].Image).To(Equal("docker.io/library/nginx@sha256:aaa"))
+	})
+
+	It("should group the images by registry", func() {
+		repository.persistence["1"] = domain.Resource{K8sResource: &domain.Deployment{
+			ID: "1", Name: "web", Namespace: "shop",
+			ContainerSpecs: []domain.Container{{Name: "web", Image: "nginx:1.19"}, {Name: "cache", Image: "redis:6"}},
+		}}
+		repository.persistence["2"] = domain.Resource{K8sResource: &domain.Deployment{
+			ID: "2", Name: "edge", Namespace: "ops",
+			ContainerSpecs: []domain.Container{{Name: "edge", Image: "nginx:1.18"}},
+		}}
+		repository.persistence["3"] = domain.Resource{K8sResource: &domain.Job{
+			ID: "3", Name: "backup", Namespace: "ops",
+			ContainerSpecs: []domain.Container{{Name: "backup", Image: "quay.io/ops/backup:1.0"}},
+		}}
+		rec := httptest.NewRecorder()
+
+		routes["/images/_registries"](rec, nil)
+
+		var registries []webhookServer.RegistryUsage
+		json.NewDecoder(rec.Body).Decode(&registries)
+		Expect(registries).To(Equal([]webhookServer.RegistryUsage{
+			{
+				Registry:     "docker.io",
+				Repositories: []string{"library/nginx", "library/redis"},
+				Images:       []string{"docker.io/library/nginx:1.18", "docker.io/library/nginx:1.19", "docker.io/library/redis:6"},
+				Workloads:    2,
+			},
+			{
+				Registry:     "quay.io",
+				Repositories: []string{"ops/backup"},
+				Images:       []string{"quay.io/ops/backup:1.0"},
+				Workloads:    1,
+			},
+		}))
+	})
+
+	It("should check the images of deployments against the image policy", func() {
+		file, _ := ioutil.TempFile("", "image-policy-*.yaml")
+		defer os.Remove(file.Name())
+		file.WriteString(`
+disallowedRegistries: [docker.io]
+forbidLatestTag: true
+requireTag: true
+allowedTags:
+- namespaces: ["prod-*"]
+  pattern: '^v\d+\.\d+\.\d+$'
+`)
+		file.Close()
+		policy, err := server.LoadImagePolicy(file.Name())
+		Expect(err).NotTo(HaveOccurred())
+		serveWithImagePolicy(policy, &repository, &router, &httpServer, fakeMetrics)
+
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		deployment := domain.Deployment{ID: id, Name: "api", Namespace: "prod-shop", ContainerSpecs: []domain.Container{
+			{Name: "api", Image: "registry.example.com/api:latest"},
+			{Name: "proxy", Image: "nginx"},
+		}}
+		fakeMetrics.EXPECT().SetGauge("imagePolicyViolations", float64(1), server.RuleLatestTag, "Deployment", "", "prod-shop", "api")
+		fakeMetrics.EXPECT().SetGauge("imagePolicyViolations", float64(2), server.RuleTagPattern, "Deployment", "", "prod-shop", "api")
+		fakeMetrics.EXPECT().SetGauge("imagePolicyViolations", float64(1), server.RuleDisallowedRegistry, "Deployment", "", "prod-shop", "api")
+		fakeMetrics.EXPECT().SetGauge("imagePolicyViolations", float64(1), server.RuleMissingTag, "Deployment", "", "prod-shop", "api")
+		body := new(bytes.Buffer)
+		json.NewEncoder(body).Encode(deployment)
+		req, _ := http.NewRequest(http.MethodPost, "", body)
+		routes["/deployments/{id}@POST"](httptest.NewRecorder(), req)
+
+		req, _ = http.NewRequest(http.MethodGet, "/violations?rule=tag-pattern", nil)
+		rec := httptest.NewRecorder()
+		routes["/violations"](rec, req)
+
+		var violations []server.Violation
+		json.NewDecoder(rec.Body).Decode(&violations)
+		Expect(violations).To(HaveLen(2))
+		Expect(violations[0].Container).To(Equal("api"))
+		Expect(violations[0].Kind).To(Equal("Deployment"))
+		Expect(violations[1].Container).To(Equal("proxy"))
+		Expect(violations[1].Image).To(Equal("nginx"))
+
+		deployment.Generation = 1
+		deployment.ContainerSpecs = []domain.Container{
+			{Name: "api", Image: "registry.example.com/api:v1.2.3"},
+			{Name: "proxy", Image: "registry.example.com/proxy:v1.0.0"},
+		}
+		for _, rule := range []string{server.RuleLatestTag, server.RuleTagPattern, server.RuleDisallowedRegistry, server.RuleMissingTag} {
+			fakeMetrics.EXPECT().SetGauge("imagePolicyViolations", float64(0), rule, "Deployment", "", "prod-shop", "api")
+		}
+		body = new(bytes.Buffer)
+		json.NewEncoder(body).Encode(deployment)
+		req, _ = http.NewRequest(http.MethodPut, "", body)
+		routes["/deployments/{id}@PUT"](httptest.NewRecorder(), req)
+
+		rec = httptest.NewRecorder()
+		routes["/violations"](rec, nil)
+		json.NewDecoder(rec.Body).Decode(&violations)
+		Expect(violations).To(BeEmpty())
+
+		stale := deployment
+		stale.Generation = 0
+		stale.ContainerSpecs = []domain.Container{{Name: "api", Image: "registry.example.com/api:latest"}}
+		body = new(bytes.Buffer)
+		json.NewEncoder(body).Encode(stale)
+		req, _ = http.NewRequest(http.MethodPut, "", body)
+		routes["/deployments/{id}@PUT"](httptest.NewRecorder(), req)
+
+		rec = httptest.NewRecorder()
+		routes["/violations"](rec, nil)
+		json.NewDecoder(rec.Body).Decode(&violations)
+		Expect(violations).To(BeEmpty())
+	})
+
+	It("should remove the violation gauges of a deleted deployment", func() {
+		policy := &server.ImagePolicy{ForbidLatestTag: true}
+		serveWithImagePolicy(policy, &repository, &router, &httpServer, fakeMetrics)
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		repository.persistence[id] = domain.Resource{K8sResource: &domain.Deployment{ID: id, Name: "api", Namespace: "shop", Cluster: &domain.Cluster{Name: "east"},
+			ContainerSpecs: []domain.Container{{Name: "api", Image: "registry.example.com/api:latest"}}}}
+		for _, rule := range []string{server.RuleLatestTag, server.RuleTagPattern, server.RuleDisallowedRegistry, server.RuleMissingTag, server.RuleMissingDigest} {
+			fakeMetrics.EXPECT().DeleteGauge("imagePolicyViolations", rule, "Deployment", "east", "shop", "api")
+		}
+		req, _ := http.NewRequest(http.MethodDelete, "/deployments/"+id, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		rec := httptest.NewRecorder()
+
+		routes["/deployments/{id}@DELETE"](rec, req)
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(repository.persistence[id]).To(BeNil())
+	})
+
+	It("should check the stored workloads against the current image policy", func() {
+		persistence["east/d1"] = domain.Resource{K8sResource: &domain.Deployment{ID: "d1", Name: "api", Namespace: "shop", Cluster: &domain.Cluster{Name: "east"},
+			ContainerSpecs: []domain.Container{{Name: "api", Image: "registry.example.com/api:latest"}}}}
+		persistence["s1"] = domain.Resource{K8sResource: &domain.Service{ID: "s1", Name: "api", Namespace: "shop"}}
+
+		rec := httptest.NewRecorder()
+		routes["/violations"](rec, nil)
+		var violations []server.Violation
+		json.NewDecoder(rec.Body).Decode(&violations)
+		Expect(violations).To(BeEmpty())
+
+		serveWithImagePolicy(&server.ImagePolicy{ForbidLatestTag: true}, &repository, &router, &httpServer, fakeMetrics)
+		rec = httptest.NewRecorder()
+		routes["/violations"](rec, nil)
+		json.NewDecoder(rec.Body).Decode(&violations)
+		Expect(violations).To(Equal([]server.Violation{{
+			Rule:      server.RuleLatestTag,
+			Kind:      "Deployment",
+			ID:        "d1",
+			Name:      "api",
+			Namespace: "shop",
+			Cluster:   "east",
+			Container: "api",
+			Image:     "registry.example.com/api:latest",
+			Message:   "the latest tag is not allowed",
+		}}))
+	})
+
+	It("should return the owners, children and selections of a resource", func() {
+		persistence["r1"] = domain.Resource{K8sResource: &domain.CustomResource{ID: "r1", Name: "web", Namespace: "shop", Group: "argoproj.io", Kind: "Rollout"}}
+		persistence["d1"] = domain.Resource{K8sResource: &domain.Deployment{
+			ID: "d1", Name: "web", Namespace: "shop",
+			OwnerReferences: []domain.OwnerReference{{Kind: "Rollout", Name: "web", UID: "r1", Controller: true}},
+			PodLabels:       map[string]string{"app": "web", "tier": "frontend"},
+		}}
+		persistence["s1"] = domain.Resource{K8sResource: &domain.Service{ID: "s1", Name: "web", Namespace: "shop", Selector: map[string]string{"app": "web"}}}
+		persistence["s2"] = domain.Resource{K8sResource: &domain.Service{ID: "s2", Name: "web", Namespace: "other", Selector: map[string]string{"app": "web"}}}
+		persistence["rs1"] = domain.Resource{K8sResource: &domain.ReplicaSet{
+			ID: "rs1", Name: "web-7d4b9", Namespace: "shop",
+			OwnerReferences: []domain.OwnerReference{{Kind: "Deployment", Name: "web", UID: "d1", Controller: true}},
+		}}
+		persistence["c1"] = domain.Resource{K8sResource: &domain.CronJob{ID: "c1", Name: "report", Namespace: "shop"}}
+		persistence["j1"] = domain.Resource{K8sResource: &domain.Job{
+			ID: "j1", Name: "report-1", Namespace: "shop",
+			OwnerReferences: []domain.OwnerReference{{Kind: "CronJob", Name: "report", UID: "c1"}},
+		}}
+
+		req, _ := http.NewRequest(http.MethodGet, "/graph/deployments/d1", nil)
+		req = mux.SetURLVars(req, map[string]string{"kind": "deployments", "id": "d1"})
+		rec := httptest.NewRecorder()
+		routes["/graph/{kind}/{id}"](rec, req)
+
+		var topology webhookServer.Topology
+		json.NewDecoder(rec.Body).Decode(&topology)
+		Expect(topology.Resource).To(Equal(webhookServer.GraphNode{Kind: "Deployment", ID: "d1", Name: "web", Namespace: "shop"}))
+		Expect(topology.Owners).To(Equal([]webhookServer.GraphNode{{Kind: "Rollout", ID: "r1", Name: "web", Namespace: "shop"}}))
+		Expect(topology.Children).To(Equal([]webhookServer.GraphNode{{Kind: "ReplicaSet", ID: "rs1", Name: "web-7d4b9", Namespace: "shop"}}))
+		Expect(topology.SelectedBy).To(Equal([]webhookServer.GraphNode{{Kind: "Service", ID: "s1", Name: "web", Namespace: "shop"}}))
+
+		req, _ = http.NewRequest(http.MethodGet, "/graph/cronjobs/c1", nil)
+		req = mux.SetURLVars(req, map[string]string{"kind": "cronjobs", "id": "c1"})
+		rec = httptest.NewRecorder()
+		routes["/graph/{kind}/{id}"](rec, req)
+
+		topology = webhookServer.Topology{}
+		json.NewDecoder(rec.Body).Decode(&topology)
+		Expect(topology.Children).To(Equal([]webhookServer.GraphNode{{Kind: "Job", ID: "j1", Name: "report-1", Namespace: "shop"}}))
+
+		req, _ = http.NewRequest(http.MethodGet, "/graph/services/d1", nil)
+		req = mux.SetURLVars(req, map[string]string{"kind": "services", "id": "d1"})
+		rec = httptest.NewRecorder()
+		routes["/graph/{kind}/{id}"](rec, req)
+
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should list the services selecting nothing and the workloads no service selects", func() {
+		persistence["d1"] = domain.Resource{K8sResource: &domain.Deployment{ID: "d1", Name: "web", Namespace: "shop", PodLabels: map[string]string{"app": "web"}}}
+		persistence["d2"] = domain.Resource{K8sResource: &domain.StatefulSet{ID: "d2", Name: "db", Namespace: "shop", PodLabels: map[string]string{"app": "db"}}}
+		persistence["j1"] = domain.Resource{K8sResource: &domain.Job{ID: "j1", Name: "migrate", Namespace: "shop"}}
+		persistence["s1"] = domain.Resource{K8sResource: &domain.Service{ID: "s1", Name: "web", Namespace: "shop", Selector: map[string]string{"app": "web"}}}
+		persistence["s2"] = domain.Resource{K8sResource: &domain.Service{ID: "s2", Name: "legacy", Namespace: "shop", Selector: map[string]string{"app": "legacy"}}}
+		persistence["s3"] = domain.Resource{K8sResource: &domain.Service{ID: "s3", Name: "external", Namespace: "shop"}}
+		rec := httptest.NewRecorder()
+
+		routes["/graph/_orphans"](rec, nil)
+
+		var orphans webhookServer.Orphans
+		json.NewDecoder(rec.Body).Decode(&orphans)
+		Expect(orphans.Services).To(Equal([]webhookServer.GraphNode{{Kind: "Service", ID: "s2", Name: "legacy", Namespace: "shop"}}))
+		Expect(orphans.Workloads).To(Equal([]webhookServer.GraphNode{{Kind: "StatefulSet", ID: "d2", Name: "db", Namespace: "shop"}}))
+	})
+
+	Describe("querying resources", func() {
+		BeforeEach(func() {
+			persistence["d1"] = domain.Resource{K8sResource: &domain.Deployment{
+				ID: "d1", Name: "web-frontend", Namespace: "shop",
+				Labels:         map[string]string{"app": "web", "tier": "web"},
+				Annotations:    map[string]string{"katalog.io/owner": "storefront"},
+				ContainerSpecs: []domain.Container{{Name: "web", Image: "registry.example.com/shop/web:1.2"}},
+			}}
+			persistence["d2"] = domain.Resource{K8sResource: &domain.Deployment{
+				ID: "d2", Name: "api", Namespace: "shop",
+				Labels:     map[string]string{"app": "api", "tier": "api"},
+				Containers: map[string]string{"api": "golang:1.15"},
+			}}
+			persistence["d3"] = domain.Resource{K8sResource: &domain.Deployment{
+				ID: "d3", Name: "web-admin", Namespace: "backoffice",
+				Labels: map[string]string{"app": "web", "tier": "batch"},
+			}}
+		})
+
+		list := func(query string) []string {
+			req, _ := http.NewRequest(http.MethodGet, "/deployments?"+query, nil)
+			rec := httptest.NewRecorder()
+			routes["/deployments"](rec, req)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			b, _ := ioutil.ReadAll(rec.Body)
+			resources, err := utils.DeserializeResourceArray(b, reflect.TypeOf(domain.Deployment{}))
+			Expect(err).To(BeNil())
+			ids := make([]string, 0)
+			for _, resource := range resources {
+				ids = append(ids, resource.GetID())
+			}
+			return ids
+		}
+
+		It("should filter by namespaces, repeated or comma separated", func() {
+			Expect(list("namespace=shop")).To(ConsistOf("d1", "d2"))
+			Expect(list("namespace=shop&namespace=backoffice")).To(ConsistOf("d1", "d2", "d3"))
+			Expect(list("namespace=backoffice,payments")).To(ConsistOf("d3"))
+		})
+
+		It("should filter by label selector", func() {
+			Expect(list("selector=" + url.QueryEscape("app=web,tier in (web,api)"))).To(ConsistOf("d1"))
+			Expect(list("selector=" + url.QueryEscape("tier!=batch"))).To(ConsistOf("d1", "d2"))
+		})
+
+		It("should filter by name prefix and regular expression", func() {
+			Expect(list("namePrefix=web-")).To(ConsistOf("d1", "d3"))
+			Expect(list("nameRegex=" + url.QueryEscape("^(api|web-admin)$"))).To(ConsistOf("d2", "d3"))
+		})
+
+		It("should filter by annotation presence and image substring", func() {
+			Expect(list("annotation=katalog.io/owner")).To(ConsistOf("d1"))
+			Expect(list("image=golang")).To(ConsistOf("d2"))
+			Expect(list("image=example.com/shop&namespace=shop")).To(ConsistOf("d1"))
+		})
+
+		It("should filter services by annotation presence", func() {
+			persistence["s1"] = domain.Resource{K8sResource: &domain.Service{
+				ID: "s1", Name: "web", Namespace: "shop",
+				Annotations: map[string]string{"katalog.io/owner": "storefront"},
+			}}
+			persistence["s2"] = domain.Resource{K8sResource: &domain.Service{ID: "s2", Name: "api", Namespace: "shop"}}
+			req, _ := http.NewRequest(http.MethodGet, "/services?annotation=katalog.io/owner", nil)
+			rec := httptest.NewRecorder()
+
+			routes["/services"](rec, req)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			b, _ := ioutil.ReadAll(rec.Body)
+			resources, err := utils.DeserializeResourceArray(b, reflect.TypeOf(domain.Service{}))
+			Expect(err).To(BeNil())
+			Expect(resources).To(HaveLen(1))
+			Expect(resources[0].GetID()).To(Equal("s1"))
+		})
+
+		It("should push the namespace, name prefix and label equalities down to the repository", func() {
+			query := "namespace=shop&namePrefix=web-&selector=" + url.QueryEscape("app=web,tier in (web,api),env!=dev")
+			Expect(list(query)).To(ConsistOf("d1"))
+			Expect(list("namespace=shop,backoffice")).To(ConsistOf("d1", "d2", "d3"))
+
+			Expect(repository.filters).To(Equal([]katalogPersistence.Filter{
+				{Kind: "Deployment", Namespace: "shop", NamePrefix: "web-", Labels: map[string]string{"app": "web"}},
+				{Kind: "Deployment"},
+			}))
+		})
+
+		It("should count with the same filters", func() {
+			req, _ := http.NewRequest(http.MethodGet, "/deployments/_count?namePrefix=web-&namespace=shop", nil)
+			rec := httptest.NewRecorder()
+			routes["/deployments/_count"](rec, req)
+
+			Expect(rec.Body.String()).To(MatchJSON(`{"Count": 1}`))
+		})
+
+		It("should answer a 400 with a JSON error to an invalid selector", func() {
+			for _, path := range []string{"/deployments", "/deployments/_count"} {
+				req, _ := http.NewRequest(http.MethodGet, path+"?selector="+url.QueryEscape("tier in web"), nil)
+				rec := httptest.NewRecorder()
+				routes[path](rec, req)
+
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(rec.Header().Get("Content-Type")).To(Equal("application/json"))
+				var queryError webhookServer.QueryError
+				Expect(json.NewDecoder(rec.Body).Decode(&queryError)).To(Succeed())
+				Expect(queryError.Parameter).To(Equal("selector"))
+				Expect(queryError.Error).NotTo(BeEmpty())
+			}
+		})
+
+		It("should answer a 400 to an invalid name regular expression", func() {
+			req, _ := http.NewRequest(http.MethodGet, "/deployments?nameRegex="+url.QueryEscape("web-("), nil)
+			rec := httptest.NewRecorder()
+			routes["/deployments"](rec, req)
+
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+			Expect(rec.Body.String()).To(ContainSubstring(`"Parameter":"nameRegex"`))
+		})
+	})
+
+	Describe("exporting the catalog graph", func() {
+		BeforeEach(func() {
+			persistence["d1"] = domain.Resource{K8sResource: &domain.Deployment{
+				ID: "d1", Name: "web", Namespace: "shop",
+				Labels:         map[string]string{"team": "storefront", "tier": "frontend"},
+				PodLabels:      map[string]string{"app": "web"},
+				ContainerSpecs: []domain.Container{{Name: "web", Image: "nginx:1.19"}, {Name: "init", Image: "busybox", Init: true}},
+			}}
+			persistence["s1"] = domain.Resource{K8sResource: &domain.Service{
+				ID: "s1", Name: "web", Namespace: "shop",
+				Labels:   map[string]string{"team": "storefront"},
+				Selector: map[string]string{"app": "web"},
+			}}
+			persistence["s2"] = domain.Resource{K8sResource: &domain.Service{ID: "s2", Name: "db", Namespace: "data"}}
+		})
+
+		It("should export a namespace as a JSON graph", func() {
+			req, _ := http.NewRequest(http.MethodGet, "/export/graph?namespace=shop", nil)
+			rec := httptest.NewRecorder()
+			routes["/export/graph"](rec, req)
+
+			var graph webhookServer.ExportGraph
+			json.NewDecoder(rec.Body).Decode(&graph)
+			Expect(graph.Nodes).To(Equal([]webhookServer.ExportNode{
+				{ID: "d1", Kind: "Deployment", Name: "web", Namespace: "shop", Images: []string{"busybox", "nginx:1.19"}, Team: "storefront"},
+				{ID: "s1", Kind: "Service", Name: "web", Namespace: "shop", Team: "storefront"},
+			}))
+			Expect(graph.Edges).To(Equal([]webhookServer.ExportEdge{{Source: "s1", Target: "d1", Type: "selects"}}))
+		})
+
+		It("should export the resources matching a label selector as DOT", func() {
+			req, _ := http.NewRequest(http.MethodGet, "/export/graph?format=dot&selector=tier%3Dfrontend", nil)
+			rec := httptest.NewRecorder()
+			routes["/export/graph"](rec, req)
+
+			Expect(rec.Header().Get("Content-Type")).To(Equal("text/vnd.graphviz"))
+			Expect(rec.Body.String()).To(Equal(`digraph katalog {
+  "d1" [label="Deployment\nshop/web", kind="Deployment", namespace="shop", images="busybox,nginx:1.19", team="storefront"];
+}
+`))
+		})
+
+		It("should export the graph as GraphML", func() {
+			req, _ := http.NewRequest(http.MethodGet, "/export/graph?format=graphml&namespace=shop", nil)
+			rec := httptest.NewRecorder()
+			routes["/export/graph"](rec, req)
+
+			body := rec.Body.String()
+			Expect(body).To(HavePrefix("<?xml"))
+			Expect(body).To(ContainSubstring(`<graph id="katalog" edgedefault="directed">`))
+			Expect(body).To(ContainSubstring(`<node id="d1">`))
+			Expect(body).To(ContainSubstring(`<data key="team">storefront</data>`))
+			Expect(body).To(ContainSubstring(`<edge source="s1" target="d1">`))
+		})
+
+		It("should refuse an unknown format or an invalid selector", func() {
+			req, _ := http.NewRequest(http.MethodGet, "/export/graph?format=svg", nil)
+			rec := httptest.NewRecorder()
+			routes["/export/graph"](rec, req)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+			var queryError webhookServer.QueryError
+			json.NewDecoder(rec.Body).Decode(&queryError)
+			Expect(queryError.Parameter).To(Equal("format"))
+
+			req, _ = http.NewRequest(http.MethodGet, "/export/graph?selector=%3D%3D", nil)
+			rec = httptest.NewRecorder()
+			routes["/export/graph"](rec, req)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+			json.NewDecoder(rec.Body).Decode(&queryError)
+			Expect(queryError.Parameter).To(Equal("selector"))
+		})
+
+		It("should fail with a server error when the catalog cannot be read", func() {
+			repository.fail = true
+			rec := httptest.NewRecorder()
+			routes["/export/graph"](rec, nil)
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
 	It("should create a deployment", func() {
 		id := "22d080de-4138-446f-acd4-d4c13fe77912"
 		deployment := domain.Deployment{ID: id}
@@ -680,6 +1494,540 @@ var _ = Describe("run server", func() {
 		Expect(string(b)).To(Equal("Resource not found"))
 	})
 
+	It("should create a daemonSet", func() {
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		daemonSet := domain.DaemonSet{ID: id}
+		body := new(bytes.Buffer)
+		json.NewEncoder(body).Encode(daemonSet)
+		path := "/daemonsets/{id}"
+		req, _ := http.NewRequest(http.MethodPost, "", body)
+		rec := httptest.NewRecorder()
+
+		routes[path+"@POST"](rec, req)
+
+		b, _ := ioutil.ReadAll(rec.Body)
+		var srv domain.DaemonSet
+		json.Unmarshal(b, &srv)
+		resource := repository.persistence[id].(domain.Resource)
+		output := resource.GetK8sResource().(*domain.DaemonSet)
+		Expect(srv).To(Equal(*output))
+	})
+
+	It("should update a daemonSet", func() {
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		daemonSet := domain.DaemonSet{ID: id, Generation: 1}
+		resource := domain.Resource{K8sResource: &daemonSet}
+		repository.persistence[id] = resource
+		newDaemonSet := domain.DaemonSet{ID: id, Generation: 2}
+		body := new(bytes.Buffer)
+		json.NewEncoder(body).Encode(newDaemonSet)
+		path := "/daemonsets/{id}"
+		req, _ := http.NewRequest(http.MethodPut, "/daemonsets/"+id, body)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		rec := httptest.NewRecorder()
+
+		routes[path+"@PUT"](rec, req)
+
+		newResource := domain.Resource{K8sResource: &newDaemonSet}
+		Expect(repository.persistence[id]).To(Equal(newResource))
+	})
+
+	It("should not update an non-existing daemonset", func() {
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		daemonSet := domain.DaemonSet{ID: id, Generation: 1}
+		resource := domain.Resource{K8sResource: &daemonSet}
+		repository.persistence[id] = resource
+		newDaemonSet := domain.DaemonSet{ID: "22d080de-4138-446f-acd4-d4c13fe779ff", Generation: 2}
+		body := new(bytes.Buffer)
+		json.NewEncoder(body).Encode(newDaemonSet)
+		path := "/daemonsets/{id}"
+		req, _ := http.NewRequest(http.MethodPut, "/daemonsets/"+id, body)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		rec := httptest.NewRecorder()
+
+		routes[path+"@PUT"](rec, req)
+
+		newResource := domain.Resource{K8sResource: &newDaemonSet}
+		Expect(repository.persistence[id]).NotTo(Equal(newResource))
+	})
+
+	It("should delete a daemonset", func() {
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		daemonSet := domain.DaemonSet{ID: id}
+		resource := domain.Resource{K8sResource: &daemonSet}
+		repository.persistence[id] = resource
+		path := "/daemonsets/{id}"
+		req, _ := http.NewRequest(http.MethodDelete, "/daemonsets/"+id, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		rec := httptest.NewRecorder()
+
+		routes[path+"@DELETE"](rec, req)
+
+		Expect(repository.persistence[id]).To(BeNil())
+	})
+
+	It("should not delete a non-existing daemonset", func() {
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		daemonSet := domain.DaemonSet{ID: id}
+		resource := domain.Resource{K8sResource: &daemonSet}
+		repository.persistence[id] = resource
+		nonExistingID := "3c665874-bc02-4691-981a-73fed8a12562"
+		path := "/daemonsets/{id}"
+		req, _ := http.NewRequest(http.MethodDelete, "/daemonsets/"+id, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": nonExistingID})
+		rec := httptest.NewRecorder()
+
+		routes[path+"@DELETE"](rec, req)
+
+		Expect(repository.persistence[nonExistingID]).To(BeNil())
+	})
+
+	It("should not delete daemonset when repository error", func() {
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		daemonSet := domain.Deployment{ID: id}
+		resource := domain.Resource{K8sResource: &daemonSet}
+		repository.persistence[id] = resource
+		repository.fail = true
+		path := "/daemonsets/{id}"
+		req, _ := http.NewRequest(http.MethodDelete, "/daemonsets/"+id, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		rec := httptest.NewRecorder()
+
+		routes[path+"@DELETE"](rec, req)
+
+		Expect(repository.persistence[id]).NotTo(BeNil())
+	})
+
+	It("should list all daemonsets", func() {
+		inputResource1 := domain.Resource{
+			K8sResource: &domain.DaemonSet{ID: "22d080de-4138-446f-acd4-d4c13fe77912"},
+		}
+		inputResource2 := domain.Resource{
+			K8sResource: &domain.Service{ID: "22d080de-ffff-446f-acd4-d4c13fe77912"},
+		}
+		inputResource3 := domain.Resource{
+			K8sResource: &domain.DaemonSet{ID: "22d080de-xxxx-446f-acd4-d4c13fe77912"},
+		}
+		repository.persistence["22d080de-4138-446f-acd4-d4c13fe77912"] = inputResource1
+		repository.persistence["22d080de-ffff-446f-acd4-d4c13fe77912"] = inputResource2
+		repository.persistence["22d080de-xxxx-446f-acd4-d4c13fe77912"] = inputResource3
+		path := "/daemonsets"
+		rec := httptest.NewRecorder()
+
+		routes[path](rec, nil)
+
+		b, _ := ioutil.ReadAll(rec.Body)
+		resources, err := utils.DeserializeResourceArray(b, reflect.TypeOf(domain.DaemonSet{}))
+		if err != nil {
+			fmt.Println(err)
+		}
+		for _, r := range resources {
+			Expect(*r).To(Equal(repository.persistence[r.GetID()]))
+		}
+	})
+
+	It("should not find any daemonsets to list", func() {
+		repository.fail = true
+		path := "/daemonsets"
+		rec := httptest.NewRecorder()
+
+		routes[path](rec, nil)
+
+		b, _ := ioutil.ReadAll(rec.Body)
+		Expect(string(b)).To(Equal("Resource not found"))
+	})
+
+	It("should count amount of daemonsets", func() {
+		inputResource1 := domain.Resource{
+			K8sResource: &domain.DaemonSet{ID: "22d080de-4138-446f-acd4-d4c13fe77912"},
+		}
+		inputResource2 := domain.Resource{
+			K8sResource: &domain.Service{ID: "22d080de-ffff-446f-acd4-d4c13fe77912"},
+		}
+		inputResource3 := domain.Resource{
+			K8sResource: &domain.DaemonSet{ID: "22d080de-xxxx-446f-acd4-d4c13fe77912"},
+		}
+		repository.persistence["22d080de-4138-446f-acd4-d4c13fe77912"] = inputResource1
+		repository.persistence["22d080de-ffff-446f-acd4-d4c13fe77912"] = inputResource2
+		repository.persistence["22d080de-xxxx-446f-acd4-d4c13fe77912"] = inputResource3
+		path := "/daemonsets/_count"
+		rec := httptest.NewRecorder()
+
+		routes[path](rec, nil)
+
+		b, _ := ioutil.ReadAll(rec.Body)
+		var m struct{ Count int }
+		json.Unmarshal(b, &m)
+		Expect(m.Count).To(Equal(2))
+	})
+
+	It("should not find any daemonsets to count", func() {
+		repository.fail = true
+		path := "/daemonsets/_count"
+		rec := httptest.NewRecorder()
+
+		routes[path](rec, nil)
+
+		b, _ := ioutil.ReadAll(rec.Body)
+		Expect(string(b)).To(Equal("Resource not found"))
+	})
+
+	It("should create a job", func() {
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		job := domain.Job{ID: id}
+		body := new(bytes.Buffer)
+		json.NewEncoder(body).Encode(job)
+		path := "/jobs/{id}"
+		req, _ := http.NewRequest(http.MethodPost, "", body)
+		rec := httptest.NewRecorder()
+
+		routes[path+"@POST"](rec, req)
+
+		b, _ := ioutil.ReadAll(rec.Body)
+		var srv domain.Job
+		json.Unmarshal(b, &srv)
+		resource := repository.persistence[id].(domain.Resource)
+		output := resource.GetK8sResource().(*domain.Job)
+		Expect(srv).To(Equal(*output))
+	})
+
+	It("should update a job", func() {
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		job := domain.Job{ID: id, Generation: 1}
+		resource := domain.Resource{K8sResource: &job}
+		repository.persistence[id] = resource
+		newJob := domain.Job{ID: id, Generation: 2}
+		body := new(bytes.Buffer)
+		json.NewEncoder(body).Encode(newJob)
+		path := "/jobs/{id}"
+		req, _ := http.NewRequest(http.MethodPut, "/jobs/"+id, body)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		rec := httptest.NewRecorder()
+
+		routes[path+"@PUT"](rec, req)
+
+		newResource := domain.Resource{K8sResource: &newJob}
+		Expect(repository.persistence[id]).To(Equal(newResource))
+	})
+
+	It("should not update an non-existing job", func() {
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		job := domain.Job{ID: id, Generation: 1}
+		resource := domain.Resource{K8sResource: &job}
+		repository.persistence[id] = resource
+		newJob := domain.Job{ID: "22d080de-4138-446f-acd4-d4c13fe779ff", Generation: 2}
+		body := new(bytes.Buffer)
+		json.NewEncoder(body).Encode(newJob)
+		path := "/jobs/{id}"
+		req, _ := http.NewRequest(http.MethodPut, "/jobs/"+id, body)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		rec := httptest.NewRecorder()
+
+		routes[path+"@PUT"](rec, req)
+
+		newResource := domain.Resource{K8sResource: &newJob}
+		Expect(repository.persistence[id]).NotTo(Equal(newResource))
+	})
+
+	It("should delete a job", func() {
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		job := domain.Job{ID: id}
+		resource := domain.Resource{K8sResource: &job}
+		repository.persistence[id] = resource
+		path := "/jobs/{id}"
+		req, _ := http.NewRequest(http.MethodDelete, "/jobs/"+id, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		rec := httptest.NewRecorder()
+
+		routes[path+"@DELETE"](rec, req)
+
+		Expect(repository.persistence[id]).To(BeNil())
+	})
+
+	It("should not delete a non-existing job", func() {
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		job := domain.Job{ID: id}
+		resource := domain.Resource{K8sResource: &job}
+		repository.persistence[id] = resource
+		nonExistingID := "3c665874-bc02-4691-981a-73fed8a12562"
+		path := "/jobs/{id}"
+		req, _ := http.NewRequest(http.MethodDelete, "/jobs/"+id, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": nonExistingID})
+		rec := httptest.NewRecorder()
+
+		routes[path+"@DELETE"](rec, req)
+
+		Expect(repository.persistence[nonExistingID]).To(BeNil())
+	})
+
+	It("should not delete job when repository error", func() {
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		job := domain.Deployment{ID: id}
+		resource := domain.Resource{K8sResource: &job}
+		repository.persistence[id] = resource
+		repository.fail = true
+		path := "/jobs/{id}"
+		req, _ := http.NewRequest(http.MethodDelete, "/jobs/"+id, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		rec := httptest.NewRecorder()
+
+		routes[path+"@DELETE"](rec, req)
+
+		Expect(repository.persistence[id]).NotTo(BeNil())
+	})
+
+	It("should list all jobs", func() {
+		inputResource1 := domain.Resource{
+			K8sResource: &domain.Job{ID: "22d080de-4138-446f-acd4-d4c13fe77912"},
+		}
+		inputResource2 := domain.Resource{
+			K8sResource: &domain.Service{ID: "22d080de-ffff-446f-acd4-d4c13fe77912"},
+		}
+		inputResource3 := domain.Resource{
+			K8sResource: &domain.Job{ID: "22d080de-xxxx-446f-acd4-d4c13fe77912"},
+		}
+		repository.persistence["22d080de-4138-446f-acd4-d4c13fe77912"] = inputResource1
+		repository.persistence["22d080de-ffff-446f-acd4-d4c13fe77912"] = inputResource2
+		repository.persistence["22d080de-xxxx-446f-acd4-d4c13fe77912"] = inputResource3
+		path := "/jobs"
+		rec := httptest.NewRecorder()
+
+		routes[path](rec, nil)
+
+		b, _ := ioutil.ReadAll(rec.Body)
+		resources, err := utils.DeserializeResourceArray(b, reflect.TypeOf(domain.Job{}))
+		if err != nil {
+			fmt.Println(err)
+		}
+		for _, r := range resources {
+			Expect(*r).To(Equal(repository.persistence[r.GetID()]))
+		}
+	})
+
+	It("should not find any jobs to list", func() {
+		repository.fail = true
+		path := "/jobs"
+		rec := httptest.NewRecorder()
+
+		routes[path](rec, nil)
+
+		b, _ := ioutil.ReadAll(rec.Body)
+		Expect(string(b)).To(Equal("Resource not found"))
+	})
+
+	It("should count amount of jobs", func() {
+		inputResource1 := domain.Resource{
+			K8sResource: &domain.Job{ID: "22d080de-4138-446f-acd4-d4c13fe77912"},
+		}
+		inputResource2 := domain.Resource{
+			K8sResource: &domain.Service{ID: "22d080de-ffff-446f-acd4-d4c13fe77912"},
+		}
+		inputResource3 := domain.Resource{
+			K8sResource: &domain.Job{ID: "22d080de-xxxx-446f-acd4-d4c13fe77912"},
+		}
+		repository.persistence["22d080de-4138-446f-acd4-d4c13fe77912"] = inputResource1
+		repository.persistence["22d080de-ffff-446f-acd4-d4c13fe77912"] = inputResource2
+		repository.persistence["22d080de-xxxx-446f-acd4-d4c13fe77912"] = inputResource3
+		path := "/jobs/_count"
+		rec := httptest.NewRecorder()
+
+		routes[path](rec, nil)
+
+		b, _ := ioutil.ReadAll(rec.Body)
+		var m struct{ Count int }
+		json.Unmarshal(b, &m)
+		Expect(m.Count).To(Equal(2))
+	})
+
+	It("should not find any jobs to count", func() {
+		repository.fail = true
+		path := "/jobs/_count"
+		rec := httptest.NewRecorder()
+
+		routes[path](rec, nil)
+
+		b, _ := ioutil.ReadAll(rec.Body)
+		Expect(string(b)).To(Equal("Resource not found"))
+	})
+
+	It("should create a cronJob", func() {
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		cronJob := domain.CronJob{ID: id}
+		body := new(bytes.Buffer)
+		json.NewEncoder(body).Encode(cronJob)
+		path := "/cronjobs/{id}"
+		req, _ := http.NewRequest(http.MethodPost, "", body)
+		rec := httptest.NewRecorder()
+
+		routes[path+"@POST"](rec, req)
+
+		b, _ := ioutil.ReadAll(rec.Body)
+		var srv domain.CronJob
+		json.Unmarshal(b, &srv)
+		resource := repository.persistence[id].(domain.Resource)
+		output := resource.GetK8sResource().(*domain.CronJob)
+		Expect(srv).To(Equal(*output))
+	})
+
+	It("should update a cronJob", func() {
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		cronJob := domain.CronJob{ID: id, Generation: 1}
+		resource := domain.Resource{K8sResource: &cronJob}
+		repository.persistence[id] = resource
+		newCronJob := domain.CronJob{ID: id, Generation: 2}
+		body := new(bytes.Buffer)
+		json.NewEncoder(body).Encode(newCronJob)
+		path := "/cronjobs/{id}"
+		req, _ := http.NewRequest(http.MethodPut, "/cronjobs/"+id, body)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		rec := httptest.NewRecorder()
+
+		routes[path+"@PUT"](rec, req)
+
+		newResource := domain.Resource{K8sResource: &newCronJob}
+		Expect(repository.persistence[id]).To(Equal(newResource))
+	})
+
+	It("should not update an non-existing cronjob", func() {
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		cronJob := domain.CronJob{ID: id, Generation: 1}
+		resource := domain.Resource{K8sResource: &cronJob}
+		repository.persistence[id] = resource
+		newCronJob := domain.CronJob{ID: "22d080de-4138-446f-acd4-d4c13fe779ff", Generation: 2}
+		body := new(bytes.Buffer)
+		json.NewEncoder(body).Encode(newCronJob)
+		path := "/cronjobs/{id}"
+		req, _ := http.NewRequest(http.MethodPut, "/cronjobs/"+id, body)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		rec := httptest.NewRecorder()
+
+		routes[path+"@PUT"](rec, req)
+
+		newResource := domain.Resource{K8sResource: &newCronJob}
+		Expect(repository.persistence[id]).NotTo(Equal(newResource))
+	})
+
+	It("should delete a cronjob", func() {
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		cronJob := domain.CronJob{ID: id}
+		resource := domain.Resource{K8sResource: &cronJob}
+		repository.persistence[id] = resource
+		path := "/cronjobs/{id}"
+		req, _ := http.NewRequest(http.MethodDelete, "/cronjobs/"+id, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		rec := httptest.NewRecorder()
+
+		routes[path+"@DELETE"](rec, req)
+
+		Expect(repository.persistence[id]).To(BeNil())
+	})
+
+	It("should not delete a non-existing cronjob", func() {
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		cronJob := domain.CronJob{ID: id}
+		resource := domain.Resource{K8sResource: &cronJob}
+		repository.persistence[id] = resource
+		nonExistingID := "3c665874-bc02-4691-981a-73fed8a12562"
+		path := "/cronjobs/{id}"
+		req, _ := http.NewRequest(http.MethodDelete, "/cronjobs/"+id, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": nonExistingID})
+		rec := httptest.NewRecorder()
+
+		routes[path+"@DELETE"](rec, req)
+
+		Expect(repository.persistence[nonExistingID]).To(BeNil())
+	})
+
+	It("should not delete cronjob when repository error", func() {
+		id := "22d080de-4138-446f-acd4-d4c13fe77912"
+		cronJob := domain.Deployment{ID: id}
+		resource := domain.Resource{K8sResource: &cronJob}
+		repository.persistence[id] = resource
+		repository.fail = true
+		path := "/cronjobs/{id}"
+		req, _ := http.NewRequest(http.MethodDelete, "/cronjobs/"+id, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		rec := httptest.NewRecorder()
+
+		routes[path+"@DELETE"](rec, req)
+
+		Expect(repository.persistence[id]).NotTo(BeNil())
+	})
+
+	It("should list all cronjobs", func() {
+		inputResource1 := domain.Resource{
+			K8sResource: &domain.CronJob{ID: "22d080de-4138-446f-acd4-d4c13fe77912"},
+		}
+		inputResource2 := domain.Resource{
+			K8sResource: &domain.Service{ID: "22d080de-ffff-446f-acd4-d4c13fe77912"},
+		}
+		inputResource3 := domain.Resource{
+			K8sResource: &domain.CronJob{ID: "22d080de-xxxx-446f-acd4-d4c13fe77912"},
+		}
+		repository.persistence["22d080de-4138-446f-acd4-d4c13fe77912"] = inputResource1
+		repository.persistence["22d080de-ffff-446f-acd4-d4c13fe77912"] = inputResource2
+		repository.persistence["22d080de-xxxx-446f-acd4-d4c13fe77912"] = inputResource3
+		path := "/cronjobs"
+		rec := httptest.NewRecorder()
+
+		routes[path](rec, nil)
+
+		b, _ := ioutil.ReadAll(rec.Body)
+		resources, err := utils.DeserializeResourceArray(b, reflect.TypeOf(domain.CronJob{}))
+		if err != nil {
+			fmt.Println(err)
+		}
+		for _, r := range resources {
+			Expect(*r).To(Equal(repository.persistence[r.GetID()]))
+		}
+	})
+
+	It("should not find any cronjobs to list", func() {
+		repository.fail = true
+		path := "/cronjobs"
+		rec := httptest.NewRecorder()
+
+		routes[path](rec, nil)
+
+		b, _ := ioutil.ReadAll(rec.Body)
+		Expect(string(b)).To(Equal("Resource not found"))
+	})
+
+	It("should count amount of cronjobs", func() {
+		inputResource1 := domain.Resource{
+			K8sResource: &domain.CronJob{ID: "22d080de-4138-446f-acd4-d4c13fe77912"},
+		}
+		inputResource2 := domain.Resource{
+			K8sResource: &domain.Service{ID: "22d080de-ffff-446f-acd4-d4c13fe77912"},
+		}
+		inputResource3 := domain.Resource{
+			K8sResource: &domain.CronJob{ID: "22d080de-xxxx-446f-acd4-d4c13fe77912"},
+		}
+		repository.persistence["22d080de-4138-446f-acd4-d4c13fe77912"] = inputResource1
+		repository.persistence["22d080de-ffff-446f-acd4-d4c13fe77912"] = inputResource2
+		repository.persistence["22d080de-xxxx-446f-acd4-d4c13fe77912"] = inputResource3
+		path := "/cronjobs/_count"
+		rec := httptest.NewRecorder()
+
+		routes[path](rec, nil)
+
+		b, _ := ioutil.ReadAll(rec.Body)
+		var m struct{ Count int }
+		json.Unmarshal(b, &m)
+		Expect(m.Count).To(Equal(2))
+	})
+
+	It("should not find any cronjobs to count", func() {
+		repository.fail = true
+		path := "/cronjobs/_count"
+		rec := httptest.NewRecorder()
+
+		routes[path](rec, nil)
+
+		b, _ := ioutil.ReadAll(rec.Body)
+		Expect(string(b)).To(Equal("Resource not found"))
+	})
+
 	AfterEach(func() {
 	})
 })
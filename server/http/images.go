@@ -0,0 +1,184 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	"github.com/walmartdigital/katalog/domain"
+)
+
+// ImageUsage is a distinct image with the workloads running it
+type ImageUsage struct {
+	Image string
+	domain.ImageReference
+	Namespaces []string
+	Workloads  []ImageWorkload
+}
+
+// ImageWorkload is a container of a workload running an image
+type ImageWorkload struct {
+	Kind      string
+	ID        string
+	Name      string
+	Namespace string
+	Cluster   string `json:",omitempty"`
+	Container string
+}
+
+// RegistryUsage sums up the images pulled from a registry
+type RegistryUsage struct {
+	Registry     string
+	Repositories []string
+	Images       []string
+	Workloads    int
+}
+
+// getImages lists the distinct images run by the workloads, sorted by image.
+// The cluster, registry and repository filters are ignored when empty, the
+// repository matching at any tag.
+func (s *Server) getImages(cluster string, registry string, repository string) ([]ImageUsage, error) {
+	resources, err := s.resourcesRepository.GetAllResources()
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make(map[string]*ImageUsage)
+	for _, r := range resources {
+		res := r.(domain.Resource)
+		workload, ok := res.K8sResource.(domain.Workload)
+		if !ok || (cluster != "" && res.GetCluster().Name != cluster) {
+			continue
+		}
+
+		for container, image := range domain.ImagesOf(workload) {
+			reference := domain.ParseImage(image)
+			if (registry != "" && reference.Registry != registry) || (repository != "" && !reference.MatchesRepository(repository)) {
+				continue
+			}
+
+			usage, ok := usages[reference.String()]
+			if !ok {
+				usage = &ImageUsage{Image: reference.String(), ImageReference: reference, Namespaces: make([]string, 0)}
+				usages[reference.String()] = usage
+			}
+			usage.Workloads = append(usage.Workloads, ImageWorkload{
+				Kind:      res.GetType().Elem().Name(),
+				ID:        res.GetID(),
+				Name:      res.GetName(),
+				Namespace: res.GetNamespace(),
+				Cluster:   res.GetCluster().Name,
+				Container: container,
+			})
+		}
+	}
+
+	list := make([]ImageUsage, 0, len(usages))
+	for _, usage := range usages {
+		namespaces := make(map[string]bool)
+		for _, workload := range usage.Workloads {
+			if !namespaces[workload.Namespace] {
+				namespaces[workload.Namespace] = true
+				usage.Namespaces = append(usage.Namespaces, workload.Namespace)
+			}
+		}
+		sort.Strings(usage.Namespaces)
+		sort.Slice(usage.Workloads, func(i, j int) bool {
+			a, b := usage.Workloads[i], usage.Workloads[j]
+			if a.Namespace != b.Namespace {
+				return a.Namespace < b.Namespace
+			}
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			return a.Container < b.Container
+		})
+		list = append(list, *usage)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Image < list[j].Image
+	})
+
+	return list, nil
+}
+
+// getRegistries groups the images run by the workloads by registry
+func (s *Server) getRegistries(cluster string) ([]RegistryUsage, error) {
+	images, err := s.getImages(cluster, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	registries := make(map[string]*RegistryUsage)
+	workloads := make(map[string]map[string]bool)
+	for _, image := range images {
+		usage, ok := registries[image.Registry]
+		if !ok {
+			usage = &RegistryUsage{Registry: image.Registry}
+			registries[image.Registry] = usage
+			workloads[image.Registry] = make(map[string]bool)
+		}
+		if !containsString(usage.Repositories, image.Repository) {
+			usage.Repositories = append(usage.Repositories, image.Repository)
+		}
+		usage.Images = append(usage.Images, image.Image)
+		for _, workload := range image.Workloads {
+			workloads[image.Registry][domain.BuildResourceKey(workload.Cluster, workload.ID)] = true
+		}
+	}
+
+	list := make([]RegistryUsage, 0, len(registries))
+	for registry, usage := range registries {
+		usage.Workloads = len(workloads[registry])
+		sort.Strings(usage.Repositories)
+		list = append(list, *usage)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Registry < list[j].Registry
+	})
+
+	return list, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) getAllImages(w http.ResponseWriter, r *http.Request) {
+	images, err := s.getImages(queryParam(r, "cluster"), queryParam(r, "registry"), queryParam(r, "repository"))
+	if err != nil {
+		fmt.Fprint(w, "Resource not found")
+		log.Error("Resource not found")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	errEncoding := json.NewEncoder(w).Encode(images)
+	if errEncoding != nil {
+		log.WithFields(logrus.Fields{
+			"msg": errEncoding.Error(),
+		}).Error("Getting images")
+	}
+}
+
+func (s *Server) getAllRegistries(w http.ResponseWriter, r *http.Request) {
+	registries, err := s.getRegistries(queryParam(r, "cluster"))
+	if err != nil {
+		fmt.Fprint(w, "Resource not found")
+		log.Error("Resource not found")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	errEncoding := json.NewEncoder(w).Encode(registries)
+	if errEncoding != nil {
+		log.WithFields(logrus.Fields{
+			"msg": errEncoding.Error(),
+		}).Error("Getting registries")
+	}
+}
@@ -0,0 +1,163 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/walmartdigital/katalog/domain"
+	"github.com/walmartdigital/katalog/server/persistence"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// QueryError is the body of the 400 answered to an invalid query parameter
+type QueryError struct {
+	Parameter string
+	Error     string
+}
+
+// invalidParameterError tells which query parameter could not be parsed
+type invalidParameterError struct {
+	parameter string
+	err       error
+}
+
+func (e invalidParameterError) Error() string {
+	return fmt.Sprintf("invalid %s: %v", e.parameter, e.err)
+}
+
+// resourceQuery filters the listed and counted resources. Every filter set
+// must match, the ones left out match any resource.
+type resourceQuery struct {
+	namespaces  []string
+	selector    labels.Selector
+	namePrefix  string
+	nameRegex   *regexp.Regexp
+	annotations []string
+	image       string
+}
+
+// parseResourceQuery reads the namespace and annotation parameters, which
+// may be repeated or comma separated, the label selector, the name prefix
+// and regular expression and the image substring
+func parseResourceQuery(r *http.Request) (resourceQuery, error) {
+	query := resourceQuery{selector: labels.Everything()}
+	if r == nil || r.URL == nil {
+		return query, nil
+	}
+	values := r.URL.Query()
+
+	query.namespaces = queryList(values["namespace"])
+	query.annotations = queryList(values["annotation"])
+	query.namePrefix = values.Get("namePrefix")
+	query.image = values.Get("image")
+
+	selector, err := labels.Parse(values.Get("selector"))
+	if err != nil {
+		return query, invalidParameterError{"selector", err}
+	}
+	query.selector = selector
+
+	if pattern := values.Get("nameRegex"); pattern != "" {
+		nameRegex, err := regexp.Compile(pattern)
+		if err != nil {
+			return query, invalidParameterError{"nameRegex", err}
+		}
+		query.nameRegex = nameRegex
+	}
+
+	return query, nil
+}
+
+// queryList splits the comma separated values of a repeated parameter
+func queryList(values []string) []string {
+	list := make([]string, 0)
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
+func (q resourceQuery) matches(res domain.Resource) bool {
+	if len(q.namespaces) > 0 && !containsString(q.namespaces, res.GetNamespace()) {
+		return false
+	}
+	if !q.selector.Matches(labels.Set(res.GetLabels())) {
+		return false
+	}
+	if !strings.HasPrefix(res.GetName(), q.namePrefix) {
+		return false
+	}
+	if q.nameRegex != nil && !q.nameRegex.MatchString(res.GetName()) {
+		return false
+	}
+	for _, annotation := range q.annotations {
+		if _, ok := res.GetAnnotations()[annotation]; !ok {
+			return false
+		}
+	}
+	return q.image == "" || runsImage(res, q.image)
+}
+
+// filter maps the query to the persistence filter of the kind, pushed down
+// to the persistences supporting it. Only a single namespace, the name prefix
+// and the label equalities map to it, matches still checks every resource.
+func (q resourceQuery) filter(kind domain.Kind) persistence.Filter {
+	filter := persistence.Filter{Kind: kind.Name, NamePrefix: q.namePrefix}
+	if len(q.namespaces) == 1 {
+		filter.Namespace = q.namespaces[0]
+	}
+
+	requirements, _ := q.selector.Requirements()
+	for _, requirement := range requirements {
+		operator := requirement.Operator()
+		if (operator != selection.Equals && operator != selection.DoubleEquals) || requirement.Values().Len() != 1 {
+			continue
+		}
+		if filter.Labels == nil {
+			filter.Labels = make(map[string]string)
+		}
+		filter.Labels[requirement.Key()] = requirement.Values().List()[0]
+	}
+
+	return filter
+}
+
+// runsImage tells whether a container of the workload runs an image
+// containing substring, resources without containers never do
+func runsImage(res domain.Resource, substring string) bool {
+	workload, ok := res.K8sResource.(domain.Workload)
+	if !ok {
+		return false
+	}
+	for _, image := range domain.ImagesOf(workload) {
+		if strings.Contains(image, substring) {
+			return true
+		}
+	}
+	return false
+}
+
+func writeQueryError(w http.ResponseWriter, err error) {
+	body := QueryError{Error: err.Error()}
+	if invalid, ok := err.(invalidParameterError); ok {
+		body = QueryError{Parameter: invalid.parameter, Error: invalid.err.Error()}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	errEncoding := json.NewEncoder(w).Encode(body)
+	if errEncoding != nil {
+		log.WithFields(logrus.Fields{
+			"msg": errEncoding.Error(),
+		}).Error("Encoding query error")
+	}
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	"github.com/walmartdigital/katalog/domain"
+)
+
+// DegradedWorkload ...
+type DegradedWorkload struct {
+	Kind      string
+	ID        string
+	Name      string
+	Namespace string
+	Cluster   string `json:",omitempty"`
+	Status    domain.RolloutStatus
+	Replicas  domain.Replicas
+}
+
+// getDegradedWorkloads lists the workloads whose rollout is degraded or
+// stalled, restricted to one cluster when cluster is not empty
+func (s *Server) getDegradedWorkloads(cluster string) ([]DegradedWorkload, error) {
+	resources, err := s.resourcesRepository.GetAllResources()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]DegradedWorkload, 0)
+	for _, r := range resources {
+		res := r.(domain.Resource)
+		workload, ok := res.K8sResource.(domain.RolledOut)
+		if !ok || (cluster != "" && res.GetCluster().Name != cluster) {
+			continue
+		}
+
+		status := workload.GetRolloutStatus()
+		if status.IsHealthy() {
+			continue
+		}
+
+		list = append(list, DegradedWorkload{
+			Kind:      res.GetType().Elem().Name(),
+			ID:        res.GetID(),
+			Name:      res.GetName(),
+			Namespace: res.GetNamespace(),
+			Cluster:   res.GetCluster().Name,
+			Status:    status,
+			Replicas:  *workload.GetReplicas(),
+		})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Namespace != list[j].Namespace {
+			return list[i].Namespace < list[j].Namespace
+		}
+		return list[i].Name < list[j].Name
+	})
+
+	return list, nil
+}
+
+func (s *Server) getAllDegradedWorkloads(w http.ResponseWriter, r *http.Request) {
+	workloads, err := s.getDegradedWorkloads(queryParam(r, "cluster"))
+	if err != nil {
+		fmt.Fprint(w, "Resource not found")
+		log.Error("Resource not found")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	errEncoding := json.NewEncoder(w).Encode(workloads)
+	if errEncoding != nil {
+		log.WithFields(logrus.Fields{
+			"msg": errEncoding.Error(),
+		}).Error("Getting degraded workloads")
+	}
+}
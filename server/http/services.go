@@ -3,16 +3,37 @@ package http
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 
 	"github.com/emirpasic/gods/lists/arraylist"
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 	"github.com/walmartdigital/katalog/domain"
+	"github.com/walmartdigital/katalog/server/persistence"
 )
 
-func (s *Server) getResourcesByType(resource domain.Resource) ([]interface{}, error) {
-	resources, err := s.resourcesRepository.GetAllResources()
+// queryParam reads a query string parameter, handlers may be invoked without
+// a request when called internally
+func queryParam(r *http.Request, name string) string {
+	if r == nil || r.URL == nil {
+		return ""
+	}
+	return r.URL.Query().Get(name)
+}
+
+// routeVars reads the route variables, nil safe like queryParam
+func routeVars(r *http.Request) map[string]string {
+	if r == nil {
+		return nil
+	}
+	return mux.Vars(r)
+}
+
+// getResourcesByType lists the resources of the given type matching the
+// filter, restricted to one cluster when cluster is not empty
+func (s *Server) getResourcesByType(resource domain.Resource, filter persistence.Filter, cluster string) ([]interface{}, error) {
+	resources, err := s.resourcesRepository.GetResourcesBy(filter)
 	if err != nil {
 		return nil, err
 	}
@@ -20,295 +41,150 @@ func (s *Server) getResourcesByType(resource domain.Resource) ([]interface{}, er
 	list := arraylist.New()
 	for _, r := range resources {
 		res := r.(domain.Resource)
-		if res.GetType() == resource.GetType() {
+		if res.GetType() == resource.GetType() && (cluster == "" || res.GetCluster().Name == cluster) {
 			list.Add(r)
 		}
 	}
 	return list.Values(), nil
 }
 
-// CreateService ...
-func (s *Server) CreateService(w http.ResponseWriter, r *http.Request) {
-	var service domain.Service
-	errDecoding := json.NewDecoder(r.Body).Decode(&service)
-	if errDecoding != nil {
-		log.WithFields(logrus.Fields{
-			"msg": errDecoding.Error(),
-		}).Error("Deserializing Service")
-	}
-
-	errCreating := s.service.CreateService(service)
-	if errCreating != nil {
-		log.WithFields(logrus.Fields{
-			"msg": errCreating.Error(),
-		}).Error("Creating Service")
-	}
-
-	errEncoding := json.NewEncoder(w).Encode(service)
-	if errEncoding != nil {
-		log.WithFields(logrus.Fields{
-			"msg": errEncoding.Error(),
-		}).Error("Encoding Service")
-	}
-}
-
-// UpdateService ...
-func (s *Server) UpdateService(w http.ResponseWriter, r *http.Request) {
-	var service domain.Service
-	errDecoding := json.NewDecoder(r.Body).Decode(&service)
-
-	if errDecoding != nil {
-		log.WithFields(logrus.Fields{
-			"msg": errDecoding.Error(),
-		}).Error("Deserializing Service")
-	}
-
-	errUpdating := s.service.UpdateService(service)
-	if errUpdating != nil {
-		log.WithFields(logrus.Fields{
-			"msg": errUpdating.Error(),
-		}).Error("Updating Service")
-	}
-
-	errEncoding := json.NewEncoder(w).Encode(service)
-	if errEncoding != nil {
-		log.WithFields(logrus.Fields{
-			"msg": errEncoding.Error(),
-		}).Error("Encoding Service")
-	}
-}
-
-// DeleteService ...
-func (s *Server) DeleteService(w http.ResponseWriter, r *http.Request) {
-	id := mux.Vars(r)["id"]
-
-	err := s.service.DeleteService(id)
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Error("Deleting Service")
-	}
-
-	fmt.Fprintf(w, "service id: %s", id)
-}
-
-func (s *Server) getAllServices(w http.ResponseWriter, r *http.Request) {
-	services, err := s.getResourcesByType(domain.Resource{K8sResource: &domain.Service{}})
-	if err != nil {
-		fmt.Fprint(w, "Resource not found")
-		log.Error("Resource not found")
-		return
-	}
-	w.Header().Set("Content-Type", "application/json")
-	errEncoding := json.NewEncoder(w).Encode(services)
-	if errEncoding != nil {
-		log.WithFields(logrus.Fields{
-			"msg": errEncoding.Error(),
-		}).Error("Getting all services")
-	}
-}
-
-func (s *Server) countServices(w http.ResponseWriter, r *http.Request) {
-	services, err := s.getResourcesByType(domain.Resource{K8sResource: &domain.Service{}})
+// getResourcesOfKind lists the resources of the kind matching the route
+// variables and the query of the request
+func (s *Server) getResourcesOfKind(kind domain.Kind, r *http.Request, query resourceQuery) ([]interface{}, error) {
+	resources, err := s.getResourcesByType(domain.Resource{K8sResource: kind.New()}, query.filter(kind), queryParam(r, "cluster"))
 	if err != nil {
-		fmt.Fprint(w, "Resource not found")
-		log.Error("Resource not found")
-		return
+		return resources, err
 	}
-	errEncode := json.NewEncoder(w).Encode(struct{ Count int }{len(services)})
-	if errEncode != nil {
-		log.WithFields(logrus.Fields{
-			"msg": errEncode.Error(),
-		}).Error("Counting all services")
-	}
-}
 
-// CreateDeployment ...
-func (s *Server) CreateDeployment(w http.ResponseWriter, r *http.Request) {
-	var deployment domain.Deployment
-	err := json.NewDecoder(r.Body).Decode(&deployment)
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Error("Deserializing Deployment")
-	}
-
-	err = s.service.CreateDeployment(deployment)
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Error("Creating Deployment")
-	}
-
-	errEncode := json.NewEncoder(w).Encode(deployment)
-	if errEncode != nil {
-		log.WithFields(logrus.Fields{
-			"msg": errEncode.Error(),
-		}).Error("Encoding Create Deployment")
+	vars := routeVars(r)
+	list := arraylist.New()
+	for _, res := range resources {
+		resource := res.(domain.Resource)
+		if (kind.Matches == nil || kind.Matches(resource.K8sResource, vars)) && query.matches(resource) {
+			list.Add(res)
+		}
 	}
+	return list.Values(), nil
 }
 
-// UpdateDeployment ...
-func (s *Server) UpdateDeployment(w http.ResponseWriter, r *http.Request) {
-	var deployment domain.Deployment
-	err := json.NewDecoder(r.Body).Decode(&deployment)
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Error("Deserializing Deployment")
-	}
-
-	err = s.service.UpdateDeployment(deployment)
+func decodeResource(kind domain.Kind, r *http.Request) (domain.Resource, error) {
+	body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Error("Updating Deployment")
-	}
-
-	errEncode := json.NewEncoder(w).Encode(deployment)
-	if errEncode != nil {
-		log.WithFields(logrus.Fields{
-			"msg": errEncode.Error(),
-		}).Error("Encoding Update Deployment")
+		return domain.Resource{K8sResource: kind.New()}, err
 	}
+	resource, err := kind.Decode(body)
+	return domain.Resource{K8sResource: resource}, err
 }
 
-// DeleteDeployment ...
-func (s *Server) DeleteDeployment(w http.ResponseWriter, r *http.Request) {
-	id := mux.Vars(r)["id"]
-
-	err := s.service.DeleteDeployment(id)
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Error("Deleting Deployment")
-	}
-
-	fmt.Fprintf(w, "deployment id: %s", id)
-}
+func (s *Server) createResource(kind domain.Kind) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resource, err := decodeResource(kind, r)
+		if err != nil {
+			log.WithFields(logrus.Fields{
+				"msg": err.Error(),
+			}).Errorf("Deserializing %s", kind.Name)
+		}
 
-func (s *Server) getAllDeployments(w http.ResponseWriter, r *http.Request) {
-	deployments, err := s.getResourcesByType(domain.Resource{K8sResource: &domain.Deployment{}})
-	if err != nil {
-		fmt.Fprint(w, "Resource not found")
-		log.Error("Resource not found")
-		return
-	}
-	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(deployments)
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Error("Getting all Deployments")
-	}
-}
+		err = s.service.CreateResource(kind, resource)
+		if err != nil {
+			log.WithFields(logrus.Fields{
+				"msg": err.Error(),
+			}).Errorf("Creating %s", kind.Name)
+		}
 
-func (s *Server) countDeployments(w http.ResponseWriter, r *http.Request) {
-	deployments, err := s.getResourcesByType(domain.Resource{K8sResource: &domain.Deployment{}})
-	if err != nil {
-		fmt.Fprint(w, "Resource not found")
-		log.Error("Resource not found")
-		return
-	}
-	err = json.NewEncoder(w).Encode(struct{ Count int }{len(deployments)})
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Error("Counting all Deployments")
+		errEncode := json.NewEncoder(w).Encode(resource.K8sResource)
+		if errEncode != nil {
+			log.WithFields(logrus.Fields{
+				"msg": errEncode.Error(),
+			}).Errorf("Encoding Create %s", kind.Name)
+		}
 	}
 }
 
-// CreateStatefulSet ...
-func (s *Server) CreateStatefulSet(w http.ResponseWriter, r *http.Request) {
-	var statefulset domain.StatefulSet
-	err := json.NewDecoder(r.Body).Decode(&statefulset)
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Error("Deserializing StatefulSet")
-	}
+func (s *Server) updateResource(kind domain.Kind) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resource, err := decodeResource(kind, r)
+		if err != nil {
+			log.WithFields(logrus.Fields{
+				"msg": err.Error(),
+			}).Errorf("Deserializing %s", kind.Name)
+		}
 
-	err = s.service.CreateStatefulSet(statefulset)
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Error("Creating StatefulSet")
-	}
+		err = s.service.UpdateResource(kind, resource)
+		if err != nil {
+			log.WithFields(logrus.Fields{
+				"msg": err.Error(),
+			}).Errorf("Updating %s", kind.Name)
+		}
 
-	errEncode := json.NewEncoder(w).Encode(statefulset)
-	if errEncode != nil {
-		log.WithFields(logrus.Fields{
-			"msg": errEncode.Error(),
-		}).Error("Encoding Create StatefulSet")
+		errEncode := json.NewEncoder(w).Encode(resource.K8sResource)
+		if errEncode != nil {
+			log.WithFields(logrus.Fields{
+				"msg": errEncode.Error(),
+			}).Errorf("Encoding Update %s", kind.Name)
+		}
 	}
 }
 
-// UpdateStatefulSet ...
-func (s *Server) UpdateStatefulSet(w http.ResponseWriter, r *http.Request) {
-	var statefulset domain.StatefulSet
-	err := json.NewDecoder(r.Body).Decode(&statefulset)
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Error("Deserializing StatefulSet")
-	}
+func (s *Server) deleteResource(kind domain.Kind) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := mux.Vars(r)["id"]
+		key := domain.BuildResourceKey(queryParam(r, "cluster"), id)
 
-	err = s.service.UpdateStatefulSet(statefulset)
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Error("Updating StatefulSet")
-	}
+		err := s.service.DeleteResource(kind, key)
+		if err != nil {
+			log.WithFields(logrus.Fields{
+				"msg": err.Error(),
+			}).Errorf("Deleting %s", kind.Name)
+		}
 
-	errEncode := json.NewEncoder(w).Encode(statefulset)
-	if errEncode != nil {
-		log.WithFields(logrus.Fields{
-			"msg": errEncode.Error(),
-		}).Error("Encoding Update StatefulSet")
+		fmt.Fprintf(w, "%s id: %s", kind.Label, id)
 	}
 }
 
-// DeleteStatefulSet ...
-func (s *Server) DeleteStatefulSet(w http.ResponseWriter, r *http.Request) {
-	id := mux.Vars(r)["id"]
+func (s *Server) listResources(kind domain.Kind) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query, err := parseResourceQuery(r)
+		if err != nil {
+			writeQueryError(w, err)
+			return
+		}
 
-	err := s.service.DeleteStatefulSet(id)
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Error("Deleting StatefulSet")
+		resources, err := s.getResourcesOfKind(kind, r, query)
+		if err != nil {
+			fmt.Fprint(w, "Resource not found")
+			log.Error("Resource not found")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(resources)
+		if err != nil {
+			log.WithFields(logrus.Fields{
+				"msg": err.Error(),
+			}).Errorf("Getting All %s", kind.Name)
+		}
 	}
-
-	fmt.Fprintf(w, "deployment id: %s", id)
 }
 
-func (s *Server) getAllStatefulSets(w http.ResponseWriter, r *http.Request) {
-	statefulsets, err := s.getResourcesByType(domain.Resource{K8sResource: &domain.StatefulSet{}})
-	if err != nil {
-		fmt.Fprint(w, "Resource not found")
-		log.Error("Resource not found")
-		return
-	}
-	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(statefulsets)
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Error("Getting All StatefulSet")
-	}
-}
+func (s *Server) countResources(kind domain.Kind) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query, err := parseResourceQuery(r)
+		if err != nil {
+			writeQueryError(w, err)
+			return
+		}
 
-func (s *Server) countStatefulSets(w http.ResponseWriter, r *http.Request) {
-	statefulsets, err := s.getResourcesByType(domain.Resource{K8sResource: &domain.StatefulSet{}})
-	if err != nil {
-		fmt.Fprint(w, "Resource not found")
-		log.Error("Resource not found")
-		return
-	}
-	err = json.NewEncoder(w).Encode(struct{ Count int }{len(statefulsets)})
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Error("Counting StatefulSet")
+		resources, err := s.getResourcesOfKind(kind, r, query)
+		if err != nil {
+			fmt.Fprint(w, "Resource not found")
+			log.Error("Resource not found")
+			return
+		}
+		err = json.NewEncoder(w).Encode(struct{ Count int }{len(resources)})
+		if err != nil {
+			log.WithFields(logrus.Fields{
+				"msg": err.Error(),
+			}).Errorf("Counting %s", kind.Name)
+		}
 	}
 }
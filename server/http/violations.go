@@ -0,0 +1,46 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/walmartdigital/katalog/server"
+)
+
+// getViolations lists the image policy violations, restricted by cluster,
+// namespace and rule when they are not empty
+func (s *Server) getViolations(cluster string, namespace string, rule string) ([]server.Violation, error) {
+	violations, err := s.service.GetViolations()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]server.Violation, 0)
+	for _, violation := range violations {
+		if (cluster != "" && violation.Cluster != cluster) ||
+			(namespace != "" && violation.Namespace != namespace) ||
+			(rule != "" && violation.Rule != rule) {
+			continue
+		}
+		list = append(list, violation)
+	}
+	return list, nil
+}
+
+func (s *Server) getAllViolations(w http.ResponseWriter, r *http.Request) {
+	violations, err := s.getViolations(queryParam(r, "cluster"), queryParam(r, "namespace"), queryParam(r, "rule"))
+	if err != nil {
+		fmt.Fprint(w, "Resource not found")
+		log.Error("Resource not found")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	errEncoding := json.NewEncoder(w).Encode(violations)
+	if errEncoding != nil {
+		log.WithFields(logrus.Fields{
+			"msg": errEncoding.Error(),
+		}).Error("Getting violations")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/sirupsen/logrus"
+	"github.com/walmartdigital/katalog/domain"
 	"github.com/walmartdigital/katalog/regex"
 	"github.com/walmartdigital/katalog/server"
 	"github.com/walmartdigital/katalog/utils"
@@ -45,6 +46,7 @@ type Consumer struct {
 	reader      Reader
 	context     context.Context
 	wg          *sync.WaitGroup
+	inflight    sync.WaitGroup
 	service     *server.Service
 }
 
@@ -61,10 +63,12 @@ func CreateConsumer(ctx context.Context, wg *sync.WaitGroup, kafkaURL string, to
 	}
 }
 
-// Run ...
+// Run consumes messages until the context is done, then waits for the
+// messages being processed before closing the reader
 func (c *Consumer) Run() {
 	defer c.wg.Done()
 	defer c.reader.Close()
+	defer c.inflight.Wait()
 
 	for {
 		select {
@@ -86,13 +90,23 @@ func (c *Consumer) Run() {
 			}).Debug("Message Received")
 
 			matchedNamedGroups := regex.GetParams(
-				"/(?P<artifact>.+)/(?P<id>.+)",
+				"/(?P<artifact>[^/]+)/(?P<id>.+)",
 				key,
 			)
 
 			artifact := matchedNamedGroups["artifact"]
 			id := matchedNamedGroups["id"]
 
+			kind, ok := domain.KindBySegment(artifact)
+			if !ok {
+				log.WithFields(logrus.Fields{
+					"event":    c.event,
+					"artifact": artifact,
+				}).Warn("Artifact not recognized")
+				continue
+			}
+			id = kind.KeyFromPath(id)
+
 			log.WithFields(logrus.Fields{
 				"event":    c.event,
 				"artifact": artifact,
@@ -101,47 +115,11 @@ func (c *Consumer) Run() {
 
 			switch c.event {
 			case "created":
-				switch artifact {
-				case "services":
-					go c.CreateService(value)
-				case "deployments":
-					go c.CreateDeployment(value)
-				case "statefulsets":
-					go c.CreateStatefulSet(value)
-				default:
-					log.WithFields(logrus.Fields{
-						"event":    c.event,
-						"artifact": artifact,
-					}).Warn("Artifact not recognized")
-				}
+				c.process(func() error { return c.CreateResource(kind, value) })
 			case "updated":
-				switch artifact {
-				case "services":
-					go c.UpdateService(value)
-				case "deployments":
-					go c.UpdateDeployment(value)
-				case "statefulsets":
-					go c.UpdateStatefulSet(value)
-				default:
-					log.WithFields(logrus.Fields{
-						"event":    c.event,
-						"artifact": artifact,
-					}).Warn("Artifact not recognized")
-				}
+				c.process(func() error { return c.UpdateResource(kind, value) })
 			case "deleted":
-				switch artifact {
-				case "services":
-					go c.DeleteService(id)
-				case "deployments":
-					go c.DeleteDeployment(id)
-				case "statefulsets":
-					go c.DeleteStatefulSet(id)
-				default:
-					log.WithFields(logrus.Fields{
-						"event":    c.event,
-						"artifact": artifact,
-					}).Warn("Artifact not recognized")
-				}
+				c.process(func() error { return c.DeleteResource(kind, id) })
 			default:
 				log.WithFields(logrus.Fields{
 					"event":    c.event,
@@ -157,3 +135,18 @@ func (c *Consumer) Run() {
 		}
 	}
 }
+
+// process handles a message in the background, keeping track of it so Run
+// can drain the messages in progress
+func (c *Consumer) process(handle func() error) {
+	c.inflight.Add(1)
+	go func() {
+		defer c.inflight.Done()
+		err := handle()
+		if err != nil {
+			log.WithFields(logrus.Fields{
+				"msg": err.Error(),
+			}).Debug("Processing message")
+		}
+	}()
+}
@@ -130,6 +130,48 @@ var _ = Describe("Run Consumer on 'created' topic", func() {
 		go consumer.Run()
 	})
 
+	It("should finish processing the messages in progress before closing the reader", func() {
+		ss := domain.Deployment{
+			ID:        "276797fa-b207-11e9-8527-000d3af9d6b6",
+			Name:      "queue-node",
+			Namespace: "amida",
+		}
+
+		ssbytes, _ := json.Marshal(ss)
+
+		message := kafgo.Message{
+			Topic: "_katalog.artifact.created",
+			Key:   []byte("/deployments/276797fa-b207-11e9-8527-000d3af9d6b6"),
+			Value: ssbytes,
+		}
+
+		resource := domain.Resource{K8sResource: &ss}
+		processed := false
+
+		fakeRepo.EXPECT().CreateResource(resource).Times(1).Do(
+			func(r domain.Resource) {
+				time.Sleep(100 * time.Millisecond)
+				processed = true
+			},
+		)
+		fakeReader.EXPECT().ReadMessage(ctx).Return(message, nil).Times(1).Do(
+			func(c context.Context) {
+				cancel()
+			},
+		)
+		fakeReader.EXPECT().Close().Times(1).Do(
+			func() {
+				Expect(processed).To(BeTrue())
+			},
+		)
+
+		wg.Add(1)
+		consumer.Run()
+		wg.Wait()
+
+		Expect(processed).To(BeTrue())
+	})
+
 	It("should create a StatefulSet", func() {
 		wg.Add(1)
 		defer wg.Wait()
@@ -183,7 +225,7 @@ var _ = Describe("Run Consumer on 'created' topic", func() {
 		go consumer.Run()
 	})
 
-	It("should create a Service", func() {
+	It("should create a DaemonSet", func() {
 		wg.Add(1)
 		defer wg.Wait()
 
@@ -191,15 +233,20 @@ var _ = Describe("Run Consumer on 'created' topic", func() {
 		testwg.Add(1)
 		defer testwg.Wait()
 
-		i := domain.Instance{Address: "hello"}
-		ss := domain.Service{
+		ss := domain.DaemonSet{
 			ID:         "276797fa-b207-11e9-8527-000d3af9d6b6",
 			Name:       "queue-node",
-			Port:       1212,
-			Address:    "someservice",
 			Generation: 7,
 			Namespace:  "amida",
-			Instances:  []domain.Instance{i},
+			Labels: map[string]string{
+				"HEAD":                   "569de2ecd9f9357b3380664f43c90d07ec6acaff",
+				"app":                    "nats",
+				"fluxcd.io/sync-gc-mark": "sha256.0fRlq9kqkh2eSDRqXANMzgN8_8jeguja3eDLoE5E0Xo",
+			},
+			Containers: map[string]string{
+				"nats-exporter":  "synadia/prometheus-nats-exporter:0.4.0",
+				"nats-streaming": "nats-streaming:0.15.1",
+			},
 		}
 
 		ssbytes, _ := json.Marshal(ss)
@@ -208,7 +255,7 @@ var _ = Describe("Run Consumer on 'created' topic", func() {
 			Topic:     "_katalog.artifact.created",
 			Partition: 1,
 			Offset:    5,
-			Key:       []byte("/services/276797fa-b207-11e9-8527-000d3af9d6b6"),
+			Key:       []byte("/daemonsets/276797fa-b207-11e9-8527-000d3af9d6b6"),
 			Value:     ssbytes,
 			Headers:   nil,
 			Time:      time.Now(),
@@ -231,59 +278,7 @@ var _ = Describe("Run Consumer on 'created' topic", func() {
 		go consumer.Run()
 	})
 
-	AfterEach(func() {
-	})
-})
-
-var _ = Describe("Run Consumer on 'updated' topic", func() {
-	var (
-		fakeReaderFactory  *mock_kafka.MockReaderFactory
-		fakeReader         *mock_kafka.MockReader
-		fakeRepoFactory    *mock_repositories.MockRepositoryFactory
-		fakeRepo           *mock_repositories.MockRepository
-		fakeMetricsFactory *mock_server.MockMetricsFactory
-		fakeMetrics        *mock_server.MockMetrics
-		upconsumer         *kafka.Consumer
-		ctx                context.Context
-		cancel             context.CancelFunc
-		wg                 *sync.WaitGroup
-		service            server.Service
-	)
-
-	BeforeEach(func() {
-		// Initialize the mocked Kafka related objects
-		fakeReaderFactory = mock_kafka.NewMockReaderFactory(ctrl)
-		fakeReader = mock_kafka.NewMockReader(ctrl)
-		fakeReaderFactory.EXPECT().Create(gomock.Any(), gomock.Any()).Return(
-			fakeReader,
-		).Times(1)
-
-		// Initialize the mocked Repository related objects
-		fakeRepoFactory = mock_repositories.NewMockRepositoryFactory(ctrl)
-		fakeRepo = mock_repositories.NewMockRepository(ctrl)
-		fakeRepoFactory.EXPECT().Create().Return(
-			fakeRepo,
-		).Times(1)
-
-		// Initialize the mocked Metrics related objects
-		fakeMetricsFactory = mock_server.NewMockMetricsFactory(ctrl)
-		fakeMetrics = mock_server.NewMockMetrics(ctrl)
-		fakeMetrics.EXPECT().IncrementCounter(gomock.Any(), gomock.Any()).AnyTimes()
-		fakeMetricsFactory.EXPECT().Create().Return(
-			fakeMetrics,
-		).Times(1)
-
-		ctx, cancel = context.WithCancel(context.Background())
-		service = server.MakeService(fakeRepoFactory.Create(), fakeMetricsFactory)
-		wg = new(sync.WaitGroup)
-		upconsumer = kafka.CreateConsumer(ctx, wg, "", "", "updated", fakeReaderFactory, &service)
-	})
-
-	It("should create a consumer", func() {
-		Expect(upconsumer).NotTo(BeNil())
-	})
-
-	It("should update a Deployment", func() {
+	It("should create a Job", func() {
 		wg.Add(1)
 		defer wg.Wait()
 
@@ -291,16 +286,15 @@ var _ = Describe("Run Consumer on 'updated' topic", func() {
 		testwg.Add(1)
 		defer testwg.Wait()
 
-		ss := domain.Deployment{
+		ss := domain.Job{
 			ID:         "276797fa-b207-11e9-8527-000d3af9d6b6",
 			Name:       "queue-node",
-			Generation: 8,
+			Generation: 7,
 			Namespace:  "amida",
 			Labels: map[string]string{
 				"HEAD":                   "569de2ecd9f9357b3380664f43c90d07ec6acaff",
 				"app":                    "nats",
 				"fluxcd.io/sync-gc-mark": "sha256.0fRlq9kqkh2eSDRqXANMzgN8_8jeguja3eDLoE5E0Xo",
-				"some":                   "change",
 			},
 			Containers: map[string]string{
 				"nats-exporter":  "synadia/prometheus-nats-exporter:0.4.0",
@@ -311,18 +305,19 @@ var _ = Describe("Run Consumer on 'updated' topic", func() {
 		ssbytes, _ := json.Marshal(ss)
 
 		message := kafgo.Message{
-			Topic:     "_katalog.artifact.updated",
+			Topic:     "_katalog.artifact.created",
 			Partition: 1,
 			Offset:    5,
-			Key:       []byte("/deployments/276797fa-b207-11e9-8527-000d3af9d6b6"),
+			Key:       []byte("/jobs/276797fa-b207-11e9-8527-000d3af9d6b6"),
 			Value:     ssbytes,
 			Headers:   nil,
 			Time:      time.Now(),
 		}
 
 		resource := domain.Resource{K8sResource: &ss}
+
 		fakeReader.EXPECT().Close().Times(1)
-		fakeRepo.EXPECT().UpdateResource(resource).Return(&resource, nil).Times(1).Do(
+		fakeRepo.EXPECT().CreateResource(resource).Times(1).Do(
 			func(r domain.Resource) {
 				testwg.Done()
 			},
@@ -332,10 +327,11 @@ var _ = Describe("Run Consumer on 'updated' topic", func() {
 				cancel()
 			},
 		)
-		go upconsumer.Run()
+		Expect(consumer).NotTo(BeNil())
+		go consumer.Run()
 	})
 
-	It("should update a Statefulset", func() {
+	It("should create a CronJob", func() {
 		wg.Add(1)
 		defer wg.Wait()
 
@@ -343,16 +339,15 @@ var _ = Describe("Run Consumer on 'updated' topic", func() {
 		testwg.Add(1)
 		defer testwg.Wait()
 
-		ss := domain.StatefulSet{
+		ss := domain.CronJob{
 			ID:         "276797fa-b207-11e9-8527-000d3af9d6b6",
 			Name:       "queue-node",
-			Generation: 8,
+			Generation: 7,
 			Namespace:  "amida",
 			Labels: map[string]string{
 				"HEAD":                   "569de2ecd9f9357b3380664f43c90d07ec6acaff",
 				"app":                    "nats",
 				"fluxcd.io/sync-gc-mark": "sha256.0fRlq9kqkh2eSDRqXANMzgN8_8jeguja3eDLoE5E0Xo",
-				"some":                   "change",
 			},
 			Containers: map[string]string{
 				"nats-exporter":  "synadia/prometheus-nats-exporter:0.4.0",
@@ -363,10 +358,10 @@ var _ = Describe("Run Consumer on 'updated' topic", func() {
 		ssbytes, _ := json.Marshal(ss)
 
 		message := kafgo.Message{
-			Topic:     "_katalog.artifact.updated",
+			Topic:     "_katalog.artifact.created",
 			Partition: 1,
 			Offset:    5,
-			Key:       []byte("/statefulsets/276797fa-b207-11e9-8527-000d3af9d6b6"),
+			Key:       []byte("/cronjobs/276797fa-b207-11e9-8527-000d3af9d6b6"),
 			Value:     ssbytes,
 			Headers:   nil,
 			Time:      time.Now(),
@@ -375,7 +370,7 @@ var _ = Describe("Run Consumer on 'updated' topic", func() {
 		resource := domain.Resource{K8sResource: &ss}
 
 		fakeReader.EXPECT().Close().Times(1)
-		fakeRepo.EXPECT().UpdateResource(resource).Return(&resource, nil).Times(1).Do(
+		fakeRepo.EXPECT().CreateResource(resource).Times(1).Do(
 			func(r domain.Resource) {
 				testwg.Done()
 			},
@@ -385,10 +380,11 @@ var _ = Describe("Run Consumer on 'updated' topic", func() {
 				cancel()
 			},
 		)
-		go upconsumer.Run()
+		Expect(consumer).NotTo(BeNil())
+		go consumer.Run()
 	})
 
-	It("should update a Service", func() {
+	It("should create a Service", func() {
 		wg.Add(1)
 		defer wg.Wait()
 
@@ -410,7 +406,7 @@ var _ = Describe("Run Consumer on 'updated' topic", func() {
 		ssbytes, _ := json.Marshal(ss)
 
 		message := kafgo.Message{
-			Topic:     "_katalog.artifact.updated",
+			Topic:     "_katalog.artifact.created",
 			Partition: 1,
 			Offset:    5,
 			Key:       []byte("/services/276797fa-b207-11e9-8527-000d3af9d6b6"),
@@ -422,7 +418,7 @@ var _ = Describe("Run Consumer on 'updated' topic", func() {
 		resource := domain.Resource{K8sResource: &ss}
 
 		fakeReader.EXPECT().Close().Times(1)
-		fakeRepo.EXPECT().UpdateResource(resource).Return(&resource, nil).Times(1).Do(
+		fakeRepo.EXPECT().CreateResource(resource).Times(1).Do(
 			func(r domain.Resource) {
 				testwg.Done()
 			},
@@ -432,14 +428,15 @@ var _ = Describe("Run Consumer on 'updated' topic", func() {
 				cancel()
 			},
 		)
-		go upconsumer.Run()
+		Expect(consumer).NotTo(BeNil())
+		go consumer.Run()
 	})
 
 	AfterEach(func() {
 	})
 })
 
-var _ = Describe("Run Consumer on 'deleted' topic", func() {
+var _ = Describe("Run Consumer on 'updated' topic", func() {
 	var (
 		fakeReaderFactory  *mock_kafka.MockReaderFactory
 		fakeReader         *mock_kafka.MockReader
@@ -447,7 +444,7 @@ var _ = Describe("Run Consumer on 'deleted' topic", func() {
 		fakeRepo           *mock_repositories.MockRepository
 		fakeMetricsFactory *mock_server.MockMetricsFactory
 		fakeMetrics        *mock_server.MockMetrics
-		consumer           *kafka.Consumer
+		upconsumer         *kafka.Consumer
 		ctx                context.Context
 		cancel             context.CancelFunc
 		wg                 *sync.WaitGroup
@@ -480,14 +477,14 @@ var _ = Describe("Run Consumer on 'deleted' topic", func() {
 		ctx, cancel = context.WithCancel(context.Background())
 		service = server.MakeService(fakeRepoFactory.Create(), fakeMetricsFactory)
 		wg = new(sync.WaitGroup)
-		consumer = kafka.CreateConsumer(ctx, wg, "", "", "deleted", fakeReaderFactory, &service)
+		upconsumer = kafka.CreateConsumer(ctx, wg, "", "", "updated", fakeReaderFactory, &service)
 	})
 
 	It("should create a consumer", func() {
-		Expect(consumer).NotTo(BeNil())
+		Expect(upconsumer).NotTo(BeNil())
 	})
 
-	It("should delete a Deployment", func() {
+	It("should update a Deployment", func() {
 		wg.Add(1)
 		defer wg.Wait()
 
@@ -498,12 +495,13 @@ var _ = Describe("Run Consumer on 'deleted' topic", func() {
 		ss := domain.Deployment{
 			ID:         "276797fa-b207-11e9-8527-000d3af9d6b6",
 			Name:       "queue-node",
-			Generation: 7,
+			Generation: 8,
 			Namespace:  "amida",
 			Labels: map[string]string{
 				"HEAD":                   "569de2ecd9f9357b3380664f43c90d07ec6acaff",
 				"app":                    "nats",
 				"fluxcd.io/sync-gc-mark": "sha256.0fRlq9kqkh2eSDRqXANMzgN8_8jeguja3eDLoE5E0Xo",
+				"some":                   "change",
 			},
 			Containers: map[string]string{
 				"nats-exporter":  "synadia/prometheus-nats-exporter:0.4.0",
@@ -514,7 +512,7 @@ var _ = Describe("Run Consumer on 'deleted' topic", func() {
 		ssbytes, _ := json.Marshal(ss)
 
 		message := kafgo.Message{
-			Topic:     "_katalog.artifact.deleted",
+			Topic:     "_katalog.artifact.updated",
 			Partition: 1,
 			Offset:    5,
 			Key:       []byte("/deployments/276797fa-b207-11e9-8527-000d3af9d6b6"),
@@ -524,12 +522,9 @@ var _ = Describe("Run Consumer on 'deleted' topic", func() {
 		}
 
 		resource := domain.Resource{K8sResource: &ss}
-		id := "276797fa-b207-11e9-8527-000d3af9d6b6"
-
 		fakeReader.EXPECT().Close().Times(1)
-		fakeRepo.EXPECT().GetResource(id).Return(resource, nil).Times(1)
-		fakeRepo.EXPECT().DeleteResource(id).Return(nil).Times(1).Do(
-			func(id string) {
+		fakeRepo.EXPECT().UpdateResource(resource).Return(&resource, nil).Times(1).Do(
+			func(r domain.Resource) {
 				testwg.Done()
 			},
 		)
@@ -538,10 +533,10 @@ var _ = Describe("Run Consumer on 'deleted' topic", func() {
 				cancel()
 			},
 		)
-		go consumer.Run()
+		go upconsumer.Run()
 	})
 
-	It("should delete a Statefulset", func() {
+	It("should update a Statefulset", func() {
 		wg.Add(1)
 		defer wg.Wait()
 
@@ -552,12 +547,13 @@ var _ = Describe("Run Consumer on 'deleted' topic", func() {
 		ss := domain.StatefulSet{
 			ID:         "276797fa-b207-11e9-8527-000d3af9d6b6",
 			Name:       "queue-node",
-			Generation: 7,
+			Generation: 8,
 			Namespace:  "amida",
 			Labels: map[string]string{
 				"HEAD":                   "569de2ecd9f9357b3380664f43c90d07ec6acaff",
 				"app":                    "nats",
 				"fluxcd.io/sync-gc-mark": "sha256.0fRlq9kqkh2eSDRqXANMzgN8_8jeguja3eDLoE5E0Xo",
+				"some":                   "change",
 			},
 			Containers: map[string]string{
 				"nats-exporter":  "synadia/prometheus-nats-exporter:0.4.0",
@@ -568,7 +564,7 @@ var _ = Describe("Run Consumer on 'deleted' topic", func() {
 		ssbytes, _ := json.Marshal(ss)
 
 		message := kafgo.Message{
-			Topic:     "_katalog.artifact.deleted",
+			Topic:     "_katalog.artifact.updated",
 			Partition: 1,
 			Offset:    5,
 			Key:       []byte("/statefulsets/276797fa-b207-11e9-8527-000d3af9d6b6"),
@@ -577,6 +573,627 @@ var _ = Describe("Run Consumer on 'deleted' topic", func() {
 			Time:      time.Now(),
 		}
 
+		resource := domain.Resource{K8sResource: &ss}
+
+		fakeReader.EXPECT().Close().Times(1)
+		fakeRepo.EXPECT().UpdateResource(resource).Return(&resource, nil).Times(1).Do(
+			func(r domain.Resource) {
+				testwg.Done()
+			},
+		)
+		fakeReader.EXPECT().ReadMessage(ctx).Return(message, nil).Times(1).Do(
+			func(c context.Context) {
+				cancel()
+			},
+		)
+		go upconsumer.Run()
+	})
+
+	It("should update a Daemonset", func() {
+		wg.Add(1)
+		defer wg.Wait()
+
+		var testwg sync.WaitGroup
+		testwg.Add(1)
+		defer testwg.Wait()
+
+		ss := domain.DaemonSet{
+			ID:         "276797fa-b207-11e9-8527-000d3af9d6b6",
+			Name:       "queue-node",
+			Generation: 8,
+			Namespace:  "amida",
+			Labels: map[string]string{
+				"HEAD":                   "569de2ecd9f9357b3380664f43c90d07ec6acaff",
+				"app":                    "nats",
+				"fluxcd.io/sync-gc-mark": "sha256.0fRlq9kqkh2eSDRqXANMzgN8_8jeguja3eDLoE5E0Xo",
+				"some":                   "change",
+			},
+			Containers: map[string]string{
+				"nats-exporter":  "synadia/prometheus-nats-exporter:0.4.0",
+				"nats-streaming": "nats-streaming:0.15.1",
+			},
+		}
+
+		ssbytes, _ := json.Marshal(ss)
+
+		message := kafgo.Message{
+			Topic:     "_katalog.artifact.updated",
+			Partition: 1,
+			Offset:    5,
+			Key:       []byte("/daemonsets/276797fa-b207-11e9-8527-000d3af9d6b6"),
+			Value:     ssbytes,
+			Headers:   nil,
+			Time:      time.Now(),
+		}
+
+		resource := domain.Resource{K8sResource: &ss}
+
+		fakeReader.EXPECT().Close().Times(1)
+		fakeRepo.EXPECT().UpdateResource(resource).Return(&resource, nil).Times(1).Do(
+			func(r domain.Resource) {
+				testwg.Done()
+			},
+		)
+		fakeReader.EXPECT().ReadMessage(ctx).Return(message, nil).Times(1).Do(
+			func(c context.Context) {
+				cancel()
+			},
+		)
+		go upconsumer.Run()
+	})
+
+	It("should update a Job", func() {
+		wg.Add(1)
+		defer wg.Wait()
+
+		var testwg sync.WaitGroup
+		testwg.Add(1)
+		defer testwg.Wait()
+
+		ss := domain.Job{
+			ID:         "276797fa-b207-11e9-8527-000d3af9d6b6",
+			Name:       "queue-node",
+			Generation: 8,
+			Namespace:  "amida",
+			Labels: map[string]string{
+				"HEAD":                   "569de2ecd9f9357b3380664f43c90d07ec6acaff",
+				"app":                    "nats",
+				"fluxcd.io/sync-gc-mark": "sha256.0fRlq9kqkh2eSDRqXANMzgN8_8jeguja3eDLoE5E0Xo",
+				"some":                   "change",
+			},
+			Containers: map[string]string{
+				"nats-exporter":  "synadia/prometheus-nats-exporter:0.4.0",
+				"nats-streaming": "nats-streaming:0.15.1",
+			},
+		}
+
+		ssbytes, _ := json.Marshal(ss)
+
+		message := kafgo.Message{
+			Topic:     "_katalog.artifact.updated",
+			Partition: 1,
+			Offset:    5,
+			Key:       []byte("/jobs/276797fa-b207-11e9-8527-000d3af9d6b6"),
+			Value:     ssbytes,
+			Headers:   nil,
+			Time:      time.Now(),
+		}
+
+		resource := domain.Resource{K8sResource: &ss}
+
+		fakeReader.EXPECT().Close().Times(1)
+		fakeRepo.EXPECT().UpdateResource(resource).Return(&resource, nil).Times(1).Do(
+			func(r domain.Resource) {
+				testwg.Done()
+			},
+		)
+		fakeReader.EXPECT().ReadMessage(ctx).Return(message, nil).Times(1).Do(
+			func(c context.Context) {
+				cancel()
+			},
+		)
+		go upconsumer.Run()
+	})
+
+	It("should update a Cronjob", func() {
+		wg.Add(1)
+		defer wg.Wait()
+
+		var testwg sync.WaitGroup
+		testwg.Add(1)
+		defer testwg.Wait()
+
+		ss := domain.CronJob{
+			ID:         "276797fa-b207-11e9-8527-000d3af9d6b6",
+			Name:       "queue-node",
+			Generation: 8,
+			Namespace:  "amida",
+			Labels: map[string]string{
+				"HEAD":                   "569de2ecd9f9357b3380664f43c90d07ec6acaff",
+				"app":                    "nats",
+				"fluxcd.io/sync-gc-mark": "sha256.0fRlq9kqkh2eSDRqXANMzgN8_8jeguja3eDLoE5E0Xo",
+				"some":                   "change",
+			},
+			Containers: map[string]string{
+				"nats-exporter":  "synadia/prometheus-nats-exporter:0.4.0",
+				"nats-streaming": "nats-streaming:0.15.1",
+			},
+		}
+
+		ssbytes, _ := json.Marshal(ss)
+
+		message := kafgo.Message{
+			Topic:     "_katalog.artifact.updated",
+			Partition: 1,
+			Offset:    5,
+			Key:       []byte("/cronjobs/276797fa-b207-11e9-8527-000d3af9d6b6"),
+			Value:     ssbytes,
+			Headers:   nil,
+			Time:      time.Now(),
+		}
+
+		resource := domain.Resource{K8sResource: &ss}
+
+		fakeReader.EXPECT().Close().Times(1)
+		fakeRepo.EXPECT().UpdateResource(resource).Return(&resource, nil).Times(1).Do(
+			func(r domain.Resource) {
+				testwg.Done()
+			},
+		)
+		fakeReader.EXPECT().ReadMessage(ctx).Return(message, nil).Times(1).Do(
+			func(c context.Context) {
+				cancel()
+			},
+		)
+		go upconsumer.Run()
+	})
+
+	It("should update a Service", func() {
+		wg.Add(1)
+		defer wg.Wait()
+
+		var testwg sync.WaitGroup
+		testwg.Add(1)
+		defer testwg.Wait()
+
+		i := domain.Instance{Address: "hello"}
+		ss := domain.Service{
+			ID:         "276797fa-b207-11e9-8527-000d3af9d6b6",
+			Name:       "queue-node",
+			Port:       1212,
+			Address:    "someservice",
+			Generation: 7,
+			Namespace:  "amida",
+			Instances:  []domain.Instance{i},
+		}
+
+		ssbytes, _ := json.Marshal(ss)
+
+		message := kafgo.Message{
+			Topic:     "_katalog.artifact.updated",
+			Partition: 1,
+			Offset:    5,
+			Key:       []byte("/services/276797fa-b207-11e9-8527-000d3af9d6b6"),
+			Value:     ssbytes,
+			Headers:   nil,
+			Time:      time.Now(),
+		}
+
+		resource := domain.Resource{K8sResource: &ss}
+
+		fakeReader.EXPECT().Close().Times(1)
+		fakeRepo.EXPECT().UpdateResource(resource).Return(&resource, nil).Times(1).Do(
+			func(r domain.Resource) {
+				testwg.Done()
+			},
+		)
+		fakeReader.EXPECT().ReadMessage(ctx).Return(message, nil).Times(1).Do(
+			func(c context.Context) {
+				cancel()
+			},
+		)
+		go upconsumer.Run()
+	})
+
+	AfterEach(func() {
+	})
+})
+
+var _ = Describe("Run Consumer on 'deleted' topic", func() {
+	var (
+		fakeReaderFactory  *mock_kafka.MockReaderFactory
+		fakeReader         *mock_kafka.MockReader
+		fakeRepoFactory    *mock_repositories.MockRepositoryFactory
+		fakeRepo           *mock_repositories.MockRepository
+		fakeMetricsFactory *mock_server.MockMetricsFactory
+		fakeMetrics        *mock_server.MockMetrics
+		consumer           *kafka.Consumer
+		ctx                context.Context
+		cancel             context.CancelFunc
+		wg                 *sync.WaitGroup
+		service            server.Service
+	)
+
+	BeforeEach(func() {
+		// Initialize the mocked Kafka related objects
+		fakeReaderFactory = mock_kafka.NewMockReaderFactory(ctrl)
+		fakeReader = mock_kafka.NewMockReader(ctrl)
+		fakeReaderFactory.EXPECT().Create(gomock.Any(), gomock.Any()).Return(
+			fakeReader,
+		).Times(1)
+
+		// Initialize the mocked Repository related objects
+		fakeRepoFactory = mock_repositories.NewMockRepositoryFactory(ctrl)
+		fakeRepo = mock_repositories.NewMockRepository(ctrl)
+		fakeRepoFactory.EXPECT().Create().Return(
+			fakeRepo,
+		).Times(1)
+
+		// Initialize the mocked Metrics related objects
+		fakeMetricsFactory = mock_server.NewMockMetricsFactory(ctrl)
+		fakeMetrics = mock_server.NewMockMetrics(ctrl)
+		fakeMetrics.EXPECT().IncrementCounter(gomock.Any(), gomock.Any()).AnyTimes()
+		fakeMetricsFactory.EXPECT().Create().Return(
+			fakeMetrics,
+		).Times(1)
+
+		ctx, cancel = context.WithCancel(context.Background())
+		service = server.MakeService(fakeRepoFactory.Create(), fakeMetricsFactory)
+		wg = new(sync.WaitGroup)
+		consumer = kafka.CreateConsumer(ctx, wg, "", "", "deleted", fakeReaderFactory, &service)
+	})
+
+	It("should create a consumer", func() {
+		Expect(consumer).NotTo(BeNil())
+	})
+
+	It("should delete a Deployment", func() {
+		wg.Add(1)
+		defer wg.Wait()
+
+		var testwg sync.WaitGroup
+		testwg.Add(1)
+		defer testwg.Wait()
+
+		ss := domain.Deployment{
+			ID:         "276797fa-b207-11e9-8527-000d3af9d6b6",
+			Name:       "queue-node",
+			Generation: 7,
+			Namespace:  "amida",
+			Labels: map[string]string{
+				"HEAD":                   "569de2ecd9f9357b3380664f43c90d07ec6acaff",
+				"app":                    "nats",
+				"fluxcd.io/sync-gc-mark": "sha256.0fRlq9kqkh2eSDRqXANMzgN8_8jeguja3eDLoE5E0Xo",
+			},
+			Containers: map[string]string{
+				"nats-exporter":  "synadia/prometheus-nats-exporter:0.4.0",
+				"nats-streaming": "nats-streaming:0.15.1",
+			},
+		}
+
+		ssbytes, _ := json.Marshal(ss)
+
+		message := kafgo.Message{
+			Topic:     "_katalog.artifact.deleted",
+			Partition: 1,
+			Offset:    5,
+			Key:       []byte("/deployments/276797fa-b207-11e9-8527-000d3af9d6b6"),
+			Value:     ssbytes,
+			Headers:   nil,
+			Time:      time.Now(),
+		}
+
+		resource := domain.Resource{K8sResource: &ss}
+		id := "276797fa-b207-11e9-8527-000d3af9d6b6"
+
+		fakeReader.EXPECT().Close().Times(1)
+		fakeRepo.EXPECT().GetResource(id).Return(resource, nil).Times(1)
+		fakeRepo.EXPECT().DeleteResource(id).Return(nil).Times(1).Do(
+			func(id string) {
+				testwg.Done()
+			},
+		)
+		fakeReader.EXPECT().ReadMessage(ctx).Return(message, nil).Times(1).Do(
+			func(c context.Context) {
+				cancel()
+			},
+		)
+		go consumer.Run()
+	})
+
+	It("should delete a Deployment of a named cluster", func() {
+		wg.Add(1)
+		defer wg.Wait()
+
+		var testwg sync.WaitGroup
+		testwg.Add(1)
+		defer testwg.Wait()
+
+		ss := domain.Deployment{
+			ID:        "276797fa-b207-11e9-8527-000d3af9d6b6",
+			Name:      "queue-node",
+			Namespace: "amida",
+			Cluster:   &domain.Cluster{Name: "east"},
+		}
+
+		ssbytes, _ := json.Marshal(ss)
+
+		message := kafgo.Message{
+			Topic:     "_katalog.artifact.deleted",
+			Partition: 1,
+			Offset:    5,
+			Key:       []byte("/deployments/east/276797fa-b207-11e9-8527-000d3af9d6b6"),
+			Value:     ssbytes,
+			Headers:   nil,
+			Time:      time.Now(),
+		}
+
+		resource := domain.Resource{K8sResource: &ss}
+		key := "east/276797fa-b207-11e9-8527-000d3af9d6b6"
+
+		fakeReader.EXPECT().Close().Times(1)
+		fakeRepo.EXPECT().GetResource(key).Return(resource, nil).Times(1)
+		fakeRepo.EXPECT().DeleteResource(key).Return(nil).Times(1).Do(
+			func(id string) {
+				testwg.Done()
+			},
+		)
+		fakeReader.EXPECT().ReadMessage(ctx).Return(message, nil).Times(1).Do(
+			func(c context.Context) {
+				cancel()
+			},
+		)
+		go consumer.Run()
+	})
+
+	It("should delete a custom resource keyed by group and kind", func() {
+		wg.Add(1)
+		defer wg.Wait()
+
+		var testwg sync.WaitGroup
+		testwg.Add(1)
+		defer testwg.Wait()
+
+		cr := domain.CustomResource{
+			ID:        "8d3d9c1a-3e2b-4a8f-9c43-5f1d7a2b6e01",
+			Name:      "checkout",
+			Namespace: "amida",
+			Group:     "argoproj.io",
+			Kind:      "Rollout",
+			Cluster:   &domain.Cluster{Name: "east"},
+		}
+
+		crbytes, _ := json.Marshal(cr)
+
+		message := kafgo.Message{
+			Topic:     "_katalog.artifact.deleted",
+			Partition: 1,
+			Offset:    5,
+			Key:       []byte("/resources/argoproj.io/Rollout/east/8d3d9c1a-3e2b-4a8f-9c43-5f1d7a2b6e01"),
+			Value:     crbytes,
+			Headers:   nil,
+			Time:      time.Now(),
+		}
+
+		resource := domain.Resource{K8sResource: &cr}
+		key := "east/8d3d9c1a-3e2b-4a8f-9c43-5f1d7a2b6e01"
+
+		fakeReader.EXPECT().Close().Times(1)
+		fakeRepo.EXPECT().GetResource(key).Return(resource, nil).Times(1)
+		fakeRepo.EXPECT().DeleteResource(key).Return(nil).Times(1).Do(
+			func(id string) {
+				testwg.Done()
+			},
+		)
+		fakeReader.EXPECT().ReadMessage(ctx).Return(message, nil).Times(1).Do(
+			func(c context.Context) {
+				cancel()
+			},
+		)
+		go consumer.Run()
+	})
+
+	It("should delete a Statefulset", func() {
+		wg.Add(1)
+		defer wg.Wait()
+
+		var testwg sync.WaitGroup
+		testwg.Add(1)
+		defer testwg.Wait()
+
+		ss := domain.StatefulSet{
+			ID:         "276797fa-b207-11e9-8527-000d3af9d6b6",
+			Name:       "queue-node",
+			Generation: 7,
+			Namespace:  "amida",
+			Labels: map[string]string{
+				"HEAD":                   "569de2ecd9f9357b3380664f43c90d07ec6acaff",
+				"app":                    "nats",
+				"fluxcd.io/sync-gc-mark": "sha256.0fRlq9kqkh2eSDRqXANMzgN8_8jeguja3eDLoE5E0Xo",
+			},
+			Containers: map[string]string{
+				"nats-exporter":  "synadia/prometheus-nats-exporter:0.4.0",
+				"nats-streaming": "nats-streaming:0.15.1",
+			},
+		}
+
+		ssbytes, _ := json.Marshal(ss)
+
+		message := kafgo.Message{
+			Topic:     "_katalog.artifact.deleted",
+			Partition: 1,
+			Offset:    5,
+			Key:       []byte("/statefulsets/276797fa-b207-11e9-8527-000d3af9d6b6"),
+			Value:     ssbytes,
+			Headers:   nil,
+			Time:      time.Now(),
+		}
+
+		resource := domain.Resource{K8sResource: &ss}
+		id := "276797fa-b207-11e9-8527-000d3af9d6b6"
+
+		fakeReader.EXPECT().Close().Times(1)
+		fakeRepo.EXPECT().GetResource(id).Return(resource, nil).Times(1)
+		fakeRepo.EXPECT().DeleteResource(id).Return(nil).Times(1).Do(
+			func(id string) {
+				testwg.Done()
+			},
+		)
+
+		fakeReader.EXPECT().ReadMessage(ctx).Return(message, nil).Times(1).Do(
+			func(c context.Context) {
+				cancel()
+			},
+		)
+		go consumer.Run()
+	})
+
+	It("should delete a Daemonset", func() {
+		wg.Add(1)
+		defer wg.Wait()
+
+		var testwg sync.WaitGroup
+		testwg.Add(1)
+		defer testwg.Wait()
+
+		ss := domain.DaemonSet{
+			ID:         "276797fa-b207-11e9-8527-000d3af9d6b6",
+			Name:       "queue-node",
+			Generation: 7,
+			Namespace:  "amida",
+			Labels: map[string]string{
+				"HEAD":                   "569de2ecd9f9357b3380664f43c90d07ec6acaff",
+				"app":                    "nats",
+				"fluxcd.io/sync-gc-mark": "sha256.0fRlq9kqkh2eSDRqXANMzgN8_8jeguja3eDLoE5E0Xo",
+			},
+			Containers: map[string]string{
+				"nats-exporter":  "synadia/prometheus-nats-exporter:0.4.0",
+				"nats-streaming": "nats-streaming:0.15.1",
+			},
+		}
+
+		ssbytes, _ := json.Marshal(ss)
+
+		message := kafgo.Message{
+			Topic:     "_katalog.artifact.deleted",
+			Partition: 1,
+			Offset:    5,
+			Key:       []byte("/daemonsets/276797fa-b207-11e9-8527-000d3af9d6b6"),
+			Value:     ssbytes,
+			Headers:   nil,
+			Time:      time.Now(),
+		}
+
+		resource := domain.Resource{K8sResource: &ss}
+		id := "276797fa-b207-11e9-8527-000d3af9d6b6"
+
+		fakeReader.EXPECT().Close().Times(1)
+		fakeRepo.EXPECT().GetResource(id).Return(resource, nil).Times(1)
+		fakeRepo.EXPECT().DeleteResource(id).Return(nil).Times(1).Do(
+			func(id string) {
+				testwg.Done()
+			},
+		)
+
+		fakeReader.EXPECT().ReadMessage(ctx).Return(message, nil).Times(1).Do(
+			func(c context.Context) {
+				cancel()
+			},
+		)
+		go consumer.Run()
+	})
+
+	It("should delete a Job", func() {
+		wg.Add(1)
+		defer wg.Wait()
+
+		var testwg sync.WaitGroup
+		testwg.Add(1)
+		defer testwg.Wait()
+
+		ss := domain.Job{
+			ID:         "276797fa-b207-11e9-8527-000d3af9d6b6",
+			Name:       "queue-node",
+			Generation: 7,
+			Namespace:  "amida",
+			Labels: map[string]string{
+				"HEAD":                   "569de2ecd9f9357b3380664f43c90d07ec6acaff",
+				"app":                    "nats",
+				"fluxcd.io/sync-gc-mark": "sha256.0fRlq9kqkh2eSDRqXANMzgN8_8jeguja3eDLoE5E0Xo",
+			},
+			Containers: map[string]string{
+				"nats-exporter":  "synadia/prometheus-nats-exporter:0.4.0",
+				"nats-streaming": "nats-streaming:0.15.1",
+			},
+		}
+
+		ssbytes, _ := json.Marshal(ss)
+
+		message := kafgo.Message{
+			Topic:     "_katalog.artifact.deleted",
+			Partition: 1,
+			Offset:    5,
+			Key:       []byte("/jobs/276797fa-b207-11e9-8527-000d3af9d6b6"),
+			Value:     ssbytes,
+			Headers:   nil,
+			Time:      time.Now(),
+		}
+
+		resource := domain.Resource{K8sResource: &ss}
+		id := "276797fa-b207-11e9-8527-000d3af9d6b6"
+
+		fakeReader.EXPECT().Close().Times(1)
+		fakeRepo.EXPECT().GetResource(id).Return(resource, nil).Times(1)
+		fakeRepo.EXPECT().DeleteResource(id).Return(nil).Times(1).Do(
+			func(id string) {
+				testwg.Done()
+			},
+		)
+
+		fakeReader.EXPECT().ReadMessage(ctx).Return(message, nil).Times(1).Do(
+			func(c context.Context) {
+				cancel()
+			},
+		)
+		go consumer.Run()
+	})
+
+	It("should delete a Cronjob", func() {
+		wg.Add(1)
+		defer wg.Wait()
+
+		var testwg sync.WaitGroup
+		testwg.Add(1)
+		defer testwg.Wait()
+
+		ss := domain.CronJob{
+			ID:         "276797fa-b207-11e9-8527-000d3af9d6b6",
+			Name:       "queue-node",
+			Generation: 7,
+			Namespace:  "amida",
+			Labels: map[string]string{
+				"HEAD":                   "569de2ecd9f9357b3380664f43c90d07ec6acaff",
+				"app":                    "nats",
+				"fluxcd.io/sync-gc-mark": "sha256.0fRlq9kqkh2eSDRqXANMzgN8_8jeguja3eDLoE5E0Xo",
+			},
+			Containers: map[string]string{
+				"nats-exporter":  "synadia/prometheus-nats-exporter:0.4.0",
+				"nats-streaming": "nats-streaming:0.15.1",
+			},
+		}
+
+		ssbytes, _ := json.Marshal(ss)
+
+		message := kafgo.Message{
+			Topic:     "_katalog.artifact.deleted",
+			Partition: 1,
+			Offset:    5,
+			Key:       []byte("/cronjobs/276797fa-b207-11e9-8527-000d3af9d6b6"),
+			Value:     ssbytes,
+			Headers:   nil,
+			Time:      time.Now(),
+		}
+
 		resource := domain.Resource{K8sResource: &ss}
 		id := "276797fa-b207-11e9-8527-000d3af9d6b6"
 
@@ -1,149 +1,26 @@
 package kafka
 
 import (
-	"encoding/json"
-
 	"github.com/sirupsen/logrus"
 	"github.com/walmartdigital/katalog/domain"
 )
 
-// CreateService ...
-func (c *Consumer) CreateService(body string) error {
-	var service domain.Service
-	errDecoding := json.Unmarshal([]byte(body), &service)
-	if errDecoding != nil {
-		log.WithFields(logrus.Fields{
-			"msg": errDecoding.Error(),
-		}).Debug("Deserializing Service")
-
-		return errDecoding
-	}
-
-	err := c.service.CreateService(service)
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Debug("Creating Service")
-
-		return err
-	}
-
-	return nil
-}
-
-// UpdateService ...
-func (c *Consumer) UpdateService(body string) error {
-	var service domain.Service
-	errDecoding := json.Unmarshal([]byte(body), &service)
-	if errDecoding != nil {
-		log.Fatal(errDecoding)
-	}
-
-	err := c.service.UpdateService(service)
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Debug("Creating Service")
-
-		return err
-	}
-
-	return nil
-}
-
-// DeleteService ...
-func (c *Consumer) DeleteService(id string) error {
-	err := c.service.DeleteService(id)
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Debug("Deleting Service")
-
-		return err
-	}
-
-	return nil
-}
-
-// CreateDeployment ...
-func (c *Consumer) CreateDeployment(body string) error {
-	var deployment domain.Deployment
-	errDecoding := json.Unmarshal([]byte(body), &deployment)
-	if errDecoding != nil {
-		log.WithFields(logrus.Fields{
-			"msg": errDecoding.Error(),
-		}).Debug("Deserializing Deployment")
-
-		return errDecoding
-	}
-
-	err := c.service.CreateDeployment(deployment)
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Debug("Creating Deployment")
-
-		return err
-	}
-
-	return nil
-}
-
-// UpdateDeployment ...
-func (c *Consumer) UpdateDeployment(body string) error {
-	var deployment domain.Deployment
-	errDecoding := json.Unmarshal([]byte(body), &deployment)
-	if errDecoding != nil {
-		log.WithFields(logrus.Fields{
-			"msg": errDecoding.Error(),
-		}).Debug("Deserializing Deployment")
-
-		return errDecoding
-	}
-
-	err := c.service.UpdateDeployment(deployment)
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Debug("Updating Deployment")
-
-		return err
-	}
-
-	return nil
-}
-
-// DeleteDeployment ...
-func (c *Consumer) DeleteDeployment(id string) error {
-	err := c.service.DeleteDeployment(id)
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Debug("Deleting Deployment")
-
-		return err
-	}
-
-	return nil
-}
-
-// CreateStatefulSet ...
-func (c *Consumer) CreateStatefulSet(body string) error {
-	var statefulset domain.StatefulSet
-	errDecoding := json.Unmarshal([]byte(body), &statefulset)
+// CreateResource ...
+func (c *Consumer) CreateResource(kind domain.Kind, body string) error {
+	resource, errDecoding := kind.Decode([]byte(body))
 	if errDecoding != nil {
 		log.WithFields(logrus.Fields{
 			"msg": errDecoding.Error(),
-		}).Debug("Deserializing StatefulSet")
+		}).Debugf("Deserializing %s", kind.Name)
 
 		return errDecoding
 	}
 
-	err := c.service.CreateStatefulSet(statefulset)
+	err := c.service.CreateResource(kind, domain.Resource{K8sResource: resource})
 	if err != nil {
 		log.WithFields(logrus.Fields{
 			"msg": err.Error(),
-		}).Debug("Creating StatefulSet")
+		}).Debugf("Creating %s", kind.Name)
 
 		return err
 	}
@@ -151,23 +28,22 @@ func (c *Consumer) CreateStatefulSet(body string) error {
 	return nil
 }
 
-// UpdateStatefulSet ...
-func (c *Consumer) UpdateStatefulSet(body string) error {
-	var statefulset domain.StatefulSet
-	errDecoding := json.Unmarshal([]byte(body), &statefulset)
+// UpdateResource ...
+func (c *Consumer) UpdateResource(kind domain.Kind, body string) error {
+	resource, errDecoding := kind.Decode([]byte(body))
 	if errDecoding != nil {
 		log.WithFields(logrus.Fields{
 			"msg": errDecoding.Error(),
-		}).Debug("Deserializing StatefulSet")
+		}).Debugf("Deserializing %s", kind.Name)
 
 		return errDecoding
 	}
 
-	err := c.service.UpdateStatefulSet(statefulset)
+	err := c.service.UpdateResource(kind, domain.Resource{K8sResource: resource})
 	if err != nil {
 		log.WithFields(logrus.Fields{
 			"msg": err.Error(),
-		}).Debug("Updating StatefulSet")
+		}).Debugf("Updating %s", kind.Name)
 
 		return err
 	}
@@ -175,13 +51,13 @@ func (c *Consumer) UpdateStatefulSet(body string) error {
 	return nil
 }
 
-// DeleteStatefulSet ...
-func (c *Consumer) DeleteStatefulSet(id string) error {
-	err := c.service.DeleteStatefulSet(id)
+// DeleteResource ...
+func (c *Consumer) DeleteResource(kind domain.Kind, id string) error {
+	err := c.service.DeleteResource(kind, id)
 	if err != nil {
 		log.WithFields(logrus.Fields{
 			"msg": err.Error(),
-		}).Debug("Creating StatefulSet")
+		}).Debugf("Deleting %s", kind.Name)
 
 		return err
 	}
@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/walmartdigital/katalog/domain"
 )
 
 var mutex = &sync.Mutex{}
@@ -13,6 +14,8 @@ var metrics map[string]interface{}
 type Metrics interface {
 	InitMetrics()
 	IncrementCounter(string, ...string)
+	SetGauge(string, float64, ...string)
+	DeleteGauge(string, ...string)
 	DestroyMetrics()
 }
 
@@ -21,6 +24,13 @@ type MetricsFactory interface {
 	Create() Metrics
 }
 
+// actions counted for every registered kind
+var actions = []struct{ name, help string }{
+	{"create", "creations"},
+	{"update", "updates"},
+	{"delete", "deletes"},
+}
+
 // PrometheusMetrics ...
 type PrometheusMetrics struct {
 }
@@ -31,77 +41,36 @@ func (p PrometheusMetrics) InitMetrics() {
 	if metrics == nil {
 		metrics = make(map[string]interface{})
 
-		metrics["createDeployment"] = prometheus.NewCounterVec(
-			prometheus.CounterOpts{
-				Namespace: "katalog",
-				Subsystem: "deployment",
-				Name:      "create",
-				Help:      "Total number of deployment creations",
-			},
-			[]string{"id", "ns", "rn"},
-		)
-		metrics["createDeployment"].(*prometheus.CounterVec).WithLabelValues("", "", "")
-		prometheus.MustRegister(metrics["createDeployment"].(*prometheus.CounterVec))
-
-		metrics["updateDeployment"] = prometheus.NewCounterVec(
-			prometheus.CounterOpts{
-				Namespace: "katalog",
-				Subsystem: "deployment",
-				Name:      "update",
-				Help:      "Total number of deployment updates",
-			},
-			[]string{"id", "ns", "rn"},
-		)
-		metrics["updateDeployment"].(*prometheus.CounterVec).WithLabelValues("", "", "")
-		prometheus.MustRegister(metrics["updateDeployment"].(*prometheus.CounterVec))
-
-		metrics["deleteDeployment"] = prometheus.NewCounterVec(
-			prometheus.CounterOpts{
-				Namespace: "katalog",
-				Subsystem: "deployment",
-				Name:      "delete",
-				Help:      "Total number of deployment deletes",
-			},
-			[]string{"id", "ns", "rn"},
-		)
-		metrics["deleteDeployment"].(*prometheus.CounterVec).WithLabelValues("", "", "")
-		prometheus.MustRegister(metrics["deleteDeployment"].(*prometheus.CounterVec))
+		for _, kind := range domain.Kinds() {
+			if kind.Silent {
+				continue
+			}
+			for _, action := range actions {
+				name := kind.MetricName(action.name)
+				metrics[name] = prometheus.NewCounterVec(
+					prometheus.CounterOpts{
+						Namespace: "katalog",
+						Subsystem: kind.MetricSubsystem(),
+						Name:      action.name,
+						Help:      "Total number of " + kind.Label + " " + action.help,
+					},
+					[]string{"id", "ns", "rn"},
+				)
+				metrics[name].(*prometheus.CounterVec).WithLabelValues("", "", "")
+				prometheus.MustRegister(metrics[name].(*prometheus.CounterVec))
+			}
+		}
 
-		metrics["createStatefulSet"] = prometheus.NewCounterVec(
-			prometheus.CounterOpts{
+		metrics[violationsMetric] = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
 				Namespace: "katalog",
-				Subsystem: "statefulset",
-				Name:      "create",
-				Help:      "Total number of statefulset creations",
+				Subsystem: "image_policy",
+				Name:      "violations",
+				Help:      "Number of images of a workload violating an image policy rule",
 			},
-			[]string{"id", "ns", "rn"},
+			[]string{"rule", "kind", "cluster", "ns", "rn"},
 		)
-		metrics["createStatefulSet"].(*prometheus.CounterVec).WithLabelValues("", "", "")
-		prometheus.MustRegister(metrics["createStatefulSet"].(*prometheus.CounterVec))
-
-		metrics["updateStatefulSet"] = prometheus.NewCounterVec(
-			prometheus.CounterOpts{
-				Namespace: "katalog",
-				Subsystem: "statefulset",
-				Name:      "update",
-				Help:      "Total number of statefulset updates",
-			},
-			[]string{"id", "ns", "rn"},
-		)
-		metrics["updateStatefulSet"].(*prometheus.CounterVec).WithLabelValues("", "", "")
-		prometheus.MustRegister(metrics["updateStatefulSet"].(*prometheus.CounterVec))
-
-		metrics["deleteStatefulSet"] = prometheus.NewCounterVec(
-			prometheus.CounterOpts{
-				Namespace: "katalog",
-				Subsystem: "statefulset",
-				Name:      "delete",
-				Help:      "Total number of statefulset deletes",
-			},
-			[]string{"id", "ns", "rn"},
-		)
-		metrics["deleteStatefulSet"].(*prometheus.CounterVec).WithLabelValues("", "", "")
-		prometheus.MustRegister(metrics["deleteStatefulSet"].(*prometheus.CounterVec))
+		prometheus.MustRegister(metrics[violationsMetric].(*prometheus.GaugeVec))
 	}
 	mutex.Unlock()
 }
@@ -111,6 +80,16 @@ func (p PrometheusMetrics) IncrementCounter(key string, labels ...string) {
 	metrics[key].(*prometheus.CounterVec).WithLabelValues(labels...).Inc()
 }
 
+// SetGauge ...
+func (p PrometheusMetrics) SetGauge(key string, value float64, labels ...string) {
+	metrics[key].(*prometheus.GaugeVec).WithLabelValues(labels...).Set(value)
+}
+
+// DeleteGauge ...
+func (p PrometheusMetrics) DeleteGauge(key string, labels ...string) {
+	metrics[key].(*prometheus.GaugeVec).DeleteLabelValues(labels...)
+}
+
 // DestroyMetrics ...
 func (p PrometheusMetrics) DestroyMetrics() {
 	mutex.Lock()
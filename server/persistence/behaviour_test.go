@@ -0,0 +1,91 @@
+package persistence_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/walmartdigital/katalog/domain"
+	"github.com/walmartdigital/katalog/server/persistence"
+)
+
+func buildDeploymentResource(id string, name string) domain.Resource {
+	return domain.Resource{K8sResource: &domain.Deployment{
+		ID:         id,
+		Name:       name,
+		Namespace:  "default",
+		Generation: 1,
+		Labels:     map[string]string{"app": name},
+		Containers: map[string]string{name: "nginx:1.19"},
+	}}
+}
+
+// itBehavesLikeAPersistence runs the behaviour every persistence shares on a
+// fresh store built before each spec
+func itBehavesLikeAPersistence(build func() persistence.Persistence) {
+	var store persistence.Persistence
+	id := "4128cbf6-b279-46b3-ae19-9f90ea190978"
+
+	BeforeEach(func() {
+		store = build()
+	})
+
+	It("should get a created object", func() {
+		value := buildDeploymentResource(id, "catalog")
+		Expect(store.Create(id, value)).To(Succeed())
+
+		obj, err := store.Get(id)
+
+		Expect(err).To(BeNil())
+		Expect(obj).To(Equal(value))
+	})
+
+	It("should get nothing for an unknown id", func() {
+		obj, err := store.Get(id)
+
+		Expect(err).To(BeNil())
+		Expect(obj).To(BeNil())
+	})
+
+	It("should update an existent object", func() {
+		Expect(store.Create(id, buildDeploymentResource(id, "catalog"))).To(Succeed())
+		value := buildDeploymentResource(id, "batman")
+
+		Expect(store.Update(id, value)).To(Succeed())
+
+		obj, err := store.Get(id)
+		Expect(err).To(BeNil())
+		Expect(obj).To(Equal(value))
+	})
+
+	It("should delete an object", func() {
+		Expect(store.Create(id, buildDeploymentResource(id, "catalog"))).To(Succeed())
+
+		Expect(store.Delete(id)).To(Succeed())
+
+		obj, err := store.Get(id)
+		Expect(err).To(BeNil())
+		Expect(obj).To(BeNil())
+	})
+
+	It("should get all objects", func() {
+		first := buildDeploymentResource("7879d950-e511-4798-a074-a951d9eddbb8", "first")
+		second := buildDeploymentResource("a5e1a6ba-3e3d-4a0f-a0c1-2c4b1ab8e6f1", "second")
+		Expect(store.Create(first.GetID(), first)).To(Succeed())
+		Expect(store.Create(second.GetID(), second)).To(Succeed())
+
+		results, err := store.GetAll()
+
+		Expect(err).To(BeNil())
+		Expect(results).To(ConsistOf(first, second))
+	})
+
+	It("should fail when id is empty", func() {
+		value := buildDeploymentResource("", "catalog")
+
+		_, errGet := store.Get("")
+
+		Expect(errGet).NotTo(BeNil())
+		Expect(store.Create("", value)).NotTo(Succeed())
+		Expect(store.Update("", value)).NotTo(Succeed())
+		Expect(store.Delete("")).NotTo(Succeed())
+	})
+}
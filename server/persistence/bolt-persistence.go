@@ -0,0 +1,181 @@
+package persistence
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/emirpasic/gods/lists/arraylist"
+	"github.com/walmartdigital/katalog/domain"
+	bolt "go.etcd.io/bbolt"
+)
+
+// indexBucket maps the key of every resource to the name of its kind, which
+// is also the name of the bucket holding it
+var indexBucket = []byte("_index")
+
+const boltOpenTimeout = 10 * time.Second
+
+// BoltPersistence stores the resources in an embedded bbolt database, one
+// bucket per kind. Every write is a single transaction, so the index and
+// the kind buckets never disagree.
+type BoltPersistence struct {
+	db *bolt.DB
+}
+
+// BuildBoltPersistence opens the database file, creating it if needed. The
+// file is locked while open, so it can not be shared between processes.
+func BuildBoltPersistence(path string) (*BoltPersistence, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: boltOpenTimeout})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(indexBucket); err != nil {
+			return err
+		}
+		for _, kind := range domain.Kinds() {
+			if _, err := tx.CreateBucketIfNotExists([]byte(kind.Name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &BoltPersistence{db: db}, nil
+}
+
+// Close releases the database file
+func (p *BoltPersistence) Close() error {
+	return p.db.Close()
+}
+
+// Get ...
+func (p *BoltPersistence) Get(id string) (interface{}, error) {
+	if id == "" {
+		return nil, errors.New("you must provide an id")
+	}
+
+	var value interface{}
+	err := p.db.View(func(tx *bolt.Tx) error {
+		kindName := tx.Bucket(indexBucket).Get([]byte(id))
+		if kindName == nil {
+			return nil
+		}
+
+		resource, err := decodeResource(string(kindName), tx.Bucket(kindName).Get([]byte(id)))
+		if err != nil {
+			return err
+		}
+		value = resource
+		return nil
+	})
+
+	return value, err
+}
+
+// Create ...
+func (p *BoltPersistence) Create(id string, obj interface{}) error {
+	return p.put(id, obj)
+}
+
+// Update ...
+func (p *BoltPersistence) Update(id string, obj interface{}) error {
+	return p.put(id, obj)
+}
+
+func (p *BoltPersistence) put(id string, obj interface{}) error {
+	if id == "" {
+		return errors.New("you must provide an id")
+	}
+
+	resource, ok := obj.(domain.Resource)
+	if !ok || resource.K8sResource == nil {
+		return fmt.Errorf("can not persist a %T", obj)
+	}
+	kind, ok := domain.KindOf(resource.K8sResource)
+	if !ok {
+		return fmt.Errorf("can not persist a %s, its kind is not registered", resource.GetType())
+	}
+	data, err := kind.Encode(resource.K8sResource)
+	if err != nil {
+		return err
+	}
+
+	return p.db.Update(func(tx *bolt.Tx) error {
+		index := tx.Bucket(indexBucket)
+		if previous := index.Get([]byte(id)); previous != nil && string(previous) != kind.Name {
+			if err := tx.Bucket(previous).Delete([]byte(id)); err != nil {
+				return err
+			}
+		}
+		if err := tx.Bucket([]byte(kind.Name)).Put([]byte(id), data); err != nil {
+			return err
+		}
+		return index.Put([]byte(id), []byte(kind.Name))
+	})
+}
+
+// Delete ...
+func (p *BoltPersistence) Delete(id string) error {
+	if id == "" {
+		return errors.New("you must provide an id")
+	}
+
+	return p.db.Update(func(tx *bolt.Tx) error {
+		index := tx.Bucket(indexBucket)
+		kindName := index.Get([]byte(id))
+		if kindName == nil {
+			return nil
+		}
+		if err := tx.Bucket(kindName).Delete([]byte(id)); err != nil {
+			return err
+		}
+		return index.Delete([]byte(id))
+	})
+}
+
+// GetAll ...
+func (p *BoltPersistence) GetAll() ([]interface{}, error) {
+	list := arraylist.New()
+
+	err := p.db.View(func(tx *bolt.Tx) error {
+		for _, kind := range domain.Kinds() {
+			err := tx.Bucket([]byte(kind.Name)).ForEach(func(key []byte, data []byte) error {
+				resource, err := decodeResource(kind.Name, data)
+				if err != nil {
+					return err
+				}
+				list.Add(resource)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Values(), nil
+}
+
+// decodeResource decodes a resource encoded by the kind named kindName
+func decodeResource(kindName string, data []byte) (domain.Resource, error) {
+	kind, ok := domain.KindByName(kindName)
+	if !ok {
+		return domain.Resource{}, fmt.Errorf("kind %s is not registered", kindName)
+	}
+	resource, err := kind.Decode(data)
+	if err != nil {
+		return domain.Resource{}, err
+	}
+	return domain.Resource{K8sResource: resource}, nil
+}
@@ -0,0 +1,82 @@
+package persistence_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/walmartdigital/katalog/domain"
+	"github.com/walmartdigital/katalog/server/persistence"
+)
+
+var _ = Describe("memory persistence behaviour", func() {
+	itBehavesLikeAPersistence(func() persistence.Persistence {
+		return persistence.BuildMemoryPersistence(new(sync.Map))
+	})
+})
+
+var _ = Describe("bolt persistence", func() {
+	var dir string
+	var stores []*persistence.BoltPersistence
+
+	open := func() *persistence.BoltPersistence {
+		store, err := persistence.BuildBoltPersistence(filepath.Join(dir, "katalog.db"))
+		Expect(err).To(BeNil())
+		stores = append(stores, store)
+		return store
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "katalog-bolt")
+		Expect(err).To(BeNil())
+		stores = nil
+	})
+
+	AfterEach(func() {
+		for _, store := range stores {
+			store.Close()
+		}
+		os.RemoveAll(dir)
+	})
+
+	Describe("behaviour", func() {
+		itBehavesLikeAPersistence(func() persistence.Persistence {
+			return open()
+		})
+	})
+
+	It("should keep the objects when the database is reopened", func() {
+		value := buildDeploymentResource("7879d950-e511-4798-a074-a951d9eddbb8", "catalog")
+		store := open()
+		Expect(store.Create(value.GetID(), value)).To(Succeed())
+		Expect(store.Close()).To(Succeed())
+
+		obj, err := open().Get(value.GetID())
+
+		Expect(err).To(BeNil())
+		Expect(obj).To(Equal(value))
+	})
+
+	It("should move an object whose kind changed to the bucket of its new kind", func() {
+		id := "7879d950-e511-4798-a074-a951d9eddbb8"
+		store := open()
+		Expect(store.Create(id, buildDeploymentResource(id, "catalog"))).To(Succeed())
+		service := domain.Resource{K8sResource: &domain.Service{ID: id, Name: "catalog", Namespace: "default"}}
+
+		Expect(store.Update(id, service)).To(Succeed())
+
+		results, err := store.GetAll()
+		Expect(err).To(BeNil())
+		Expect(results).To(ConsistOf(service))
+	})
+
+	It("should fail to persist anything but a resource", func() {
+		err := open().Create("7879d950-e511-4798-a074-a951d9eddbb8", struct{ id string }{"I'm batman"})
+
+		Expect(err).NotTo(BeNil())
+	})
+})
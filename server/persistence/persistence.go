@@ -1,5 +1,11 @@
 package persistence
 
+import (
+	"strings"
+
+	"github.com/walmartdigital/katalog/domain"
+)
+
 // Persistence ...
 type Persistence interface {
 	Get(id string) (interface{}, error)
@@ -13,3 +19,51 @@ type Persistence interface {
 type Factory interface {
 	Create() Persistence
 }
+
+// Filter restricts the resources listed to a kind, e.g. Deployment, a
+// namespace, a name or a name prefix, and the labels with their value. Empty
+// fields match any resource.
+type Filter struct {
+	Kind       string
+	Namespace  string
+	Name       string
+	NamePrefix string
+	Labels     map[string]string
+}
+
+// Filterable is implemented by the persistences listing the resources
+// matching a filter without reading every resource
+type Filterable interface {
+	GetAllBy(filter Filter) ([]interface{}, error)
+}
+
+// ConditionalUpdater is implemented by the persistences comparing the saved
+// resource with its update atomically, so concurrent writers can not replace
+// a newer resource with an older one. UpdateIf stores obj, creating it when
+// missing, only if supersedes accepts the saved resource, nil when missing,
+// and tells whether it was stored.
+type ConditionalUpdater interface {
+	UpdateIf(id string, obj interface{}, supersedes func(saved interface{}) bool) (bool, error)
+}
+
+// Matches tells whether the resource passes the filter, for the persistences
+// that can only list every resource
+func (f Filter) Matches(resource domain.Resource) bool {
+	if resource.K8sResource == nil {
+		return f.Kind == "" && f.Namespace == "" && f.Name == "" && f.NamePrefix == "" && len(f.Labels) == 0
+	}
+	if f.Kind != "" {
+		kind, ok := domain.KindOf(resource.K8sResource)
+		if !ok || kind.Name != f.Kind {
+			return false
+		}
+	}
+	for key, value := range f.Labels {
+		if labelValue, ok := resource.GetLabels()[key]; !ok || labelValue != value {
+			return false
+		}
+	}
+	return (f.Namespace == "" || resource.GetNamespace() == f.Namespace) &&
+		(f.Name == "" || resource.GetName() == f.Name) &&
+		strings.HasPrefix(resource.GetName(), f.NamePrefix)
+}
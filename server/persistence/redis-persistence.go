@@ -0,0 +1,281 @@
+package persistence
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/walmartdigital/katalog/domain"
+)
+
+// redisMaxAttempts bounds the optimistic transactions retried because a
+// replica changed the resource in between
+const redisMaxAttempts = 10
+
+// RedisPersistence stores the resources in Redis so several server replicas
+// share one catalog. Every resource is a hash holding its kind, namespace,
+// name, cluster and encoded data, indexed by a set per kind and namespace.
+// Writes are WATCH/MULTI transactions retried on conflict.
+type RedisPersistence struct {
+	pool   *redis.Pool
+	prefix string
+}
+
+// BuildRedisPersistence connects to the Redis server at address, prefixing
+// every key with prefix, e.g. katalog
+func BuildRedisPersistence(address string, prefix string) (*RedisPersistence, error) {
+	pool := &redis.Pool{
+		MaxIdle:     10,
+		IdleTimeout: 5 * time.Minute,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", address)
+		},
+	}
+
+	conn := pool.Get()
+	defer conn.Close()
+	if _, err := conn.Do("PING"); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	return &RedisPersistence{pool: pool, prefix: prefix}, nil
+}
+
+// Close releases the connections to Redis
+func (p *RedisPersistence) Close() error {
+	return p.pool.Close()
+}
+
+func (p *RedisPersistence) resourceKey(id string) string {
+	return p.prefix + ":resource:" + id
+}
+
+// allKey is the set of the ids of every resource
+func (p *RedisPersistence) allKey() string {
+	return p.prefix + ":resources"
+}
+
+func (p *RedisPersistence) indexKey(kindName string, namespace string) string {
+	return p.prefix + ":index:" + kindName + ":" + namespace
+}
+
+// Get ...
+func (p *RedisPersistence) Get(id string) (interface{}, error) {
+	if id == "" {
+		return nil, errors.New("you must provide an id")
+	}
+
+	conn := p.pool.Get()
+	defer conn.Close()
+
+	fields, err := redis.Strings(conn.Do("HMGET", p.resourceKey(id), "kind", "data"))
+	if err != nil {
+		return nil, err
+	}
+	if fields[0] == "" {
+		return nil, nil
+	}
+
+	return decodeResource(fields[0], []byte(fields[1]))
+}
+
+// Create ...
+func (p *RedisPersistence) Create(id string, obj interface{}) error {
+	return p.put(id, obj)
+}
+
+// Update ...
+func (p *RedisPersistence) Update(id string, obj interface{}) error {
+	return p.put(id, obj)
+}
+
+// UpdateIf stores the resource only if supersedes accepts the saved one, nil
+// when missing. The saved resource is read and compared inside the
+// transaction, so a replica can not replace a resource written meanwhile.
+func (p *RedisPersistence) UpdateIf(id string, obj interface{}, supersedes func(saved interface{}) bool) (bool, error) {
+	return p.putIf(id, obj, func(saved redisSaved) (bool, error) {
+		if saved.kindName == "" {
+			return supersedes(nil), nil
+		}
+		resource, err := decodeResource(saved.kindName, []byte(saved.data))
+		if err != nil {
+			return false, err
+		}
+		return supersedes(resource), nil
+	})
+}
+
+func (p *RedisPersistence) put(id string, obj interface{}) error {
+	_, err := p.putIf(id, obj, nil)
+	return err
+}
+
+// putIf stores the resource when accept, if any, accepts the saved one
+func (p *RedisPersistence) putIf(id string, obj interface{}, accept func(saved redisSaved) (bool, error)) (bool, error) {
+	if id == "" {
+		return false, errors.New("you must provide an id")
+	}
+
+	resource, ok := obj.(domain.Resource)
+	if !ok || resource.K8sResource == nil {
+		return false, fmt.Errorf("can not persist a %T", obj)
+	}
+	kind, ok := domain.KindOf(resource.K8sResource)
+	if !ok {
+		return false, fmt.Errorf("can not persist a %s, its kind is not registered", resource.GetType())
+	}
+	data, err := kind.Encode(resource.K8sResource)
+	if err != nil {
+		return false, err
+	}
+
+	index := p.indexKey(kind.Name, resource.GetNamespace())
+	return p.watch(id, accept, func(conn redis.Conn, saved redisSaved) {
+		if saved.index != "" && saved.index != index {
+			conn.Send("SREM", saved.index, id)
+		}
+		conn.Send("HSET", p.resourceKey(id),
+			"kind", kind.Name,
+			"namespace", resource.GetNamespace(),
+			"name", resource.GetName(),
+			"cluster", resource.GetCluster().Name,
+			"data", string(data))
+		conn.Send("SADD", index, id)
+		conn.Send("SADD", p.allKey(), id)
+	})
+}
+
+// Delete ...
+func (p *RedisPersistence) Delete(id string) error {
+	if id == "" {
+		return errors.New("you must provide an id")
+	}
+
+	_, err := p.watch(id, nil, func(conn redis.Conn, saved redisSaved) {
+		if saved.index != "" {
+			conn.Send("SREM", saved.index, id)
+		}
+		conn.Send("DEL", p.resourceKey(id))
+		conn.Send("SREM", p.allKey(), id)
+	})
+	return err
+}
+
+// redisSaved is the resource read under WATCH, its kind empty when missing
+type redisSaved struct {
+	index    string
+	kindName string
+	data     string
+}
+
+// watch queues the commands of queue in a transaction run only if the hash
+// of the resource did not change since read, retrying otherwise. accept, when
+// set, may give up the transaction after looking at the saved resource. It
+// tells whether the transaction ran.
+func (p *RedisPersistence) watch(id string, accept func(saved redisSaved) (bool, error), queue func(conn redis.Conn, saved redisSaved)) (bool, error) {
+	conn := p.pool.Get()
+	defer conn.Close()
+
+	for attempt := 0; attempt < redisMaxAttempts; attempt++ {
+		if _, err := conn.Do("WATCH", p.resourceKey(id)); err != nil {
+			return false, err
+		}
+
+		fields, err := redis.Strings(conn.Do("HMGET", p.resourceKey(id), "kind", "namespace", "data"))
+		if err != nil {
+			conn.Do("UNWATCH")
+			return false, err
+		}
+		saved := redisSaved{kindName: fields[0], data: fields[2]}
+		if fields[0] != "" {
+			saved.index = p.indexKey(fields[0], fields[1])
+		}
+
+		if accept != nil {
+			accepted, err := accept(saved)
+			if err != nil || !accepted {
+				conn.Do("UNWATCH")
+				return false, err
+			}
+		}
+
+		conn.Send("MULTI")
+		queue(conn, saved)
+		replies, err := redis.Values(conn.Do("EXEC"))
+		if err == redis.ErrNil {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		for _, reply := range replies {
+			if err, ok := reply.(redis.Error); ok {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+
+	return false, fmt.Errorf("resource %s kept changing, giving up after %d attempts", id, redisMaxAttempts)
+}
+
+// GetAll ...
+func (p *RedisPersistence) GetAll() ([]interface{}, error) {
+	return p.GetAllBy(Filter{})
+}
+
+// GetAllBy lists the resources matching the filter, sorted by id. A filter
+// naming both kind and namespace reads their index set only.
+func (p *RedisPersistence) GetAllBy(filter Filter) ([]interface{}, error) {
+	conn := p.pool.Get()
+	defer conn.Close()
+
+	set := p.allKey()
+	if filter.Kind != "" && filter.Namespace != "" {
+		set = p.indexKey(filter.Kind, filter.Namespace)
+	}
+	ids, err := redis.Strings(conn.Do("SMEMBERS", set))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		conn.Send("HMGET", p.resourceKey(id), "kind", "namespace", "name", "data")
+	}
+	conn.Flush()
+
+	list := make([]interface{}, 0, len(ids))
+	for range ids {
+		fields, err := redis.Strings(conn.Receive())
+		if err != nil {
+			return nil, err
+		}
+		// Deleted since the ids were read
+		if fields[0] == "" {
+			continue
+		}
+		if (filter.Kind != "" && fields[0] != filter.Kind) ||
+			(filter.Namespace != "" && fields[1] != filter.Namespace) ||
+			(filter.Name != "" && fields[2] != filter.Name) ||
+			!strings.HasPrefix(fields[2], filter.NamePrefix) {
+			continue
+		}
+
+		resource, err := decodeResource(fields[0], []byte(fields[3]))
+		if err != nil {
+			return nil, err
+		}
+		// The labels are only known once decoded
+		if !filter.Matches(resource) {
+			continue
+		}
+		list = append(list, resource)
+	}
+
+	return list, nil
+}
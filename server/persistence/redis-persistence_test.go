@@ -0,0 +1,190 @@
+package persistence_test
+
+import (
+	"github.com/alicebob/miniredis/v2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/walmartdigital/katalog/domain"
+	"github.com/walmartdigital/katalog/server/persistence"
+	"github.com/walmartdigital/katalog/server/repositories"
+)
+
+var _ = Describe("redis persistence", func() {
+	var redisServer *miniredis.Miniredis
+	var stores []*persistence.RedisPersistence
+
+	open := func() *persistence.RedisPersistence {
+		store, err := persistence.BuildRedisPersistence(redisServer.Addr(), "katalog")
+		Expect(err).To(BeNil())
+		stores = append(stores, store)
+		return store
+	}
+
+	BeforeEach(func() {
+		var err error
+		redisServer, err = miniredis.Run()
+		Expect(err).To(BeNil())
+		stores = nil
+	})
+
+	AfterEach(func() {
+		for _, store := range stores {
+			store.Close()
+		}
+		redisServer.Close()
+	})
+
+	Describe("behaviour", func() {
+		itBehavesLikeAPersistence(func() persistence.Persistence {
+			return open()
+		})
+	})
+
+	It("should fail to connect to an unreachable server", func() {
+		_, err := persistence.BuildRedisPersistence("127.0.0.1:1", "katalog")
+
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should share the catalog between replicas", func() {
+		value := buildDeploymentResource("7879d950-e511-4798-a074-a951d9eddbb8", "catalog")
+		Expect(open().Create(value.GetID(), value)).To(Succeed())
+
+		obj, err := open().Get(value.GetID())
+
+		Expect(err).To(BeNil())
+		Expect(obj).To(Equal(value))
+	})
+
+	It("should store a hash per resource indexed by kind and namespace", func() {
+		id := "7879d950-e511-4798-a074-a951d9eddbb8"
+
+		Expect(open().Create(id, buildDeploymentResource(id, "catalog"))).To(Succeed())
+
+		Expect(redisServer.HGet("katalog:resource:"+id, "kind")).To(Equal("Deployment"))
+		Expect(redisServer.HGet("katalog:resource:"+id, "name")).To(Equal("catalog"))
+		Expect(redisServer.Members("katalog:index:Deployment:default")).To(Equal([]string{id}))
+	})
+
+	It("should move a resource whose namespace changed to its new index", func() {
+		id := "7879d950-e511-4798-a074-a951d9eddbb8"
+		store := open()
+		Expect(store.Create(id, buildDeploymentResource(id, "catalog"))).To(Succeed())
+		moved := buildDeploymentResource(id, "catalog")
+		moved.K8sResource.(*domain.Deployment).Namespace = "payments"
+
+		Expect(store.Update(id, moved)).To(Succeed())
+
+		Expect(redisServer.Exists("katalog:index:Deployment:default")).To(BeFalse())
+		Expect(redisServer.Members("katalog:index:Deployment:payments")).To(Equal([]string{id}))
+	})
+
+	It("should remove a deleted resource from its indexes", func() {
+		id := "7879d950-e511-4798-a074-a951d9eddbb8"
+		store := open()
+		Expect(store.Create(id, buildDeploymentResource(id, "catalog"))).To(Succeed())
+
+		Expect(store.Delete(id)).To(Succeed())
+
+		Expect(redisServer.Keys()).To(BeEmpty())
+	})
+
+	It("should filter the resources by name prefix and labels", func() {
+		store := open()
+		catalog := buildDeploymentResource("7879d950-e511-4798-a074-a951d9eddbb8", "catalog")
+		cart := buildDeploymentResource("a5e1a6ba-3e3d-4a0f-a0c1-2c4b1ab8e6f1", "cart")
+		upper := buildDeploymentResource("0b5e3bd4-5ac1-4c1b-a4a4-0c1d7e0b6a11", "CATALOG")
+		for _, resource := range []domain.Resource{catalog, cart, upper} {
+			Expect(store.Create(resource.GetID(), resource)).To(Succeed())
+		}
+
+		byPrefix, err := store.GetAllBy(persistence.Filter{NamePrefix: "ca"})
+		Expect(err).To(BeNil())
+		Expect(byPrefix).To(ConsistOf(catalog, cart))
+
+		byLabels, err := store.GetAllBy(persistence.Filter{Kind: "Deployment", Labels: map[string]string{"app": "cart"}})
+		Expect(err).To(BeNil())
+		Expect(byLabels).To(ConsistOf(cart))
+
+		none, err := store.GetAllBy(persistence.Filter{NamePrefix: "cat", Labels: map[string]string{"app": "cart"}})
+		Expect(err).To(BeNil())
+		Expect(none).To(BeEmpty())
+	})
+
+	It("should filter the resources by kind, namespace and name", func() {
+		store := open()
+		catalog := buildDeploymentResource("7879d950-e511-4798-a074-a951d9eddbb8", "catalog")
+		other := buildDeploymentResource("a5e1a6ba-3e3d-4a0f-a0c1-2c4b1ab8e6f1", "other")
+		service := domain.Resource{K8sResource: &domain.Service{ID: "0b5e3bd4-5ac1-4c1b-a4a4-0c1d7e0b6a11", Name: "catalog", Namespace: "default"}}
+		for _, resource := range []domain.Resource{catalog, other, service} {
+			Expect(store.Create(resource.GetID(), resource)).To(Succeed())
+		}
+
+		byName, err := store.GetAllBy(persistence.Filter{Name: "catalog"})
+		Expect(err).To(BeNil())
+		Expect(byName).To(ConsistOf(catalog, service))
+
+		byIndex, err := store.GetAllBy(persistence.Filter{Kind: "Deployment", Namespace: "default"})
+		Expect(err).To(BeNil())
+		Expect(byIndex).To(ConsistOf(catalog, other))
+	})
+
+	It("should be listed by kind and namespace through the repository", func() {
+		store := open()
+		catalog := buildDeploymentResource("7879d950-e511-4798-a074-a951d9eddbb8", "catalog")
+		service := domain.Resource{K8sResource: &domain.Service{ID: "0b5e3bd4-5ac1-4c1b-a4a4-0c1d7e0b6a11", Name: "catalog", Namespace: "default"}}
+		for _, resource := range []domain.Resource{catalog, service} {
+			Expect(store.Create(resource.GetID(), resource)).To(Succeed())
+		}
+
+		resources, err := repositories.CreateResourceRepository(store).GetResourcesBy(persistence.Filter{Kind: "Deployment", Namespace: "default"})
+
+		Expect(err).To(BeNil())
+		Expect(resources).To(Equal([]interface{}{catalog}))
+	})
+
+	It("should keep the newer generation when two updates race", func() {
+		id := "7879d950-e511-4798-a074-a951d9eddbb8"
+		store := open()
+		Expect(store.Create(id, buildDeploymentResource(id, "catalog"))).To(Succeed())
+		older := buildDeploymentResource(id, "catalog")
+		older.K8sResource.(*domain.Deployment).Generation = 2
+		newer := buildDeploymentResource(id, "catalog")
+		newer.K8sResource.(*domain.Deployment).Generation = 3
+
+		compared := 0
+		stored, err := store.UpdateIf(id, older, func(saved interface{}) bool {
+			compared++
+			if compared == 1 {
+				// Another replica saves the newer generation between the read and the write
+				updated, err := repositories.CreateResourceRepository(open()).UpdateResource(newer)
+				Expect(err).To(BeNil())
+				Expect(updated).NotTo(BeNil())
+			}
+			return saved.(domain.Resource).K8sResource.(*domain.Deployment).Generation < 2
+		})
+
+		Expect(err).To(BeNil())
+		Expect(stored).To(BeFalse())
+		Expect(compared).To(Equal(2))
+		obj, err := store.Get(id)
+		Expect(err).To(BeNil())
+		Expect(obj).To(Equal(newer))
+	})
+
+	It("should not let an older generation replace a newer one through the repository", func() {
+		id := "7879d950-e511-4798-a074-a951d9eddbb8"
+		repository := repositories.CreateResourceRepository(open())
+		newer := buildDeploymentResource(id, "catalog")
+		newer.K8sResource.(*domain.Deployment).Generation = 3
+		Expect(repository.UpdateResource(newer)).NotTo(BeNil())
+
+		updated, err := repositories.CreateResourceRepository(open()).UpdateResource(buildDeploymentResource(id, "catalog"))
+
+		Expect(err).To(BeNil())
+		Expect(updated).To(BeNil())
+		obj, err := open().Get(id)
+		Expect(err).To(BeNil())
+		Expect(obj).To(Equal(newer))
+	})
+})
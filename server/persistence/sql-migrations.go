@@ -0,0 +1,49 @@
+package persistence
+
+// migration is a versioned change of the SQL schema. Released migrations are
+// never edited, schema changes are appended as new versions.
+type migration struct {
+	version    int
+	statements []string
+}
+
+// migrations only use types and statements SQLite and Postgres share
+var migrations = []migration{
+	{
+		version: 1,
+		statements: []string{
+			`CREATE TABLE resources (
+				resource_key TEXT PRIMARY KEY,
+				kind TEXT NOT NULL,
+				id TEXT NOT NULL,
+				name TEXT NOT NULL,
+				namespace TEXT NOT NULL,
+				cluster TEXT NOT NULL,
+				generation BIGINT NOT NULL,
+				data TEXT NOT NULL
+			)`,
+			`CREATE INDEX resources_kind ON resources (kind)`,
+			`CREATE INDEX resources_namespace ON resources (namespace)`,
+			`CREATE INDEX resources_name ON resources (name)`,
+			`CREATE TABLE labels (
+				resource_key TEXT NOT NULL REFERENCES resources (resource_key),
+				name TEXT NOT NULL,
+				value TEXT NOT NULL,
+				PRIMARY KEY (resource_key, name)
+			)`,
+			`CREATE TABLE annotations (
+				resource_key TEXT NOT NULL REFERENCES resources (resource_key),
+				name TEXT NOT NULL,
+				value TEXT NOT NULL,
+				PRIMARY KEY (resource_key, name)
+			)`,
+			`CREATE TABLE containers (
+				resource_key TEXT NOT NULL REFERENCES resources (resource_key),
+				name TEXT NOT NULL,
+				image TEXT NOT NULL,
+				init BOOLEAN NOT NULL,
+				PRIMARY KEY (resource_key, name)
+			)`,
+		},
+	},
+}
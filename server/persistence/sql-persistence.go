@@ -0,0 +1,301 @@
+package persistence
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/walmartdigital/katalog/domain"
+)
+
+// childTables hold the labels, annotations and containers of the resources
+// for reporting, the resources table holding the whole resource
+var childTables = []string{"labels", "annotations", "containers"}
+
+// SQLPersistence stores the resources in a relational database through
+// database/sql, SQLite or Postgres, migrating its schema when built
+type SQLPersistence struct {
+	db     *sql.DB
+	driver string
+}
+
+// BuildSQLPersistence connects to the database with the driver registered
+// under driverName and applies the migrations it misses
+func BuildSQLPersistence(driverName string, dataSourceName string) (*SQLPersistence, error) {
+	db, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite locks the whole file on writes, a single connection serializes them
+	if driverName == "sqlite3" {
+		db.SetMaxOpenConns(1)
+	}
+
+	p := &SQLPersistence{db: db, driver: driverName}
+	if err := p.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Close releases the connections to the database
+func (p *SQLPersistence) Close() error {
+	return p.db.Close()
+}
+
+// migrate applies in a transaction each migration newer than the schema
+func (p *SQLPersistence) migrate() error {
+	_, err := p.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)`)
+	if err != nil {
+		return err
+	}
+
+	var current sql.NullInt64
+	if err := p.db.QueryRow(`SELECT MAX(version) FROM schema_migrations`).Scan(&current); err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if int64(m.version) <= current.Int64 {
+			continue
+		}
+		err := p.inTransaction(func(tx *sql.Tx) error {
+			for _, statement := range m.statements {
+				if _, err := tx.Exec(statement); err != nil {
+					return err
+				}
+			}
+			_, err := tx.Exec(p.rebind(`INSERT INTO schema_migrations (version) VALUES (?)`), m.version)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("migrating schema to version %d: %v", m.version, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *SQLPersistence) inTransaction(fn func(tx *sql.Tx) error) error {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// rebind numbers the ? placeholders of the query the way Postgres expects
+func (p *SQLPersistence) rebind(query string) string {
+	if p.driver != "postgres" {
+		return query
+	}
+
+	var rebound strings.Builder
+	n := 0
+	for _, c := range query {
+		if c == '?' {
+			n++
+			rebound.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		rebound.WriteRune(c)
+	}
+	return rebound.String()
+}
+
+// Get ...
+func (p *SQLPersistence) Get(id string) (interface{}, error) {
+	if id == "" {
+		return nil, errors.New("you must provide an id")
+	}
+
+	var kindName, data string
+	err := p.db.QueryRow(p.rebind(`SELECT kind, data FROM resources WHERE resource_key = ?`), id).Scan(&kindName, &data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeResource(kindName, []byte(data))
+}
+
+// Create ...
+func (p *SQLPersistence) Create(id string, obj interface{}) error {
+	return p.put(id, obj)
+}
+
+// Update ...
+func (p *SQLPersistence) Update(id string, obj interface{}) error {
+	return p.put(id, obj)
+}
+
+// put replaces the resource and its rows in the child tables in a transaction
+func (p *SQLPersistence) put(id string, obj interface{}) error {
+	if id == "" {
+		return errors.New("you must provide an id")
+	}
+
+	resource, ok := obj.(domain.Resource)
+	if !ok || resource.K8sResource == nil {
+		return fmt.Errorf("can not persist a %T", obj)
+	}
+	kind, ok := domain.KindOf(resource.K8sResource)
+	if !ok {
+		return fmt.Errorf("can not persist a %s, its kind is not registered", resource.GetType())
+	}
+	data, err := kind.Encode(resource.K8sResource)
+	if err != nil {
+		return err
+	}
+
+	return p.inTransaction(func(tx *sql.Tx) error {
+		if err := p.delete(tx, id); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(p.rebind(`INSERT INTO resources (resource_key, kind, id, name, namespace, cluster, generation, data)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`),
+			id, kind.Name, resource.GetID(), resource.GetName(), resource.GetNamespace(),
+			resource.GetCluster().Name, resource.GetGeneration(), string(data))
+		if err != nil {
+			return err
+		}
+
+		for table, values := range map[string]map[string]string{
+			"labels":      resource.GetLabels(),
+			"annotations": resource.GetAnnotations(),
+		} {
+			for name, value := range values {
+				_, err := tx.Exec(p.rebind(`INSERT INTO `+table+` (resource_key, name, value) VALUES (?, ?, ?)`), id, name, value)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, container := range containersOf(resource) {
+			_, err := tx.Exec(p.rebind(`INSERT INTO containers (resource_key, name, image, init) VALUES (?, ?, ?, ?)`),
+				id, container.Name, container.Image, container.Init)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// containersOf returns the containers of a workload, built from the
+// Containers map for resources cataloged before ContainerSpecs
+func containersOf(resource domain.Resource) []domain.Container {
+	workload, ok := resource.K8sResource.(domain.Workload)
+	if !ok {
+		return nil
+	}
+	if specs := workload.GetContainerSpecs(); len(specs) > 0 {
+		return specs
+	}
+
+	containers := make([]domain.Container, 0)
+	for name, image := range workload.GetContainers() {
+		containers = append(containers, domain.Container{Name: name, Image: image})
+	}
+	return containers
+}
+
+// Delete ...
+func (p *SQLPersistence) Delete(id string) error {
+	if id == "" {
+		return errors.New("you must provide an id")
+	}
+
+	return p.inTransaction(func(tx *sql.Tx) error {
+		return p.delete(tx, id)
+	})
+}
+
+func (p *SQLPersistence) delete(tx *sql.Tx, id string) error {
+	for _, table := range childTables {
+		if _, err := tx.Exec(p.rebind(`DELETE FROM `+table+` WHERE resource_key = ?`), id); err != nil {
+			return err
+		}
+	}
+	_, err := tx.Exec(p.rebind(`DELETE FROM resources WHERE resource_key = ?`), id)
+	return err
+}
+
+// GetAll ...
+func (p *SQLPersistence) GetAll() ([]interface{}, error) {
+	return p.GetAllBy(Filter{})
+}
+
+// GetAllBy lists the resources matching the filter, sorted by kind and key.
+// The filter is pushed down to the indexed kind, namespace and name columns
+// and to the labels table.
+func (p *SQLPersistence) GetAllBy(filter Filter) ([]interface{}, error) {
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	for column, value := range map[string]string{
+		"kind":      filter.Kind,
+		"namespace": filter.Namespace,
+		"name":      filter.Name,
+	} {
+		if value != "" {
+			conditions = append(conditions, column+" = ?")
+			args = append(args, value)
+		}
+	}
+	// LIKE ignores the case in SQLite, substr compares the prefix exactly
+	if filter.NamePrefix != "" {
+		conditions = append(conditions, "substr(name, 1, ?) = ?")
+		args = append(args, utf8.RuneCountInString(filter.NamePrefix), filter.NamePrefix)
+	}
+	labelNames := make([]string, 0, len(filter.Labels))
+	for name := range filter.Labels {
+		labelNames = append(labelNames, name)
+	}
+	sort.Strings(labelNames)
+	for _, name := range labelNames {
+		conditions = append(conditions, "resource_key IN (SELECT resource_key FROM labels WHERE name = ? AND value = ?)")
+		args = append(args, name, filter.Labels[name])
+	}
+
+	query := `SELECT kind, data FROM resources`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY kind, resource_key`
+
+	rows, err := p.db.Query(p.rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]interface{}, 0)
+	for rows.Next() {
+		var kindName, data string
+		if err := rows.Scan(&kindName, &data); err != nil {
+			return nil, err
+		}
+		resource, err := decodeResource(kindName, []byte(data))
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, resource)
+	}
+
+	return list, rows.Err()
+}
@@ -0,0 +1,165 @@
+package persistence_test
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	_ "github.com/mattn/go-sqlite3"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/walmartdigital/katalog/domain"
+	"github.com/walmartdigital/katalog/server/persistence"
+)
+
+var _ = Describe("sql persistence", func() {
+	var dir string
+	var stores []*persistence.SQLPersistence
+
+	open := func() *persistence.SQLPersistence {
+		store, err := persistence.BuildSQLPersistence("sqlite3", filepath.Join(dir, "katalog.sqlite"))
+		Expect(err).To(BeNil())
+		stores = append(stores, store)
+		return store
+	}
+
+	query := func(statement string, args ...interface{}) [][]string {
+		db, err := sql.Open("sqlite3", filepath.Join(dir, "katalog.sqlite"))
+		Expect(err).To(BeNil())
+		defer db.Close()
+
+		rows, err := db.Query(statement, args...)
+		Expect(err).To(BeNil())
+		defer rows.Close()
+		columns, _ := rows.Columns()
+
+		results := make([][]string, 0)
+		for rows.Next() {
+			values := make([]string, len(columns))
+			pointers := make([]interface{}, len(columns))
+			for i := range values {
+				pointers[i] = &values[i]
+			}
+			Expect(rows.Scan(pointers...)).To(Succeed())
+			results = append(results, values)
+		}
+		return results
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "katalog-sql")
+		Expect(err).To(BeNil())
+		stores = nil
+	})
+
+	AfterEach(func() {
+		for _, store := range stores {
+			store.Close()
+		}
+		os.RemoveAll(dir)
+	})
+
+	Describe("behaviour", func() {
+		itBehavesLikeAPersistence(func() persistence.Persistence {
+			return open()
+		})
+	})
+
+	It("should apply the migrations once", func() {
+		open().Close()
+		open()
+
+		Expect(query(`SELECT version FROM schema_migrations`)).To(Equal([][]string{{"1"}}))
+	})
+
+	It("should keep the objects when the database is reopened", func() {
+		value := buildDeploymentResource("7879d950-e511-4798-a074-a951d9eddbb8", "catalog")
+		store := open()
+		Expect(store.Create(value.GetID(), value)).To(Succeed())
+		Expect(store.Close()).To(Succeed())
+
+		obj, err := open().Get(value.GetID())
+
+		Expect(err).To(BeNil())
+		Expect(obj).To(Equal(value))
+	})
+
+	It("should store the labels, annotations and containers of a resource", func() {
+		id := "7879d950-e511-4798-a074-a951d9eddbb8"
+		deployment := buildDeploymentResource(id, "catalog")
+		deployment.K8sResource.(*domain.Deployment).Annotations = map[string]string{"owner": "payments"}
+		deployment.K8sResource.(*domain.Deployment).ContainerSpecs = []domain.Container{
+			{Name: "migrate", Image: "flyway:7", Init: true},
+			{Name: "catalog", Image: "nginx:1.19"},
+		}
+		store := open()
+
+		Expect(store.Create(id, deployment)).To(Succeed())
+
+		Expect(query(`SELECT kind, name, namespace FROM resources WHERE resource_key = ?`, id)).To(Equal([][]string{{"Deployment", "catalog", "default"}}))
+		Expect(query(`SELECT name, value FROM labels WHERE resource_key = ?`, id)).To(Equal([][]string{{"app", "catalog"}}))
+		Expect(query(`SELECT name, value FROM annotations WHERE resource_key = ?`, id)).To(Equal([][]string{{"owner", "payments"}}))
+		Expect(query(`SELECT name, image, init FROM containers WHERE resource_key = ? ORDER BY name`, id)).To(Equal([][]string{
+			{"catalog", "nginx:1.19", "false"},
+			{"migrate", "flyway:7", "true"},
+		}))
+	})
+
+	It("should remove the rows of a deleted resource", func() {
+		id := "7879d950-e511-4798-a074-a951d9eddbb8"
+		store := open()
+		Expect(store.Create(id, buildDeploymentResource(id, "catalog"))).To(Succeed())
+
+		Expect(store.Delete(id)).To(Succeed())
+
+		for _, table := range []string{"resources", "labels", "annotations", "containers"} {
+			Expect(query(`SELECT resource_key FROM ` + table)).To(BeEmpty())
+		}
+	})
+
+	It("should filter the resources by name prefix and labels", func() {
+		store := open()
+		catalog := buildDeploymentResource("7879d950-e511-4798-a074-a951d9eddbb8", "catalog")
+		cart := buildDeploymentResource("a5e1a6ba-3e3d-4a0f-a0c1-2c4b1ab8e6f1", "cart")
+		upper := buildDeploymentResource("0b5e3bd4-5ac1-4c1b-a4a4-0c1d7e0b6a11", "CATALOG")
+		for _, resource := range []domain.Resource{catalog, cart, upper} {
+			Expect(store.Create(resource.GetID(), resource)).To(Succeed())
+		}
+
+		byPrefix, err := store.GetAllBy(persistence.Filter{NamePrefix: "ca"})
+		Expect(err).To(BeNil())
+		Expect(byPrefix).To(ConsistOf(catalog, cart))
+
+		byLabels, err := store.GetAllBy(persistence.Filter{Kind: "Deployment", Labels: map[string]string{"app": "cart"}})
+		Expect(err).To(BeNil())
+		Expect(byLabels).To(ConsistOf(cart))
+
+		none, err := store.GetAllBy(persistence.Filter{NamePrefix: "cat", Labels: map[string]string{"app": "cart"}})
+		Expect(err).To(BeNil())
+		Expect(none).To(BeEmpty())
+	})
+
+	It("should filter the resources by kind, namespace and name", func() {
+		store := open()
+		catalog := buildDeploymentResource("7879d950-e511-4798-a074-a951d9eddbb8", "catalog")
+		other := buildDeploymentResource("a5e1a6ba-3e3d-4a0f-a0c1-2c4b1ab8e6f1", "other")
+		service := domain.Resource{K8sResource: &domain.Service{ID: "0b5e3bd4-5ac1-4c1b-a4a4-0c1d7e0b6a11", Name: "catalog", Namespace: "default"}}
+		for _, resource := range []domain.Resource{catalog, other, service} {
+			Expect(store.Create(resource.GetID(), resource)).To(Succeed())
+		}
+
+		byName, err := store.GetAllBy(persistence.Filter{Name: "catalog"})
+		Expect(err).To(BeNil())
+		Expect(byName).To(ConsistOf(catalog, service))
+
+		byKind, err := store.GetAllBy(persistence.Filter{Kind: "Deployment", Namespace: "default", Name: "catalog"})
+		Expect(err).To(BeNil())
+		Expect(byKind).To(ConsistOf(catalog))
+
+		none, err := store.GetAllBy(persistence.Filter{Namespace: "kube-system"})
+		Expect(err).To(BeNil())
+		Expect(none).To(BeEmpty())
+	})
+})
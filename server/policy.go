@@ -0,0 +1,207 @@
+package server
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/walmartdigital/katalog/domain"
+	"sigs.k8s.io/yaml"
+)
+
+// Image policy rules
+const (
+	RuleDisallowedRegistry = "disallowed-registry"
+	RuleLatestTag          = "latest-tag"
+	RuleMissingTag         = "missing-tag"
+	RuleMissingDigest      = "missing-digest"
+	RuleTagPattern         = "tag-pattern"
+)
+
+var rules = []string{RuleDisallowedRegistry, RuleLatestTag, RuleMissingTag, RuleMissingDigest, RuleTagPattern}
+
+const violationsMetric = "imagePolicyViolations"
+
+// ImagePolicy holds the rules the images of deployments and statefulsets are
+// checked against. The rules left out are not checked.
+type ImagePolicy struct {
+	DisallowedRegistries []string  `json:"disallowedRegistries,omitempty"`
+	ForbidLatestTag      bool      `json:"forbidLatestTag,omitempty"`
+	RequireTag           bool      `json:"requireTag,omitempty"`
+	RequireDigest        bool      `json:"requireDigest,omitempty"`
+	AllowedTags          []TagRule `json:"allowedTags,omitempty"`
+}
+
+// TagRule restricts the tags of the images run in the namespaces matching
+// its globs to a regular expression
+type TagRule struct {
+	Namespaces []string `json:"namespaces"`
+	Pattern    string   `json:"pattern"`
+	pattern    *regexp.Regexp
+}
+
+// Violation is an image of a workload container breaking a policy rule
+type Violation struct {
+	Rule      string
+	Kind      string
+	ID        string
+	Name      string
+	Namespace string
+	Cluster   string `json:",omitempty"`
+	Container string
+	Image     string
+	Message   string
+}
+
+// LoadImagePolicy reads the YAML or JSON rules file and compiles its tag
+// patterns
+func LoadImagePolicy(file string) (*ImagePolicy, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &ImagePolicy{}
+	if err := yaml.Unmarshal(content, policy); err != nil {
+		return nil, fmt.Errorf("invalid image policy %s: %v", file, err)
+	}
+
+	if err := policy.compile(); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (p *ImagePolicy) compile() error {
+	for i := range p.AllowedTags {
+		rule := &p.AllowedTags[i]
+		for _, namespace := range rule.Namespaces {
+			if _, err := path.Match(namespace, ""); err != nil {
+				return fmt.Errorf("invalid namespace glob %q: %v", namespace, err)
+			}
+		}
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid tag pattern %q: %v", rule.Pattern, err)
+		}
+		rule.pattern = pattern
+	}
+	return nil
+}
+
+func (r TagRule) appliesTo(namespace string) bool {
+	for _, glob := range r.Namespaces {
+		if matched, _ := path.Match(glob, namespace); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate checks the images of every container of the workload, sorted by
+// container and rule
+func (p *ImagePolicy) Evaluate(resource domain.Resource) []Violation {
+	workload, ok := resource.K8sResource.(domain.Workload)
+	if !ok {
+		return nil
+	}
+
+	violations := make([]Violation, 0)
+	for container, image := range domain.ImagesOf(workload) {
+		for rule, message := range p.evaluateImage(resource.GetNamespace(), image) {
+			violations = append(violations, Violation{
+				Rule:      rule,
+				Kind:      resource.GetType().Elem().Name(),
+				ID:        resource.GetID(),
+				Name:      resource.GetName(),
+				Namespace: resource.GetNamespace(),
+				Cluster:   resource.GetCluster().Name,
+				Container: container,
+				Image:     image,
+				Message:   message,
+			})
+		}
+	}
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].Container != violations[j].Container {
+			return violations[i].Container < violations[j].Container
+		}
+		return violations[i].Rule < violations[j].Rule
+	})
+
+	return violations
+}
+
+// evaluateImage returns the message of every rule the image breaks, by rule
+func (p *ImagePolicy) evaluateImage(namespace string, image string) map[string]string {
+	broken := make(map[string]string)
+	reference := domain.ParseImage(image)
+	tagged := hasTag(image)
+
+	for _, registry := range p.DisallowedRegistries {
+		if reference.Registry == registry {
+			broken[RuleDisallowedRegistry] = "registry " + reference.Registry + " is not allowed"
+		}
+	}
+
+	if p.ForbidLatestTag && tagged && reference.Tag == "latest" {
+		broken[RuleLatestTag] = "the latest tag is not allowed"
+	}
+
+	if p.RequireTag && !tagged && reference.Digest == "" {
+		broken[RuleMissingTag] = "the image has no tag"
+	}
+
+	if p.RequireDigest && reference.Digest == "" {
+		broken[RuleMissingDigest] = "the image is not pinned by digest"
+	}
+
+	for _, rule := range p.AllowedTags {
+		if rule.appliesTo(namespace) && (reference.Tag == "" || !rule.pattern.MatchString(reference.Tag)) {
+			broken[RuleTagPattern] = fmt.Sprintf("tag %q does not match %s in namespace %s", reference.Tag, rule.Pattern, namespace)
+		}
+	}
+
+	return broken
+}
+
+// hasTag tells whether the image names its tag, nginx implying latest
+func hasTag(image string) bool {
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	i := strings.LastIndex(name, ":")
+	return i >= 0 && !strings.Contains(name[i+1:], "/")
+}
+
+// sortViolations sorts the violations by namespace, workload and cluster,
+// keeping the container and rule order of each workload
+func sortViolations(list []Violation) {
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Namespace != list[j].Namespace {
+			return list[i].Namespace < list[j].Namespace
+		}
+		if list[i].Name != list[j].Name {
+			return list[i].Name < list[j].Name
+		}
+		return list[i].Cluster < list[j].Cluster
+	})
+}
+
+// countByRule counts the violations by rule, with a zero for the rules only
+// broken before so their gauges are reset
+func countByRule(previous []Violation, current []Violation) map[string]float64 {
+	counts := make(map[string]float64)
+	for _, violation := range previous {
+		counts[violation.Rule] = 0
+	}
+	for _, violation := range current {
+		counts[violation.Rule]++
+	}
+	return counts
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"github.com/walmartdigital/katalog/domain"
+	"github.com/walmartdigital/katalog/server/persistence"
 )
 
 // Repository ...
@@ -10,6 +11,7 @@ type Repository interface {
 	UpdateResource(obj interface{}) (*domain.Resource, error)
 	DeleteResource(obj interface{}) error
 	GetAllResources() ([]interface{}, error)
+	GetResourcesBy(filter persistence.Filter) ([]interface{}, error)
 	GetResource(id string) (interface{}, error)
 }
 
@@ -1,7 +1,7 @@
 package repositories
 
 import (
-	"errors"
+	"reflect"
 
 	"github.com/emirpasic/gods/lists/arraylist"
 	"github.com/mitchellh/mapstructure"
@@ -35,14 +35,14 @@ func CreateResourceRepository(persistence persistence.Persistence) *ResourceRepo
 // CreateResource ...
 func (r *ResourceRepository) CreateResource(resource interface{}) error {
 	res := resource.(domain.Resource)
-	if err := r.persistence.Create(res.GetID(), res); err != nil {
+	if err := r.persistence.Create(res.GetKey(), res); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// GetResource ...
+// GetResource retrieves a resource by its key, see domain.BuildResourceKey
 func (r *ResourceRepository) GetResource(id string) (interface{}, error) {
 	resource, err := r.persistence.Get(id)
 	if err != nil {
@@ -52,27 +52,37 @@ func (r *ResourceRepository) GetResource(id string) (interface{}, error) {
 	return resource, nil
 }
 
-// UpdateResource ...
+// UpdateResource stores the resource if it is newer than the saved one, in a
+// single step when the persistence updates conditionally
 func (r *ResourceRepository) UpdateResource(resource interface{}) (*domain.Resource, error) {
 	res := resource.(domain.Resource)
-	savedResource, err := r.persistence.Get(res.GetID())
+	if updater, ok := r.persistence.(persistence.ConditionalUpdater); ok {
+		return r.updateIf(updater, res)
+	}
+
+	savedResource, err := r.persistence.Get(res.GetKey())
 	if err != nil {
 		return nil, err
 	}
 
+	// The collector republishes resources whose creation was lost as updates
 	if savedResource == nil {
 		log.WithFields(logrus.Fields{
 			"id":   res.GetID(),
 			"name": res.GetName(),
-		}).Error("Saved Resource Null")
+		}).Warn("Saved Resource Null, creating it")
 
-		return nil, errors.New("Saved Resource Null")
+		err := r.persistence.Create(res.GetKey(), res)
+		if err != nil {
+			return nil, err
+		}
+		return &res, nil
 	}
 
 	sr := savedResource.(domain.Resource)
 	if &sr != nil {
-		if sr.GetGeneration() < res.GetGeneration() {
-			err := r.persistence.Update(res.GetID(), res)
+		if isNewer(sr, res) {
+			err := r.persistence.Update(res.GetKey(), res)
 			if err != nil {
 				log.WithFields(logrus.Fields{
 					"msg": err.Error(),
@@ -85,6 +95,46 @@ func (r *ResourceRepository) UpdateResource(resource interface{}) (*domain.Resou
 	return nil, nil
 }
 
+func (r *ResourceRepository) updateIf(updater persistence.ConditionalUpdater, res domain.Resource) (*domain.Resource, error) {
+	created := false
+	stored, err := updater.UpdateIf(res.GetKey(), res, func(saved interface{}) bool {
+		created = saved == nil
+		return created || isNewer(saved.(domain.Resource), res)
+	})
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"msg": err.Error(),
+		}).Debug("Saving Resource")
+		return nil, err
+	}
+	if !stored {
+		return nil, nil
+	}
+
+	// The collector republishes resources whose creation was lost as updates
+	if created {
+		log.WithFields(logrus.Fields{
+			"id":   res.GetID(),
+			"name": res.GetName(),
+		}).Warn("Saved Resource Null, creating it")
+	}
+	return &res, nil
+}
+
+// isNewer tells whether the incoming resource supersedes the saved one. Status
+// only changes (e.g. endpoints) keep the generation, so the collector timestamp
+// breaks the tie. Collectors stamping seconds only may publish several changes
+// within one second, those are accepted when their content differs.
+func isNewer(saved domain.Resource, incoming domain.Resource) bool {
+	if saved.GetGeneration() != incoming.GetGeneration() {
+		return saved.GetGeneration() < incoming.GetGeneration()
+	}
+	if saved.GetTimestamp() != incoming.GetTimestamp() {
+		return saved.GetTimestamp() < incoming.GetTimestamp()
+	}
+	return !reflect.DeepEqual(saved.K8sResource, incoming.K8sResource)
+}
+
 // DeleteResource ...
 func (r *ResourceRepository) DeleteResource(obj interface{}) error {
 	id := obj.(string)
@@ -97,12 +147,36 @@ func (r *ResourceRepository) DeleteResource(obj interface{}) error {
 // GetAllResources ...
 func (r *ResourceRepository) GetAllResources() ([]interface{}, error) {
 	log.Info("get all resourcess called")
-	list := arraylist.New()
 	resources, err := r.persistence.GetAll()
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range resources {
+	return decodeResources(resources, persistence.Filter{})
+}
+
+// GetResourcesBy lists the resources matching the filter, pushed down to the
+// persistence when it is filterable
+func (r *ResourceRepository) GetResourcesBy(filter persistence.Filter) ([]interface{}, error) {
+	filterable, ok := r.persistence.(persistence.Filterable)
+	if !ok {
+		resources, err := r.persistence.GetAll()
+		if err != nil {
+			return nil, err
+		}
+		return decodeResources(resources, filter)
+	}
+
+	resources, err := filterable.GetAllBy(filter)
+	if err != nil {
+		return nil, err
+	}
+	return decodeResources(resources, persistence.Filter{})
+}
+
+// decodeResources decodes the persisted items, keeping those matching filter
+func decodeResources(items []interface{}, filter persistence.Filter) ([]interface{}, error) {
+	list := arraylist.New()
+	for _, item := range items {
 		var resource domain.Resource
 		err := mapstructure.Decode(item, &resource)
 		if err != nil {
@@ -111,7 +185,9 @@ func (r *ResourceRepository) GetAllResources() ([]interface{}, error) {
 			}).Debug("Saving Resource")
 			return nil, err
 		}
-		list.Add(resource)
+		if filter.Matches(resource) {
+			list.Add(resource)
+		}
 	}
 
 	return list.Values(), nil
@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/walmartdigital/katalog/domain"
+	"github.com/walmartdigital/katalog/server/persistence"
 	"github.com/walmartdigital/katalog/server/repositories"
 )
 
@@ -60,6 +61,23 @@ func (f *fakePersistence) GetAll() ([]interface{}, error) {
 	return list.Values(), nil
 }
 
+// fakeFilterablePersistence records the filters pushed down to it
+type fakeFilterablePersistence struct {
+	fakePersistence
+	filters []persistence.Filter
+}
+
+func (f *fakeFilterablePersistence) GetAllBy(filter persistence.Filter) ([]interface{}, error) {
+	f.filters = append(f.filters, filter)
+	list := arraylist.New()
+	for _, value := range f.memory {
+		if filter.Matches(value.(domain.Resource)) {
+			list.Add(value)
+		}
+	}
+	return list.Values(), nil
+}
+
 func TestAll(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "service repository")
@@ -105,6 +123,22 @@ var _ = Describe("create resource", func() {
 		Expect(allDeployments[0]).To(Equal(resource))
 	})
 
+	It("should keep resources sharing an ID apart when they come from different clusters", func() {
+		id := "10174c96-a835-4e9e-b49e-9085f6e63368"
+		east := domain.Resource{K8sResource: &domain.Deployment{ID: id, Cluster: &domain.Cluster{Name: "east"}}}
+		west := domain.Resource{K8sResource: &domain.Deployment{ID: id, Cluster: &domain.Cluster{Name: "west"}}}
+		memory := make(map[string]interface{})
+		fake := fakePersistence{memory: memory, fail: false}
+		resourceRepository := repositories.CreateResourceRepository(&fake)
+
+		resourceRepository.CreateResource(east)
+		resourceRepository.CreateResource(west)
+
+		Expect(memory).To(HaveLen(2))
+		Expect(memory["east/"+id]).To(Equal(east))
+		Expect(memory["west/"+id]).To(Equal(west))
+	})
+
 	It("should fail if missing id in service resource", func() {
 		resource := domain.Resource{K8sResource: &domain.Deployment{}}
 		memory := make(map[string]interface{})
@@ -220,6 +254,87 @@ var _ = Describe("update service resource", func() {
 		Expect(r).To(BeNil())
 	})
 
+	It("should update a given service resource with the same Generation but a newer Timestamp", func() {
+		r1 := domain.Resource{K8sResource: &domain.Service{ID: "10174c96-a835-4e9e-b49e-9085f6e63368", Timestamp: "2020-10-01 10:00:00"}}
+		r2 := domain.Resource{K8sResource: &domain.Service{ID: "10174c96-a835-4e9e-b49e-9085f6e63368", Timestamp: "2020-10-01 10:00:05"}}
+
+		memory := make(map[string]interface{})
+		fake := fakePersistence{memory: memory}
+		resourceRepository := repositories.CreateResourceRepository(&fake)
+		resourceRepository.CreateResource(r1)
+
+		r, error := resourceRepository.UpdateResource(r2)
+
+		Expect(error).To(BeNil())
+		Expect(r2).To(Equal(*r))
+	})
+
+	It("should update a given service resource with the same Generation and Timestamp when its content changed", func() {
+		r1 := domain.Resource{K8sResource: &domain.Service{ID: "10174c96-a835-4e9e-b49e-9085f6e63368", Timestamp: "2020-10-01 10:00:00",
+			Instances: []domain.Instance{{Address: "10.0.0.1"}}}}
+		r2 := domain.Resource{K8sResource: &domain.Service{ID: "10174c96-a835-4e9e-b49e-9085f6e63368", Timestamp: "2020-10-01 10:00:00",
+			Instances: []domain.Instance{{Address: "10.0.0.1"}, {Address: "10.0.0.2"}}}}
+
+		memory := make(map[string]interface{})
+		fake := fakePersistence{memory: memory}
+		resourceRepository := repositories.CreateResourceRepository(&fake)
+		resourceRepository.CreateResource(r1)
+
+		r, error := resourceRepository.UpdateResource(r2)
+
+		Expect(error).To(BeNil())
+		Expect(r2).To(Equal(*r))
+	})
+
+	It("should order the nanosecond Timestamps within a second, after the second precision ones", func() {
+		r1 := domain.Resource{K8sResource: &domain.Service{ID: "10174c96-a835-4e9e-b49e-9085f6e63368", Timestamp: "2020-10-01 10:00:00"}}
+		r2 := domain.Resource{K8sResource: &domain.Service{ID: "10174c96-a835-4e9e-b49e-9085f6e63368", Timestamp: "2020-10-01 10:00:00.200000000"}}
+		stale := domain.Resource{K8sResource: &domain.Service{ID: "10174c96-a835-4e9e-b49e-9085f6e63368", Timestamp: "2020-10-01 10:00:00.100000000",
+			Instances: []domain.Instance{{Address: "10.0.0.1"}}}}
+
+		memory := make(map[string]interface{})
+		fake := fakePersistence{memory: memory}
+		resourceRepository := repositories.CreateResourceRepository(&fake)
+		resourceRepository.CreateResource(r1)
+
+		r, error := resourceRepository.UpdateResource(r2)
+		Expect(error).To(BeNil())
+		Expect(r2).To(Equal(*r))
+
+		r, error = resourceRepository.UpdateResource(stale)
+		Expect(error).To(BeNil())
+		Expect(r).To(BeNil())
+	})
+
+	It("should not update a given service resource with an older Generation even if the Timestamp is newer", func() {
+		r1 := domain.Resource{K8sResource: &domain.Service{ID: "10174c96-a835-4e9e-b49e-9085f6e63368", Generation: 2, Timestamp: "2020-10-01 10:00:00"}}
+		r2 := domain.Resource{K8sResource: &domain.Service{ID: "10174c96-a835-4e9e-b49e-9085f6e63368", Generation: 1, Timestamp: "2020-10-01 10:00:05"}}
+
+		memory := make(map[string]interface{})
+		fake := fakePersistence{memory: memory}
+		resourceRepository := repositories.CreateResourceRepository(&fake)
+		resourceRepository.CreateResource(r1)
+
+		r, error := resourceRepository.UpdateResource(r2)
+
+		Expect(error).To(BeNil())
+		Expect(r).To(BeNil())
+	})
+
+	It("should create a service resource updated before being created", func() {
+		r1 := domain.Resource{K8sResource: &domain.Service{ID: "10174c96-a835-4e9e-b49e-9085f6e63368", Generation: 1}}
+
+		memory := make(map[string]interface{})
+		fake := fakePersistence{memory: memory}
+		resourceRepository := repositories.CreateResourceRepository(&fake)
+
+		r, error := resourceRepository.UpdateResource(r1)
+
+		Expect(error).To(BeNil())
+		Expect(*r).To(Equal(r1))
+		Expect(memory["10174c96-a835-4e9e-b49e-9085f6e63368"]).To(Equal(r1))
+	})
+
 	It("should fail if missing id for service resource", func() {
 		id := "10174c96-a835-4e9e-b49e-9085f6e63368"
 		resource := domain.Resource{K8sResource: &domain.Service{ID: id}}
@@ -264,3 +379,47 @@ var _ = Describe("get all resources", func() {
 		Expect(results).To(BeNil())
 	})
 })
+
+var _ = Describe("get resources by filter", func() {
+	var memory map[string]interface{}
+
+	BeforeEach(func() {
+		memory = map[string]interface{}{
+			"s1": domain.Resource{K8sResource: &domain.Service{ID: "s1", Name: "catalog", Namespace: "default"}},
+			"d1": domain.Resource{K8sResource: &domain.Deployment{ID: "d1", Name: "catalog", Namespace: "default"}},
+			"d2": domain.Resource{K8sResource: &domain.Deployment{ID: "d2", Name: "catalog", Namespace: "kube-system"}},
+		}
+	})
+
+	It("should push the filter down to a filterable persistence", func() {
+		fake := fakeFilterablePersistence{fakePersistence: fakePersistence{memory: memory}}
+		resourceRepository := repositories.CreateResourceRepository(&fake)
+		filter := persistence.Filter{Kind: "Deployment", Namespace: "default"}
+
+		results, err := resourceRepository.GetResourcesBy(filter)
+
+		Expect(err).To(BeNil())
+		Expect(fake.filters).To(Equal([]persistence.Filter{filter}))
+		Expect(results).To(Equal([]interface{}{memory["d1"]}))
+	})
+
+	It("should filter the resources of other persistences once read", func() {
+		fake := fakePersistence{memory: memory}
+		resourceRepository := repositories.CreateResourceRepository(&fake)
+
+		results, err := resourceRepository.GetResourcesBy(persistence.Filter{Kind: "Deployment", Namespace: "default"})
+
+		Expect(err).To(BeNil())
+		Expect(results).To(Equal([]interface{}{memory["d1"]}))
+	})
+
+	It("should return an error when listing from persistence fails", func() {
+		fake := fakePersistence{memory: memory, fail: true}
+		resourceRepository := repositories.CreateResourceRepository(&fake)
+
+		results, err := resourceRepository.GetResourcesBy(persistence.Filter{Kind: "Deployment"})
+
+		Expect(err).NotTo(BeNil())
+		Expect(results).To(BeNil())
+	})
+})
@@ -22,6 +22,7 @@ func init() {
 type Service struct {
 	resourcesRepository repositories.Repository
 	metrics             Metrics
+	policy              *ImagePolicy
 }
 
 // MakeService ...
@@ -32,141 +33,109 @@ func MakeService(resourcesRepository repositories.Repository, metricsfactory Met
 	}
 }
 
-// CreateService ...
-func (s *Service) CreateService(service domain.Service) error {
-	log.WithFields(logrus.Fields{
-		"id":   service.GetID(),
-		"name": service.GetName(),
-	}).Debug("Creating Service")
-
-	resource := domain.Resource{
-		K8sResource: &service,
-	}
-
-	errCreatingResource := s.resourcesRepository.CreateResource(resource)
-	if errCreatingResource != nil {
-		log.WithFields(logrus.Fields{
-			"msg": errCreatingResource.Error(),
-		}).Error("Creating Service")
-
-		return errCreatingResource
-	}
-
-	return nil
+// GetRepository returns the repository the service stores the catalog in
+func (s *Service) GetRepository() repositories.Repository {
+	return s.resourcesRepository
 }
 
-// UpdateService ...
-func (s *Service) UpdateService(service domain.Service) error {
-	log.WithFields(logrus.Fields{
-		"id":   service.GetID(),
-		"name": service.GetName(),
-	}).Debug("Updating Service")
-
-	resource := domain.Resource{K8sResource: &service}
+// policyKinds are the kinds whose images are checked against the policy
+var policyKinds = map[string]bool{"Deployment": true, "StatefulSet": true}
 
-	_, err := s.resourcesRepository.UpdateResource(resource)
-
-	if err != nil {
-		log.Errorf("Error occurred trying to update service (id: %s)", resource.GetID())
-		return err
-	}
-
-	return nil
+// SetImagePolicy makes the service check the images of the deployments and
+// statefulsets it creates or updates against the policy
+func (s *Service) SetImagePolicy(policy *ImagePolicy) {
+	s.policy = policy
 }
 
-// DeleteService ...
-func (s *Service) DeleteService(id string) error {
-	log.WithFields(logrus.Fields{
-		"id": id,
-	}).Debug("Deleting Service")
-
-	_, err := s.resourcesRepository.GetResource(id)
-	if err != nil {
-		log.Errorf("You provided a non-existing ID: %s", id)
-		return err
+// GetViolations checks the stored deployments and statefulsets against the
+// current image policy and lists their violations
+func (s *Service) GetViolations() ([]Violation, error) {
+	list := make([]Violation, 0)
+	if s.policy == nil {
+		return list, nil
 	}
 
-	err = s.resourcesRepository.DeleteResource(id)
+	resources, err := s.resourcesRepository.GetAllResources()
 	if err != nil {
-		log.Errorf("Deleted service ID: %s", id)
-		return err
+		return nil, err
+	}
+	for _, r := range resources {
+		resource := r.(domain.Resource)
+		kind, ok := domain.KindOf(resource.K8sResource)
+		if !ok {
+			continue
+		}
+		list = append(list, s.violationsOf(kind, resource)...)
 	}
+	sortViolations(list)
 
-	return nil
+	return list, nil
 }
 
-// CreateDeployment ...
-func (s *Service) CreateDeployment(deployment domain.Deployment) error {
-	log.WithFields(logrus.Fields{
-		"id":   deployment.GetID(),
-		"name": deployment.GetName(),
-	}).Debug("Creating Deployment")
+type containerized interface {
+	GetContainers() map[string]string
+}
 
-	resource := domain.Resource{K8sResource: &deployment}
+// CreateResource ...
+func (s *Service) CreateResource(kind domain.Kind, resource domain.Resource) error {
+	log.WithFields(logrus.Fields{
+		"id":   resource.GetID(),
+		"name": resource.GetName(),
+	}).Debugf("Creating %s", kind.Name)
 
 	err := s.resourcesRepository.CreateResource(resource)
 	if err != nil {
 		log.WithFields(logrus.Fields{
 			"msg": err.Error(),
-		}).Error("Creating Deployment")
+		}).Errorf("Creating %s", kind.Name)
 		return err
 	}
 
-	log.WithFields(logrus.Fields{
-		"k8s-resource-id":                    resource.GetID(),
-		"k8s-resource-type":                  "Deployment",
-		"k8s-resource-ns":                    resource.GetNamespace(),
-		"k8s-resource-name":                  resource.GetName(),
-		"k8s-resource-labels":                resource.GetLabels(),
-		"k8s-resource-annotations":           resource.GetAnnotations(),
-		"k8s-resource-generation":            resource.GetGeneration(),
-		"k8s-pod-template.containers.images": utils.ContainersToString(deployment.GetContainers()),
-		"k8s-action":                         "create",
-	}).Infof("Deployment %s/%s created", resource.GetNamespace(), resource.GetName())
-	s.metrics.IncrementCounter("createDeployment", resource.GetID(), resource.GetNamespace(), resource.GetName())
+	if !kind.Silent {
+		logResource(kind, resource, "create").Infof("%s %s/%s created", kind.Name, resource.GetNamespace(), resource.GetName())
+		s.metrics.IncrementCounter(kind.MetricName("create"), resource.GetID(), resource.GetNamespace(), resource.GetName())
+	}
+	s.evaluatePolicy(kind, resource, nil, "create")
 
 	return nil
 }
 
-// UpdateDeployment ...
-func (s *Service) UpdateDeployment(deployment domain.Deployment) error {
+// UpdateResource ...
+func (s *Service) UpdateResource(kind domain.Kind, resource domain.Resource) error {
 	log.WithFields(logrus.Fields{
-		"id":   deployment.GetID(),
-		"name": deployment.GetName(),
-	}).Debug("Updating Deployment")
+		"id":   resource.GetID(),
+		"name": resource.GetName(),
+	}).Debugf("Updating %s", kind.Name)
 
-	resource := domain.Resource{K8sResource: &deployment}
+	previous := s.savedViolations(kind, resource.GetKey())
 
 	result, err := s.resourcesRepository.UpdateResource(resource)
 	if err != nil {
-		log.Errorf("Error occurred trying to update deployment (id: %s)", resource.GetID())
+		log.Errorf("Error occurred trying to update %s (id: %s)", kind.Label, resource.GetID())
 		return err
 	}
 
-	log.WithFields(logrus.Fields{
-		"k8s-resource-id":                    resource.GetID(),
-		"k8s-resource-type":                  "Deployment",
-		"k8s-resource-ns":                    resource.GetNamespace(),
-		"k8s-resource-name":                  resource.GetName(),
-		"k8s-resource-labels":                resource.GetLabels(),
-		"k8s-resource-annotations":           resource.GetAnnotations(),
-		"k8s-resource-generation":            resource.GetGeneration(),
-		"k8s-pod-template.containers.images": utils.ContainersToString(deployment.GetContainers()),
-		"k8s-action":                         "update",
-	}).Infof("Deployment %s/%s updated", resource.GetNamespace(), resource.GetName())
-
-	if result != nil {
-		s.metrics.IncrementCounter("updateDeployment", resource.GetID(), resource.GetNamespace(), resource.GetName())
+	// Stale updates are not saved, so nothing changed
+	if result == nil {
+		return nil
 	}
 
+	if !kind.Silent {
+		logResource(kind, resource, "update").Infof("%s %s/%s updated", kind.Name, resource.GetNamespace(), resource.GetName())
+		s.metrics.IncrementCounter(kind.MetricName("update"), resource.GetID(), resource.GetNamespace(), resource.GetName())
+	}
+	s.evaluatePolicy(kind, resource, previous, "update")
+
 	return nil
 }
 
-// DeleteDeployment ...
-func (s *Service) DeleteDeployment(id string) error {
+// DeleteResource deletes the resource saved under the key, refusing to
+// delete a resource of another kind. Deleting a missing resource fails
+// unless the kind is silent
+func (s *Service) DeleteResource(kind domain.Kind, id string) error {
 	log.WithFields(logrus.Fields{
 		"id": id,
-	}).Debug("Deleting Deployment")
+	}).Debugf("Deleting %s", kind.Name)
 
 	res, err := s.resourcesRepository.GetResource(id)
 	if err != nil {
@@ -174,145 +143,125 @@ func (s *Service) DeleteDeployment(id string) error {
 		return err
 	}
 
-	if res == nil {
+	if res == nil && !kind.Silent {
 		log.WithFields(logrus.Fields{
 			"id": id,
-		}).Error("Delete Deployment Resource is null")
+		}).Errorf("Delete %s Resource is null", kind.Name)
+
+		return errors.New("Delete " + kind.Name + " Resource null:" + id)
+	}
 
-		return errors.New("Delete Deployment Resource null:" + id)
+	rep, isResource := res.(domain.Resource)
+	if isResource && rep.K8sResource != nil && rep.GetType() != kind.Type() {
+		return errors.New("Delete " + kind.Name + " Resource is not a " + kind.Label + ":" + id)
 	}
 
-	rep := res.(domain.Resource)
 	err = s.resourcesRepository.DeleteResource(id)
 	if err != nil {
-		log.Errorf("Deleted deployment ID: %s", id)
+		log.Errorf("Deleted %s ID: %s", kind.Label, id)
 		return err
 	}
 
-	log.WithFields(logrus.Fields{
-		"k8s-resource-id":          rep.GetID(),
-		"k8s-resource-type":        "Deployment",
-		"k8s-resource-ns":          rep.GetNamespace(),
-		"k8s-resource-name":        rep.GetName(),
-		"k8s-resource-labels":      rep.GetLabels(),
-		"k8s-resource-annotations": rep.GetAnnotations(),
-		"k8s-resource-generation":  rep.GetGeneration(),
-		"k8s-action":               "delete",
-	}).Infof("Deployment %s/%s deleted", rep.GetNamespace(), rep.GetName())
-
-	s.metrics.IncrementCounter("deleteDeployment", id, rep.GetNamespace(), rep.GetName())
-
-	return nil
-}
-
-// CreateStatefulSet ...
-func (s *Service) CreateStatefulSet(statefulset domain.StatefulSet) error {
-	log.WithFields(logrus.Fields{
-		"id":   statefulset.GetID(),
-		"name": statefulset.GetName(),
-	}).Debug("Creating Statefulset")
-
-	resource := domain.Resource{K8sResource: &statefulset}
-
-	err := s.resourcesRepository.CreateResource(resource)
-	if err != nil {
-		log.WithFields(logrus.Fields{
-			"msg": err.Error(),
-		}).Error("Create StatefulSet")
-		return err
+	if !isResource || rep.K8sResource == nil {
+		if !kind.Silent {
+			s.metrics.IncrementCounter(kind.MetricName("delete"), id, "", "")
+		}
+		return nil
 	}
 
-	log.WithFields(logrus.Fields{
-		"k8s-resource-id":                    resource.GetID(),
-		"k8s-resource-type":                  "StatefulSet",
-		"k8s-resource-ns":                    resource.GetNamespace(),
-		"k8s-resource-name":                  resource.GetName(),
-		"k8s-resource-labels":                resource.GetLabels(),
-		"k8s-resource-annotations":           resource.GetAnnotations(),
-		"k8s-resource-generation":            resource.GetGeneration(),
-		"k8s-pod-template.containers.images": utils.ContainersToString(statefulset.GetContainers()),
-		"k8s-action":                         "create",
-	}).Infof("Statefulset %s/%s created", resource.GetNamespace(), resource.GetName())
-
-	s.metrics.IncrementCounter("createStatefulSet", resource.GetID(), resource.GetNamespace(), resource.GetName())
+	if !kind.Silent {
+		logResource(kind, rep, "delete").Infof("%s %s/%s deleted", kind.Name, rep.GetNamespace(), rep.GetName())
+		s.metrics.IncrementCounter(kind.MetricName("delete"), id, rep.GetNamespace(), rep.GetName())
+	}
+	s.deleteViolations(kind, rep)
 
 	return nil
 }
 
-// UpdateStatefulSet ...
-func (s *Service) UpdateStatefulSet(statefulset domain.StatefulSet) error {
-	log.WithFields(logrus.Fields{
-		"id":   statefulset.GetID(),
-		"name": statefulset.GetName(),
-	}).Debug("Updating Statefulset")
+// violationsOf checks the images of the resource against the image policy
+func (s *Service) violationsOf(kind domain.Kind, resource domain.Resource) []Violation {
+	if s.policy == nil || !policyKinds[kind.Name] || resource.K8sResource == nil {
+		return nil
+	}
+	return s.policy.Evaluate(resource)
+}
 
-	resource := domain.Resource{K8sResource: &statefulset}
+// savedViolations returns the violations of the resource saved under the key,
+// before an update replaces it
+func (s *Service) savedViolations(kind domain.Kind, key string) []Violation {
+	if s.policy == nil || !policyKinds[kind.Name] {
+		return nil
+	}
 
-	result, err := s.resourcesRepository.UpdateResource(resource)
+	saved, err := s.resourcesRepository.GetResource(key)
+	if err != nil || saved == nil {
+		return nil
+	}
+	resource, ok := saved.(domain.Resource)
+	if !ok {
+		return nil
+	}
+	return s.violationsOf(kind, resource)
+}
 
-	if err != nil {
-		log.Errorf("Error occurred trying to update resource (id: %s)", resource.GetID())
-		return err
+// evaluatePolicy checks the images of a created or updated resource against
+// the image policy, logging its violations and resetting the gauges of the
+// rules only the previous version broke
+func (s *Service) evaluatePolicy(kind domain.Kind, resource domain.Resource, previous []Violation, action string) {
+	if s.policy == nil || !policyKinds[kind.Name] {
+		return
 	}
 
-	log.WithFields(logrus.Fields{
-		"k8s-resource-id":                    resource.GetID(),
-		"k8s-resource-type":                  "StatefulSet",
-		"k8s-resource-ns":                    resource.GetNamespace(),
-		"k8s-resource-name":                  resource.GetName(),
-		"k8s-resource-labels":                resource.GetLabels(),
-		"k8s-resource-annotations":           resource.GetAnnotations(),
-		"k8s-resource-generation":            resource.GetGeneration(),
-		"k8s-pod-template.containers.images": utils.ContainersToString(statefulset.GetContainers()),
-		"k8s-action":                         "update",
-	}).Infof("Statefulset %s/%s updated", resource.GetNamespace(), resource.GetName())
-
-	if result != nil {
-		s.metrics.IncrementCounter("updateStatefulSet", resource.GetID(), resource.GetNamespace(), resource.GetName())
+	violations := s.violationsOf(kind, resource)
+	for _, violation := range violations {
+		logResource(kind, resource, action).WithFields(logrus.Fields{
+			"policy-rule":      violation.Rule,
+			"policy-container": violation.Container,
+			"policy-image":     violation.Image,
+		}).Warnf("%s %s/%s violates the image policy: %s", kind.Name, resource.GetNamespace(), resource.GetName(), violation.Message)
 	}
 
-	return nil
+	s.reportViolations(kind, resource, previous, violations)
 }
 
-// DeleteStatefulSet ...
-func (s *Service) DeleteStatefulSet(id string) error {
-	log.WithFields(logrus.Fields{
-		"id": id,
-	}).Debug("Deleting Statefulset")
-
-	res, err := s.resourcesRepository.GetResource(id)
-	if err != nil {
-		log.Error("You have to provide an ID")
-		return err
+func (s *Service) reportViolations(kind domain.Kind, resource domain.Resource, previous []Violation, current []Violation) {
+	for rule, count := range countByRule(previous, current) {
+		s.metrics.SetGauge(violationsMetric, count, rule, kind.Name, resource.GetCluster().Name, resource.GetNamespace(), resource.GetName())
 	}
+}
 
-	if res == nil {
-		log.WithFields(logrus.Fields{
-			"id": id,
-		}).Error("Delete StatefulSet Resource is null")
+// deleteViolations removes the gauges of a deleted resource, so its
+// violations do not outlive it
+func (s *Service) deleteViolations(kind domain.Kind, resource domain.Resource) {
+	if s.policy == nil || !policyKinds[kind.Name] {
+		return
+	}
+	for _, rule := range rules {
+		s.metrics.DeleteGauge(violationsMetric, rule, kind.Name, resource.GetCluster().Name, resource.GetNamespace(), resource.GetName())
+	}
+}
 
-		return errors.New("Delete StatefulSet Resource null:" + id)
+func logResource(kind domain.Kind, resource domain.Resource, action string) *logrus.Entry {
+	fields := logrus.Fields{
+		"k8s-resource-id":          resource.GetID(),
+		"k8s-resource-type":        kind.Name,
+		"k8s-resource-ns":          resource.GetNamespace(),
+		"k8s-resource-name":        resource.GetName(),
+		"k8s-resource-labels":      resource.GetLabels(),
+		"k8s-resource-annotations": resource.GetAnnotations(),
+		"k8s-resource-generation":  resource.GetGeneration(),
+		"k8s-action":               action,
 	}
 
-	rep := res.(domain.Resource)
-	err = s.resourcesRepository.DeleteResource(id)
-	if err != nil {
-		log.Error("deleted statefulset id:" + id)
-		return err
+	if customResource, ok := resource.K8sResource.(*domain.CustomResource); ok {
+		fields["k8s-resource-type"] = customResource.GetKind()
+		fields["k8s-resource-group"] = customResource.GetGroup()
+		fields["k8s-resource-fields"] = customResource.GetFields()
 	}
 
-	log.WithFields(logrus.Fields{
-		"k8s-resource-id":          rep.GetID(),
-		"k8s-resource-type":        "StatefulSet",
-		"k8s-resource-ns":          rep.GetNamespace(),
-		"k8s-resource-name":        rep.GetName(),
-		"k8s-resource-labels":      rep.GetLabels(),
-		"k8s-resource-annotations": rep.GetAnnotations(),
-		"k8s-resource-generation":  rep.GetGeneration(),
-		"k8s-action":               "delete",
-	}).Infof("Statefulset %s/%s deleted", rep.GetNamespace(), rep.GetName())
-
-	s.metrics.IncrementCounter("deleteStatefulSet", id, rep.GetNamespace(), rep.GetName())
+	if workload, ok := resource.K8sResource.(containerized); ok && action != "delete" {
+		fields["k8s-pod-template.containers.images"] = utils.ContainersToString(workload.GetContainers())
+	}
 
-	return nil
+	return log.WithFields(fields)
 }